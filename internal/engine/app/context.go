@@ -9,9 +9,13 @@ import (
 	"github.com/leandroatallah/firefly/internal/engine/contracts/navigation"
 	"github.com/leandroatallah/firefly/internal/engine/data/config"
 	"github.com/leandroatallah/firefly/internal/engine/data/datamanager"
+	"github.com/leandroatallah/firefly/internal/engine/data/flags"
 	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
 	"github.com/leandroatallah/firefly/internal/engine/event"
+	"github.com/leandroatallah/firefly/internal/engine/render/lighting"
+	"github.com/leandroatallah/firefly/internal/engine/render/postfx"
 	"github.com/leandroatallah/firefly/internal/engine/scene/phases"
+	"github.com/leandroatallah/firefly/internal/engine/ui/caption"
 	"github.com/leandroatallah/firefly/internal/engine/ui/speech"
 )
 
@@ -24,13 +28,18 @@ type AppContext struct {
 	ImageManager    *imagemanager.ImageManager
 	DataManager     *datamanager.Manager
 	DialogueManager *speech.Manager
+	Captions        *caption.Manager
+	PostFX          *postfx.Pipeline
 	EventManager    *event.Manager
 	ActorManager    *actors.Manager
 	SceneManager    navigation.SceneManager
 	PhaseManager    *phases.Manager
+	Flags           *flags.Flags
 	Assets          fs.FS
 	Config          *config.AppConfig
 	Space           body.BodiesSpace
+	// Lighting of the current phase, if any
+	Lighting *lighting.System
 }
 
 // AppContextHolder is a reusable component for embedding app context
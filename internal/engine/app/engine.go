@@ -29,22 +29,39 @@ func (g *Game) Update() error {
 		g.debugVisible = !g.debugVisible
 	}
 
-	// Update Dialogue Manager
-	if g.AppContext.DialogueManager != nil {
-		g.AppContext.DialogueManager.Update()
+	// Music ducks under dialogue
+	if am := g.AppContext.AudioManager; am != nil {
+		am.SetDucked(g.AppContext.DialogueManager != nil && g.AppContext.DialogueManager.IsSpeaking())
+		am.Update()
 	}
 
-	// Then, update the current scene
+	if g.AppContext.Captions != nil {
+		g.AppContext.Captions.Update()
+	}
+
+	if g.AppContext.PostFX != nil {
+		g.AppContext.PostFX.Update()
+	}
+
+	// Then, update the scene stack. Dialogues run in an overlay scene
 	g.AppContext.SceneManager.Update()
 	return nil
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
-	g.AppContext.SceneManager.Draw(screen)
+	// Scenes are drawn through the post-processing passes, captions and
+	// debug info over them
+	target := screen
+	if g.AppContext.PostFX != nil {
+		target = g.AppContext.PostFX.Target(screen)
+	}
+	g.AppContext.SceneManager.Draw(target)
+	if g.AppContext.PostFX != nil {
+		g.AppContext.PostFX.Apply(screen)
+	}
 
-	// Draw Dialogue Manager
-	if g.AppContext.DialogueManager != nil {
-		g.AppContext.DialogueManager.Draw(screen)
+	if g.AppContext.Captions != nil {
+		g.AppContext.Captions.Draw(screen)
 	}
 
 	if g.debugVisible {
@@ -71,6 +88,7 @@ func (g *Game) DebugPhysics(screen *ebiten.Image) {
 	fmt.Fprintf(&b, "UpwardGravity: %d\n", cfg.UpwardGravity)
 	fmt.Fprintf(&b, "DownwardGravity: %d\n", cfg.DownwardGravity)
 	fmt.Fprintf(&b, "MaxFallSpeed: %d\n", cfg.MaxFallSpeed)
+	fmt.Fprintf(&b, "MaxStepHeight: %d\n", cfg.MaxStepHeight)
 
 	text.Draw(screen, b.String(), g.debugFontFace, 5, 15, color.White)
 
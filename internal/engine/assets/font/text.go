@@ -10,6 +10,24 @@ import (
 
 type FontText struct {
 	source *text.GoTextFaceSource
+
+	// Faces per size, rebuilt when fallbacks are added
+	faces     map[float64]text.Face
+	fallbacks int
+}
+
+// fallbacks draw the glyphs missing in a font, in order.
+var fallbacks []*text.GoTextFaceSource
+
+// AddFallback adds a font, from its TTF or OTF data, used for glyphs that
+// fonts don't cover.
+func AddFallback(data []byte) error {
+	src, err := text.NewGoTextFaceSource(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	fallbacks = append(fallbacks, src)
+	return nil
 }
 
 func NewFontText(path string) (*FontText, error) {
@@ -29,8 +47,39 @@ func (t *FontText) Draw(screen *ebiten.Image, msg string, size float64, op *text
 		return
 	}
 
-	text.Draw(screen, msg, &text.GoTextFace{
-		Source: t.source,
-		Size:   size,
-	}, op)
+	text.Draw(screen, msg, t.face(size), op)
+}
+
+// face returns the font at size, backed by the fallback fonts.
+func (t *FontText) face(size float64) text.Face {
+	if t.faces == nil || t.fallbacks != len(fallbacks) {
+		t.faces = make(map[float64]text.Face)
+		t.fallbacks = len(fallbacks)
+	}
+	if f, ok := t.faces[size]; ok {
+		return f
+	}
+
+	var face text.Face = &text.GoTextFace{Source: t.source, Size: size}
+	if len(fallbacks) > 0 {
+		faces := []text.Face{face}
+		for _, src := range fallbacks {
+			faces = append(faces, &text.GoTextFace{Source: src, Size: size})
+		}
+		if multi, err := text.NewMultiFace(faces...); err == nil {
+			face = multi
+		}
+	}
+	t.faces[size] = face
+	return face
+}
+
+// Measure returns the width and height of msg drawn at size, with lines
+// spaced by lineSpacing pixels.
+func (t *FontText) Measure(msg string, size float64, lineSpacing float64) (float64, float64) {
+	if t.source == nil {
+		return 0, 0
+	}
+
+	return text.Measure(msg, t.face(size), lineSpacing)
 }
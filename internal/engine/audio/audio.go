@@ -2,6 +2,7 @@ package audio
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"io/fs"
 	"log"
@@ -14,237 +15,526 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
 	"github.com/hajimehoshi/ebiten/v2/audio/wav"
 	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	"github.com/leandroatallah/firefly/internal/engine/utils/timing"
 )
 
 const (
 	sampleRate = 44100
 )
 
-type AudioItem struct {
-	name string
-	data []byte
-}
+// Default voice limits. When a limit is reached the oldest voice is stolen.
+const (
+	DefaultMaxVoices         = 16
+	DefaultMaxVoicesPerSound = 4
+)
 
-func (a *AudioItem) Name() string {
-	return a.name
-}
-func (a *AudioItem) Data() []byte {
-	return a.data
+// bytesPerSecond is the size of one second of decoded 16-bit stereo PCM.
+const bytesPerSecond = sampleRate * 4
+
+// LoopPoints delimit the section of a music track that repeats after the
+// intro. A zero End loops until the end of the track.
+type LoopPoints struct {
+	Start time.Duration
+	End   time.Duration
 }
 
+// AudioManager plays music and sound effects through the mixer buses. Sound
+// effects are polyphonic, music crossfades between tracks and every fade is
+// driven by Update, once per frame.
+//
+// Files are read from the asset FS when needed: music is streamed and sound
+// effects are decoded once and cached within a memory budget.
 type AudioManager struct {
 	audioContext *audio.Context
-	audioPlayers map[string]*audio.Player
-	volume       float64
+	fsys         fs.FS
+	cache        *soundCache
+	loops        map[string]LoopPoints
+	mixer        *mixer
+
+	music  *voice
+	voices []*voice // Sound effects, UI sounds and voices, plus music fading out
+
+	MaxVoices         int
+	MaxVoicesPerSound int
+
+	// Positional sounds are heard from the listener, usually the camera
+	// center, and are silent beyond HearingRange.
+	listenerX, listenerY float64
+	HearingRange         float64
+
+	frame int
+
+	onPlay []func(p Playback)
+}
+
+// Playback describes a sound that started playing, for listeners like
+// captions.
+type Playback struct {
+	Name     string
+	Bus      Bus
+	Duration time.Duration // Zero for music, which loops
+
+	// Position of a positional sound, following Source when it is set
+	Positional bool
+	X, Y       float64
+	Source     Source
 }
 
 func NewAudioManager() *AudioManager {
-	initialVolume := 1.0
+	m := newMixer()
 	if config.Get().NoSound {
-		initialVolume = 0.0
+		m.setVolume(BusMaster, 0)
 	}
 	return &AudioManager{
-		audioContext: audio.NewContext(sampleRate),
-		audioPlayers: make(map[string]*audio.Player),
-		volume:       initialVolume,
+		audioContext:      audio.NewContext(sampleRate),
+		fsys:              os.DirFS("."),
+		cache:             newSoundCache(DefaultSoundBudget),
+		loops:             make(map[string]LoopPoints),
+		mixer:             m,
+		MaxVoices:         DefaultMaxVoices,
+		MaxVoicesPerSound: DefaultMaxVoicesPerSound,
+		HearingRange:      float64(config.Get().ScreenWidth),
 	}
 }
 
-func (am *AudioManager) Load(path string) (*AudioItem, error) {
-	f, err := os.Open(path)
-	if err != nil {
-		return nil, err
+// OnPlay registers a function called every time a sound or a music track
+// starts playing.
+func (am *AudioManager) OnPlay(fn func(p Playback)) {
+	am.onPlay = append(am.onPlay, fn)
+}
+
+func (am *AudioManager) notifyPlay(p Playback) {
+	for _, fn := range am.onPlay {
+		fn(p)
 	}
-	defer f.Close()
+}
 
-	stat, err := f.Stat()
+// SetFS sets the file system audio files are read from.
+func (am *AudioManager) SetFS(fsys fs.FS) {
+	am.fsys = fsys
+}
+
+// SetSoundBudget sets the memory, in bytes, decoded sound effects can use.
+// Sounds larger than the budget are streamed like music.
+func (am *AudioManager) SetSoundBudget(bytes int64) {
+	am.cache.budget = bytes
+	am.cache.evict()
+}
+
+// LoadManifest reads a manifest and decodes its sounds, which stay resident
+// until the manifest is unloaded.
+func (am *AudioManager) LoadManifest(path string) (*Manifest, error) {
+	m, err := ReadManifest(am.fsys, path)
 	if err != nil {
 		return nil, err
 	}
-	bs := make([]byte, stat.Size())
-	_, err = io.ReadFull(f, bs)
-	if err != nil {
-		return nil, err
+
+	for _, music := range m.Music {
+		if loop := music.LoopPoints(); loop != nil {
+			am.loops[music.Path] = *loop
+		}
 	}
+	for i, name := range m.Sounds {
+		if am.cache.pin(name) {
+			continue
+		}
+		pcm, err := am.decodeAll(name)
+		if err != nil {
+			// Release what was loaded, as the manifest won't be unloaded
+			am.UnloadManifest(&Manifest{Sounds: m.Sounds[:i]})
+			return nil, err
+		}
+		am.cache.put(name, pcm, true)
+	}
+	return m, nil
+}
 
-	return &AudioItem{path, bs}, nil
+// UnloadManifest releases the sounds of a manifest. Music keeps playing until
+// it is stopped, so it can cross over to the next scene.
+func (am *AudioManager) UnloadManifest(m *Manifest) {
+	if m == nil {
+		return
+	}
+	for _, name := range m.Sounds {
+		am.cache.unpin(name)
+	}
 }
 
-func (am *AudioManager) LoadFromFS(fs fs.FS, path string) (*AudioItem, error) {
-	f, err := fs.Open(path)
+// open starts decoding a file from the asset FS. The returned closer releases the file.
+func (am *AudioManager) open(name string) (stream, io.Closer, error) {
+	f, err := am.fsys.Open(name)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	defer f.Close()
-
-	stat, err := f.Stat()
+	s, err := decode(name, f)
 	if err != nil {
-		return nil, err
+		f.Close()
+		return nil, nil, err
 	}
-	bs := make([]byte, stat.Size())
-	_, err = io.ReadFull(f, bs)
+	return s, f, nil
+}
+
+func (am *AudioManager) decodeAll(name string) ([]byte, error) {
+	src, f, err := am.open(name)
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
 
-	return &AudioItem{path, bs}, nil
+	pcm, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return pcm, nil
 }
 
-func (am *AudioManager) Add(name string, data []byte) {
-	var s io.ReadSeeker
+func decode(name string, r io.Reader) (stream, error) {
+	var s stream
 	var err error
 
 	switch {
 	case strings.HasSuffix(name, ".mp3"):
-		s, err = mp3.DecodeWithSampleRate(sampleRate, bytes.NewReader(data))
+		s, err = mp3.DecodeWithSampleRate(sampleRate, r)
 		if err != nil {
-			log.Printf("failed to decode mp3 file: %v", err)
-			return
+			return nil, fmt.Errorf("failed to decode mp3 file: %w", err)
 		}
 	case strings.HasSuffix(name, ".ogg"):
-		s, err = vorbis.DecodeWithSampleRate(sampleRate, bytes.NewReader(data))
+		s, err = vorbis.DecodeWithSampleRate(sampleRate, r)
 		if err != nil {
-			log.Printf("failed to decode ogg file: %v", err)
-			return
+			return nil, fmt.Errorf("failed to decode ogg file: %w", err)
 		}
 	case strings.HasSuffix(name, ".wav"):
-		s, err = wav.DecodeWithSampleRate(sampleRate, bytes.NewReader(data))
+		s, err = wav.DecodeWithSampleRate(sampleRate, r)
 		if err != nil {
-			log.Printf("failed to decode wav file: %v", err)
-			return
+			return nil, fmt.Errorf("failed to decode wav file: %w", err)
 		}
 	default:
-		log.Printf("unsupported audio format: %s", name)
-		return
+		return nil, fmt.Errorf("unsupported audio format: %s", name)
 	}
+	return s, nil
+}
 
-	p, err := am.audioContext.NewPlayer(s)
-	if err != nil {
-		log.Printf("failed to create audio player: %v", err)
-		return
-	}
-	am.audioPlayers[name] = p
+// stream is a decoded audio file.
+type stream interface {
+	io.ReadSeeker
+	Length() int64
+}
+
+// SetLoopPoints sets the section of a music track that repeats after the intro.
+func (am *AudioManager) SetLoopPoints(name string, loop LoopPoints) {
+	am.loops[name] = loop
 }
 
+// PlayMusic starts a music track right away, replacing the current one.
 func (am *AudioManager) PlayMusic(name string) *audio.Player {
-	player, ok := am.audioPlayers[name]
-	if !ok {
-		log.Printf("audio player not found: %s", name)
+	return am.CrossfadeMusic(name, 0)
+}
+
+// CrossfadeMusic fades the current track out while name fades in. Music loops,
+// between its loop points when it has them. Playing the current track again
+// only resumes it.
+func (am *AudioManager) CrossfadeMusic(name string, duration time.Duration) *audio.Player {
+	if am.music != nil && am.music.name == name {
+		am.music.paused = false
+		am.music.fade = newFade(am.music.gain, 1, timing.FromDuration(duration), nil)
+		am.music.player.Play()
+		return am.music.player
+	}
+
+	src, f, err := am.open(name)
+	if err != nil {
+		log.Printf("failed to open music %s: %v", name, err)
+		return nil
+	}
+
+	var loop *audio.InfiniteLoop
+	if points, ok := am.loops[name]; ok {
+		intro := alignedBytes(points.Start)
+		end := src.Length()
+		if points.End > 0 {
+			end = min(end, alignedBytes(points.End))
+		}
+		loop = audio.NewInfiniteLoopWithIntro(src, intro, end-intro)
+	} else {
+		loop = audio.NewInfiniteLoop(src, src.Length())
+	}
+
+	player, err := am.audioContext.NewPlayer(loop)
+	if err != nil {
+		f.Close()
+		log.Printf("failed to create audio player: %v", err)
 		return nil
 	}
-	player.SetVolume(am.volume)
+
+	frames := timing.FromDuration(duration)
+	if am.music != nil {
+		if frames > 0 {
+			am.fadeOutVoice(am.music, frames)
+			am.voices = append(am.voices, am.music)
+		} else {
+			am.music.stop()
+		}
+	}
+
+	v := &voice{name: name, bus: BusMusic, player: player, closer: f, gain: 1, frame: am.frame}
+	if frames > 0 {
+		v.gain = 0
+		v.fade = newFade(0, 1, frames, nil)
+	}
+	am.music = v
+	am.apply(v)
 	player.Play()
+	am.notifyPlay(Playback{Name: name, Bus: BusMusic})
 	return player
 }
 
+// alignedBytes converts a duration to a PCM byte offset on a sample boundary.
+func alignedBytes(d time.Duration) int64 {
+	n := int64(d.Seconds() * bytesPerSecond)
+	return n - n%4
+}
+
 func (am *AudioManager) PauseMusic(name string) {
-	player, ok := am.audioPlayers[name]
-	if !ok {
-		log.Printf("audio player not found: %s", name)
+	if am.music == nil || am.music.name != name {
 		return
 	}
-	player.Pause()
+	am.music.paused = true
+	am.music.player.Pause()
 }
 
+// PlaySound plays a sound effect on the sfx bus.
 func (am *AudioManager) PlaySound(name string) *audio.Player {
-	player, ok := am.audioPlayers[name]
-	if !ok {
-		log.Printf("audio player not found: %s", name)
+	return am.PlaySoundOn(BusSFX, name)
+}
+
+// PlaySoundOn plays a sound on a bus. The same sound can overlap with itself
+// up to MaxVoicesPerSound times.
+func (am *AudioManager) PlaySoundOn(bus Bus, name string) *audio.Player {
+	v := am.newVoice(bus, name, false)
+	if v == nil {
 		return nil
 	}
-	player.SetVolume(am.volume)
-	player.Rewind()
-	player.Play()
-	return player
+	am.notifyPlay(v.playback())
+	return v.player
 }
 
-func (am *AudioManager) SetVolume(volume float64) {
-	am.volume = volume
-	for _, player := range am.audioPlayers {
-		player.SetVolume(am.volume)
+// PlaySoundAt plays a sound effect at a world position. Its volume and pan
+// depend on the distance to the listener.
+func (am *AudioManager) PlaySoundAt(name string, x, y float64) *audio.Player {
+	v := am.newVoice(BusSFX, name, true)
+	if v == nil {
+		return nil
 	}
+	v.x, v.y = x, y
+	am.apply(v)
+	am.notifyPlay(v.playback())
+	return v.player
 }
 
-func (am *AudioManager) Volume() float64 {
-	return am.volume
+// PlaySoundFrom plays a sound effect that follows a source, e.g. a body.
+func (am *AudioManager) PlaySoundFrom(name string, source Source) *audio.Player {
+	v := am.newVoice(BusSFX, name, true)
+	if v == nil {
+		return nil
+	}
+	v.source = source
+	am.apply(v)
+	am.notifyPlay(v.playback())
+	return v.player
 }
 
-func (am *AudioManager) PauseAll() {
-	for _, player := range am.audioPlayers {
-		player.Pause()
+func (am *AudioManager) newVoice(bus Bus, name string, positional bool) *voice {
+	var src io.ReadSeeker
+	var closer io.Closer
+	var length int64
+
+	pcm, ok := am.cache.get(name)
+	if ok {
+		src = bytes.NewReader(pcm)
+		length = int64(len(pcm))
+	} else {
+		s, f, err := am.open(name)
+		if err != nil {
+			log.Printf("failed to open sound %s: %v", name, err)
+			return nil
+		}
+		length = s.Length()
+		if s.Length() > am.cache.budget {
+			// Too large to cache: stream it
+			src, closer = s, f
+		} else {
+			pcm, err = io.ReadAll(s)
+			f.Close()
+			if err != nil {
+				log.Printf("failed to decode %s: %v", name, err)
+				return nil
+			}
+			am.cache.put(name, pcm, false)
+			src = bytes.NewReader(pcm)
+		}
+	}
+
+	am.reserveVoice(name)
+
+	v := &voice{name: name, bus: bus, closer: closer, gain: 1, frame: am.frame, positional: positional}
+	v.duration = time.Duration(length) * time.Second / bytesPerSecond
+	if positional {
+		v.pan = newPanStream(src)
+		src = v.pan
+	}
+	player, err := am.audioContext.NewPlayer(src)
+	if err != nil {
+		if closer != nil {
+			closer.Close()
+		}
+		log.Printf("failed to create audio player: %v", err)
+		return nil
 	}
+	v.player = player
+
+	am.voices = append(am.voices, v)
+	am.apply(v)
+	v.player.Play()
+	return v
 }
 
-func (am *AudioManager) FadeOutAll(duration time.Duration) {
-	initialVolume := am.volume
-	if initialVolume == 0 {
+// reserveVoice steals the oldest voices until name can be played within the limits.
+func (am *AudioManager) reserveVoice(name string) {
+	for am.MaxVoicesPerSound > 0 && am.countVoices(name) >= am.MaxVoicesPerSound {
+		am.stealOldest(name)
+	}
+	for am.MaxVoices > 0 && am.countVoices("") >= am.MaxVoices {
+		am.stealOldest("")
+	}
+}
+
+// countVoices counts the sound voices of name, or all of them when name is empty.
+func (am *AudioManager) countVoices(name string) int {
+	n := 0
+	for _, v := range am.voices {
+		if v.bus != BusMusic && (name == "" || v.name == name) {
+			n++
+		}
+	}
+	return n
+}
+
+func (am *AudioManager) stealOldest(name string) {
+	oldest := -1
+	for i, v := range am.voices {
+		if v.bus == BusMusic || (name != "" && v.name != name) {
+			continue
+		}
+		if oldest < 0 || v.frame < am.voices[oldest].frame {
+			oldest = i
+		}
+	}
+	if oldest < 0 {
 		return
 	}
+	am.voices[oldest].stop()
+	am.voices = append(am.voices[:oldest], am.voices[oldest+1:]...)
+}
 
-	go func() {
-		ticker := time.NewTicker(100 * time.Millisecond)
-		defer ticker.Stop()
+// SetListener moves the point positional sounds are heard from.
+func (am *AudioManager) SetListener(x, y float64) {
+	am.listenerX, am.listenerY = x, y
+}
 
-		startTime := time.Now()
+// SetVolume sets the master volume.
+func (am *AudioManager) SetVolume(volume float64) {
+	am.SetBusVolume(BusMaster, volume)
+}
 
-		for range ticker.C {
-			elapsed := time.Since(startTime)
-			if elapsed >= duration {
-				am.SetVolume(0)
-				am.PauseAll()
-				return
-			}
+func (am *AudioManager) Volume() float64 {
+	return am.BusVolume(BusMaster)
+}
 
-			progress := float64(elapsed) / float64(duration)
-			newVolume := initialVolume * (1 - progress)
-			if newVolume < 0 {
-				newVolume = 0
-			}
-			am.SetVolume(newVolume)
-		}
-	}()
+func (am *AudioManager) SetBusVolume(bus Bus, volume float64) {
+	am.mixer.setVolume(bus, volume)
+	am.applyAll()
 }
 
-func (am *AudioManager) FadeOut(name string, duration time.Duration) {
-	player, ok := am.audioPlayers[name]
-	if !ok {
-		log.Printf("audio player not found: %s", name)
-		return
+func (am *AudioManager) BusVolume(bus Bus) float64 {
+	return am.mixer.volume(bus)
+}
+
+// FadeBus changes the volume of a bus over a duration.
+func (am *AudioManager) FadeBus(bus Bus, volume float64, duration time.Duration) {
+	am.fadeBus(bus, volume, duration, nil)
+}
+
+func (am *AudioManager) fadeBus(bus Bus, volume float64, duration time.Duration, onDone func()) {
+	am.mixer.fades[bus] = newFade(am.mixer.volume(bus), min(max(volume, 0), 1), timing.FromDuration(duration), onDone)
+}
+
+// SetDucked lowers the music, e.g. while a dialogue is on screen.
+func (am *AudioManager) SetDucked(ducked bool) {
+	am.mixer.ducked = ducked
+}
+
+// SetDuckLevel sets the music volume multiplier while ducked.
+func (am *AudioManager) SetDuckLevel(level float64) {
+	am.mixer.DuckLevel = min(max(level, 0), 1)
+}
+
+func (am *AudioManager) PauseAll() {
+	if am.music != nil {
+		am.music.paused = true
+		am.music.player.Pause()
 	}
+	for _, v := range am.voices {
+		v.paused = true
+		v.player.Pause()
+	}
+}
 
-	initialVolume := player.Volume()
-	if initialVolume == 0 {
+// FadeOutAll fades the master volume out and pauses everything.
+func (am *AudioManager) FadeOutAll(duration time.Duration) {
+	if am.Volume() == 0 {
 		return
 	}
+	am.fadeBus(BusMaster, 0, duration, am.PauseAll)
+}
 
-	go func() {
-		ticker := time.NewTicker(100 * time.Millisecond)
-		defer ticker.Stop()
-
-		startTime := time.Now()
-
-		for range ticker.C {
-			elapsed := time.Since(startTime)
-			if elapsed >= duration {
-				player.SetVolume(0)
-				player.Rewind()
-				player.Pause()
-				return
-			}
+// FadeOut fades every voice of name out. Music is rewound when it ends.
+func (am *AudioManager) FadeOut(name string, duration time.Duration) {
+	frames := timing.FromDuration(duration)
+	found := false
+	if am.music != nil && am.music.name == name {
+		am.fadeOutVoice(am.music, frames)
+		found = true
+	}
+	for _, v := range am.voices {
+		if v.name == name {
+			am.fadeOutVoice(v, frames)
+			found = true
+		}
+	}
+	if !found {
+		log.Printf("audio player not found: %s", name)
+	}
+}
 
-			progress := float64(elapsed) / float64(duration)
-			newVolume := initialVolume * (1 - progress)
-			if newVolume < 0 {
-				newVolume = 0
-			}
-			player.SetVolume(newVolume)
+func (am *AudioManager) fadeOutVoice(v *voice, frames int) {
+	v.fade = newFade(v.gain, 0, frames, func() {
+		if v == am.music {
+			v.paused = true
+			v.player.Pause()
+			_ = v.player.Rewind()
+			return
 		}
-	}()
+		v.stop()
+		v.done = true
+	})
 }
 
 func (am *AudioManager) IsPlayingSomething() bool {
-	for _, player := range am.audioPlayers {
-		if player.IsPlaying() {
+	if am.music != nil && am.music.player.IsPlaying() {
+		return true
+	}
+	for _, v := range am.voices {
+		if v.player.IsPlaying() {
 			return true
 		}
 	}
@@ -252,9 +542,79 @@ func (am *AudioManager) IsPlayingSomething() bool {
 }
 
 func (am *AudioManager) IsPlaying(name string) bool {
-	audio, ok := am.audioPlayers[name]
-	if !ok {
-		return false
+	if am.music != nil && am.music.name == name && am.music.player.IsPlaying() {
+		return true
+	}
+	for _, v := range am.voices {
+		if v.name == name && v.player.IsPlaying() {
+			return true
+		}
+	}
+	return false
+}
+
+// Update advances the fades and the ducking, follows positional sources and
+// releases the voices that finished. Call it once per frame.
+func (am *AudioManager) Update() {
+	am.frame++
+	am.mixer.update()
+
+	if am.music != nil {
+		am.stepFade(am.music)
+	}
+
+	n := 0
+	for _, v := range am.voices {
+		am.stepFade(v)
+		if v.done {
+			continue
+		}
+		if v.finished() {
+			v.stop()
+			continue
+		}
+		am.voices[n] = v
+		n++
+	}
+	clear(am.voices[n:])
+	am.voices = am.voices[:n]
+
+	am.applyAll()
+}
+
+func (am *AudioManager) stepFade(v *voice) {
+	if v.fade == nil {
+		return
+	}
+	g, done := v.fade.step()
+	v.gain = g
+	if !done {
+		return
+	}
+	f := v.fade
+	v.fade = nil
+	if f.onDone != nil {
+		f.onDone()
+	}
+}
+
+func (am *AudioManager) applyAll() {
+	if am.music != nil {
+		am.apply(am.music)
+	}
+	for _, v := range am.voices {
+		am.apply(v)
+	}
+}
+
+// apply sets the player volume from the bus, the fade and the distance to the listener.
+func (am *AudioManager) apply(v *voice) {
+	volume := am.mixer.gain(v.bus) * v.gain
+	if v.positional {
+		x, y := v.position()
+		dist, pan := attenuation(x, y, am.listenerX, am.listenerY, am.HearingRange)
+		volume *= dist
+		v.pan.SetPan(pan)
 	}
-	return audio.IsPlaying()
+	v.player.SetVolume(volume)
 }
@@ -0,0 +1,115 @@
+package audio
+
+// Bus groups sounds that share a volume, like the music or the UI sounds.
+// The volume of every bus is multiplied by the master bus.
+type Bus string
+
+const (
+	BusMaster Bus = "master"
+	BusMusic  Bus = "music"
+	BusSFX    Bus = "sfx"
+	BusUI     Bus = "ui"
+	BusVoice  Bus = "voice"
+)
+
+// DefaultDuckLevel is the music volume multiplier while ducked, e.g. under dialogue.
+const DefaultDuckLevel = 0.35
+
+// duckFrames is how long the music takes to duck or recover.
+const duckFrames = 15
+
+// fade interpolates a value over a number of frames.
+type fade struct {
+	from, to     float64
+	frame, total int
+	onDone       func()
+}
+
+func newFade(from, to float64, frames int, onDone func()) *fade {
+	return &fade{from: from, to: to, total: max(frames, 1), onDone: onDone}
+}
+
+// step advances the fade and returns the current value and whether it ended.
+func (f *fade) step() (float64, bool) {
+	f.frame++
+	if f.frame >= f.total {
+		return f.to, true
+	}
+	t := float64(f.frame) / float64(f.total)
+	return f.from + (f.to-f.from)*t, false
+}
+
+// mixer keeps the bus volumes and the music ducking.
+type mixer struct {
+	volumes map[Bus]float64
+	fades   map[Bus]*fade
+
+	ducked    bool
+	duck      float64 // Current music multiplier
+	DuckLevel float64
+}
+
+func newMixer() *mixer {
+	return &mixer{
+		volumes: map[Bus]float64{
+			BusMaster: 1,
+			BusMusic:  1,
+			BusSFX:    1,
+			BusUI:     1,
+			BusVoice:  1,
+		},
+		fades:     make(map[Bus]*fade),
+		duck:      1,
+		DuckLevel: DefaultDuckLevel,
+	}
+}
+
+func (m *mixer) volume(bus Bus) float64 {
+	v, ok := m.volumes[bus]
+	if !ok {
+		return 1
+	}
+	return v
+}
+
+func (m *mixer) setVolume(bus Bus, v float64) {
+	m.volumes[bus] = min(max(v, 0), 1)
+	delete(m.fades, bus)
+}
+
+// gain returns the final volume of a sound played on bus.
+func (m *mixer) gain(bus Bus) float64 {
+	g := m.volume(BusMaster)
+	if bus != BusMaster {
+		g *= m.volume(bus)
+	}
+	if bus == BusMusic {
+		g *= m.duck
+	}
+	return g
+}
+
+func (m *mixer) update() {
+	for bus, f := range m.fades {
+		v, done := f.step()
+		m.volumes[bus] = v
+		if done {
+			delete(m.fades, bus)
+			if f.onDone != nil {
+				f.onDone()
+			}
+		}
+	}
+
+	target := 1.0
+	if m.ducked {
+		target = m.DuckLevel
+	}
+	step := (1 - m.DuckLevel) / duckFrames
+	switch {
+	case m.duck < target:
+		m.duck = min(m.duck+step, target)
+	case m.duck > target:
+		m.duck = max(m.duck-step, target)
+	}
+}
@@ -0,0 +1,98 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestMixerGain(t *testing.T) {
+	m := newMixer()
+	m.setVolume(BusMaster, 0.5)
+	m.setVolume(BusSFX, 0.5)
+
+	if got := m.gain(BusSFX); got != 0.25 {
+		t.Errorf("sfx gain = %v, want 0.25", got)
+	}
+	if got := m.gain(BusUI); got != 0.5 {
+		t.Errorf("ui gain = %v, want 0.5", got)
+	}
+
+	m.setVolume(BusMusic, 2)
+	if got := m.volume(BusMusic); got != 1 {
+		t.Errorf("music volume = %v, want it clamped to 1", got)
+	}
+}
+
+func TestMixerDucking(t *testing.T) {
+	m := newMixer()
+	m.ducked = true
+	for range duckFrames {
+		m.update()
+	}
+	if got := m.gain(BusMusic); math.Abs(got-DefaultDuckLevel) > 1e-9 {
+		t.Errorf("ducked music gain = %v, want %v", got, DefaultDuckLevel)
+	}
+	if got := m.gain(BusSFX); got != 1 {
+		t.Errorf("sfx gain = %v, want 1 while the music is ducked", got)
+	}
+
+	m.ducked = false
+	for range duckFrames {
+		m.update()
+	}
+	if got := m.gain(BusMusic); math.Abs(got-1) > 1e-9 {
+		t.Errorf("music gain = %v, want 1 after ducking", got)
+	}
+}
+
+func TestMixerFade(t *testing.T) {
+	m := newMixer()
+	done := false
+	m.fades[BusMusic] = newFade(1, 0, 4, func() { done = true })
+
+	for range 3 {
+		m.update()
+	}
+	if done || m.volume(BusMusic) != 0.25 {
+		t.Fatalf("volume = %v after 3 of 4 frames, want 0.25", m.volume(BusMusic))
+	}
+	m.update()
+	if !done || m.volume(BusMusic) != 0 {
+		t.Errorf("fade did not finish: volume = %v", m.volume(BusMusic))
+	}
+}
+
+func TestPanStream(t *testing.T) {
+	pcm := make([]byte, 8)
+	for i := 0; i < len(pcm); i += 2 {
+		binary.LittleEndian.PutUint16(pcm[i:], uint16(int16(1000)))
+	}
+
+	s := newPanStream(bytes.NewReader(pcm))
+	s.SetPan(0.5)
+	out, err := io.ReadAll(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	left := int16(binary.LittleEndian.Uint16(out[0:]))
+	right := int16(binary.LittleEndian.Uint16(out[2:]))
+	if left != 500 || right != 1000 {
+		t.Errorf("panned right: got left %d right %d, want 500 1000", left, right)
+	}
+}
+
+func TestAttenuation(t *testing.T) {
+	volume, pan := attenuation(100, 0, 0, 0, 200)
+	if volume != 0.5 || pan != 1 {
+		t.Errorf("got volume %v pan %v, want 0.5 1", volume, pan)
+	}
+
+	volume, _ = attenuation(0, 300, 0, 0, 200)
+	if volume != 0 {
+		t.Errorf("got volume %v beyond the hearing range, want 0", volume)
+	}
+}
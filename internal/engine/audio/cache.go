@@ -0,0 +1,108 @@
+package audio
+
+// DefaultSoundBudget is the memory decoded sounds can use, about 47 seconds of audio.
+const DefaultSoundBudget = 8 << 20
+
+// soundCache keeps decoded sounds within a memory budget. Sounds pinned by a
+// loaded manifest are never evicted; the others go least recently used first.
+type soundCache struct {
+	budget  int64
+	used    int64
+	entries map[string]*cachedSound
+	clock   int
+}
+
+type cachedSound struct {
+	pcm      []byte
+	pins     int
+	lastUsed int
+}
+
+func newSoundCache(budget int64) *soundCache {
+	return &soundCache{
+		budget:  budget,
+		entries: make(map[string]*cachedSound),
+	}
+}
+
+func (c *soundCache) get(name string) ([]byte, bool) {
+	e, ok := c.entries[name]
+	if !ok {
+		return nil, false
+	}
+	c.clock++
+	e.lastUsed = c.clock
+	return e.pcm, true
+}
+
+// put caches a decoded sound and reports whether it fit in the budget.
+// Pinned sounds are always cached.
+func (c *soundCache) put(name string, pcm []byte, pinned bool) bool {
+	size := int64(len(pcm))
+	pins := 0
+	if pinned {
+		pins = 1
+	}
+	if e, ok := c.entries[name]; ok {
+		// Keep the pins of the other manifests using the sound
+		pins += e.pins
+		c.used -= int64(len(e.pcm))
+		delete(c.entries, name)
+	}
+	if pins == 0 && size > c.budget {
+		return false
+	}
+
+	c.clock++
+	e := &cachedSound{pcm: pcm, pins: pins, lastUsed: c.clock}
+	c.entries[name] = e
+	c.used += size
+	c.evict()
+	return true
+}
+
+func (c *soundCache) pin(name string) bool {
+	e, ok := c.entries[name]
+	if ok {
+		e.pins++
+	}
+	return ok
+}
+
+// unpin releases a sound pinned by a manifest, dropping it once unused.
+func (c *soundCache) unpin(name string) {
+	e, ok := c.entries[name]
+	if !ok || e.pins == 0 {
+		return
+	}
+	e.pins--
+	if e.pins == 0 {
+		c.remove(name)
+	}
+}
+
+func (c *soundCache) remove(name string) {
+	if e, ok := c.entries[name]; ok {
+		c.used -= int64(len(e.pcm))
+		delete(c.entries, name)
+	}
+}
+
+// evict drops the least recently used unpinned sounds until the cache fits the budget.
+func (c *soundCache) evict() {
+	for c.used > c.budget {
+		oldest := ""
+		for name, e := range c.entries {
+			if e.pins > 0 {
+				continue
+			}
+			if oldest == "" || e.lastUsed < c.entries[oldest].lastUsed {
+				oldest = name
+			}
+		}
+		if oldest == "" {
+			return // Only pinned sounds left
+		}
+		c.remove(oldest)
+	}
+}
@@ -0,0 +1,70 @@
+package audio
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestSoundCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newSoundCache(10)
+	c.put("a", make([]byte, 4), false)
+	c.put("b", make([]byte, 4), false)
+	c.get("a")
+	c.put("c", make([]byte, 4), false)
+
+	if _, ok := c.get("b"); ok {
+		t.Error("b should have been evicted as the least recently used sound")
+	}
+	if _, ok := c.get("a"); !ok {
+		t.Error("a should still be cached")
+	}
+	if c.used != 8 {
+		t.Errorf("used = %d, want 8", c.used)
+	}
+}
+
+func TestSoundCachePinned(t *testing.T) {
+	c := newSoundCache(4)
+	c.put("music", make([]byte, 8), true)
+	if _, ok := c.get("music"); !ok {
+		t.Fatal("pinned sounds are cached even over the budget")
+	}
+	if c.put("sfx", make([]byte, 8), false) {
+		t.Error("unpinned sounds larger than the budget should not be cached")
+	}
+
+	c.pin("music")
+	c.unpin("music")
+	if _, ok := c.get("music"); !ok {
+		t.Fatal("a sound pinned twice should survive one unpin")
+	}
+	c.unpin("music")
+	if _, ok := c.get("music"); ok || c.used != 0 {
+		t.Error("unpinned manifest sounds should be dropped")
+	}
+
+	c.put("jump", make([]byte, 2), true)
+	c.put("jump", make([]byte, 2), true)
+	c.unpin("jump")
+	if _, ok := c.get("jump"); !ok {
+		t.Error("putting a pinned sound again should keep its pins")
+	}
+}
+
+func TestLoadManifestUnpinsOnError(t *testing.T) {
+	am := &AudioManager{
+		fsys: fstest.MapFS{
+			"manifest.json": {Data: []byte(`{"sounds": ["hit.wav", "missing.wav"]}`)},
+		},
+		cache: newSoundCache(DefaultSoundBudget),
+		loops: make(map[string]LoopPoints),
+	}
+	am.cache.put("hit.wav", make([]byte, 4), true)
+
+	if _, err := am.LoadManifest("manifest.json"); err == nil {
+		t.Fatal("loading a manifest with a missing sound should fail")
+	}
+	if e := am.cache.entries["hit.wav"]; e == nil || e.pins != 1 {
+		t.Errorf("hit.wav = %+v, want it pinned once, as before loading", e)
+	}
+}
@@ -0,0 +1,45 @@
+package audio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"time"
+)
+
+// Manifest lists the audio a scene or phase needs. Its sounds are decoded and
+// kept resident while it is loaded; its music is streamed when played.
+type Manifest struct {
+	Music  []MusicData `json:"music"`
+	Sounds []string    `json:"sounds"`
+}
+
+// MusicData is a music track and its loop points, in seconds. A zero loop end
+// loops until the end of the track.
+type MusicData struct {
+	Path      string  `json:"path"`
+	LoopStart float64 `json:"loop_start,omitempty"`
+	LoopEnd   float64 `json:"loop_end,omitempty"`
+}
+
+func (m MusicData) LoopPoints() *LoopPoints {
+	if m.LoopStart == 0 && m.LoopEnd == 0 {
+		return nil
+	}
+	return &LoopPoints{
+		Start: time.Duration(m.LoopStart * float64(time.Second)),
+		End:   time.Duration(m.LoopEnd * float64(time.Second)),
+	}
+}
+
+func ReadManifest(fsys fs.FS, path string) (*Manifest, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse audio manifest %s: %w", path, err)
+	}
+	return &m, nil
+}
@@ -0,0 +1,54 @@
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"sync/atomic"
+)
+
+// panStream scales the left and right channels of 16-bit stereo PCM. The pan
+// is changed from the game loop while the audio goroutine reads the stream.
+type panStream struct {
+	src io.ReadSeeker
+	pan atomic.Uint64 // float64 bits, -1 (left) to 1 (right)
+}
+
+func newPanStream(src io.ReadSeeker) *panStream {
+	return &panStream{src: src}
+}
+
+func (s *panStream) SetPan(pan float64) {
+	s.pan.Store(math.Float64bits(min(max(pan, -1), 1)))
+}
+
+func (s *panStream) Pan() float64 {
+	return math.Float64frombits(s.pan.Load())
+}
+
+func (s *panStream) Read(p []byte) (int, error) {
+	n, err := s.src.Read(p)
+
+	pan := s.Pan()
+	if pan == 0 {
+		return n, err
+	}
+	// Balance: the channel opposite to the pan is attenuated
+	left, right := min(1, 1-pan), min(1, 1+pan)
+
+	for i := 0; i+4 <= n; i += 4 {
+		l := float64(int16(binary.LittleEndian.Uint16(p[i:])))
+		r := float64(int16(binary.LittleEndian.Uint16(p[i+2:])))
+		binary.LittleEndian.PutUint16(p[i:], uint16(clampSample(l*left)))
+		binary.LittleEndian.PutUint16(p[i+2:], uint16(clampSample(r*right)))
+	}
+	return n, err
+}
+
+func (s *panStream) Seek(offset int64, whence int) (int64, error) {
+	return s.src.Seek(offset, whence)
+}
+
+func clampSample(v float64) int16 {
+	return int16(min(max(v, math.MinInt16), math.MaxInt16))
+}
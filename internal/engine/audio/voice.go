@@ -0,0 +1,83 @@
+package audio
+
+import (
+	"image"
+	"io"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// Source is anything that emits positional sounds, e.g. a body of the space.
+type Source interface {
+	Position() image.Rectangle
+}
+
+// voice is a single playing instance of a sound.
+type voice struct {
+	name   string
+	bus    Bus
+	player *audio.Player
+	pan    *panStream // Nil for sounds that are not positional
+	closer io.Closer  // File of a streamed voice
+
+	gain   float64 // Fade multiplier
+	fade   *fade
+	paused bool
+	done   bool // Stopped by a fade, waiting to be released
+	frame  int  // Frame the voice started, used to steal the oldest voice
+
+	duration time.Duration
+
+	positional bool
+	x, y       float64
+	source     Source
+}
+
+func (v *voice) position() (float64, float64) {
+	if v.source != nil {
+		p := v.source.Position()
+		return float64(p.Min.X+p.Max.X) / 2, float64(p.Min.Y+p.Max.Y) / 2
+	}
+	return v.x, v.y
+}
+
+// attenuation returns the volume and pan of a positional voice heard from the
+// listener. The volume falls off linearly up to hearingRange.
+func attenuation(x, y, listenerX, listenerY, hearingRange float64) (volume, pan float64) {
+	if hearingRange <= 0 {
+		return 1, 0
+	}
+	dx, dy := x-listenerX, y-listenerY
+	dist := math.Hypot(dx, dy)
+	volume = max(0, 1-dist/hearingRange)
+	pan = min(max(dx/(hearingRange/2), -1), 1)
+	return volume, pan
+}
+
+func (v *voice) playback() Playback {
+	return Playback{
+		Name:       v.name,
+		Bus:        v.bus,
+		Duration:   v.duration,
+		Positional: v.positional,
+		X:          v.x,
+		Y:          v.y,
+		Source:     v.source,
+	}
+}
+
+func (v *voice) stop() {
+	v.player.Pause()
+	_ = v.player.Close()
+	if v.closer != nil {
+		_ = v.closer.Close()
+		v.closer = nil
+	}
+}
+
+// finished reports whether a voice reached its end on its own.
+func (v *voice) finished() bool {
+	return !v.paused && !v.player.IsPlaying()
+}
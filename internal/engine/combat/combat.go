@@ -0,0 +1,152 @@
+package combat
+
+import (
+	"image"
+	"slices"
+
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/data/schemas"
+	"github.com/leandroatallah/firefly/internal/engine/utils/fp16"
+)
+
+const DamageEventType = "combat_damage"
+
+// DefaultInvulnerableFrames is the i-frame window opened by a hit, shared with
+// the damage dealt outside of combat, e.g. by hazards.
+const DefaultInvulnerableFrames = body.DefaultInvulnerableFrames
+
+// Team avoids friendly fire. Neutral combatants can hit and be hit by anyone.
+type Team int
+
+const (
+	TeamNeutral Team = iota
+	TeamPlayer
+	TeamEnemy
+)
+
+// HitboxDef is an attack area relative to the body, with the sprite facing right.
+type HitboxDef struct {
+	Rect      image.Rectangle
+	Frames    []int // Animation frames where the hitbox is active. Empty means always.
+	Damage    int
+	Knockback image.Point // Pixels per frame, facing right.
+}
+
+func NewHitboxDef(data schemas.HitboxData) HitboxDef {
+	x, y, w, h := data.Rect()
+	return HitboxDef{
+		Rect:      image.Rect(x, y, x+w, y+h),
+		Frames:    data.Frames,
+		Damage:    data.Damage,
+		Knockback: image.Pt(data.KnockbackX, data.KnockbackY),
+	}
+}
+
+func (h HitboxDef) ActiveOn(frame int) bool {
+	return len(h.Frames) == 0 || slices.Contains(h.Frames, frame)
+}
+
+// Hitbox is an active attack area in world coordinates.
+type Hitbox struct {
+	Rect         image.Rectangle
+	Damage       int
+	KnockbackX16 int
+	KnockbackY16 int
+}
+
+// DamageEvent is published every time a hit lands.
+type DamageEvent struct {
+	Source             any
+	Target             any
+	Damage             int
+	KnockbackX16       int
+	KnockbackY16       int
+	InvulnerableFrames int
+}
+
+func (e *DamageEvent) Type() string {
+	return DamageEventType
+}
+
+// Damageable receives damage. It returns false when the damage was ignored,
+// e.g. during the invulnerability window.
+type Damageable interface {
+	TakeDamage(e *DamageEvent) bool
+}
+
+// Combatant can deal damage with hitboxes and receive it on hurtboxes.
+// Hurtboxes are separate from the collision rects used for movement.
+type Combatant interface {
+	body.Body
+	Damageable
+
+	Team() Team
+	Hitboxes() []Hitbox
+	Hurtboxes() []image.Rectangle
+}
+
+// Boxes keeps the hitboxes and hurtboxes of each animation state.
+type Boxes[T comparable] struct {
+	hit  map[T][]HitboxDef
+	hurt map[T][]image.Rectangle
+}
+
+func NewBoxes[T comparable]() *Boxes[T] {
+	return &Boxes[T]{
+		hit:  make(map[T][]HitboxDef),
+		hurt: make(map[T][]image.Rectangle),
+	}
+}
+
+func (b *Boxes[T]) AddHitbox(state T, h HitboxDef) {
+	b.hit[state] = append(b.hit[state], h)
+}
+
+func (b *Boxes[T]) AddHurtbox(state T, r image.Rectangle) {
+	b.hurt[state] = append(b.hurt[state], r)
+}
+
+// Hitboxes returns the hitboxes of a state active on the given frame, placed
+// on pos and mirrored when facing left.
+func (b *Boxes[T]) Hitboxes(state T, frame int, pos image.Rectangle, facingLeft bool) []Hitbox {
+	var res []Hitbox
+	for _, h := range b.hit[state] {
+		if !h.ActiveOn(frame) {
+			continue
+		}
+		kx := h.Knockback.X
+		if facingLeft {
+			kx = -kx
+		}
+		res = append(res, Hitbox{
+			Rect:         place(h.Rect, pos, facingLeft),
+			Damage:       h.Damage,
+			KnockbackX16: fp16.To16(kx),
+			KnockbackY16: fp16.To16(h.Knockback.Y),
+		})
+	}
+	return res
+}
+
+// Hurtboxes returns the hurtboxes of a state in world coordinates. The second
+// value is false when the state has none declared.
+func (b *Boxes[T]) Hurtboxes(state T, pos image.Rectangle, facingLeft bool) ([]image.Rectangle, bool) {
+	rects, ok := b.hurt[state]
+	if !ok {
+		return nil, false
+	}
+	res := make([]image.Rectangle, 0, len(rects))
+	for _, r := range rects {
+		res = append(res, place(r, pos, facingLeft))
+	}
+	return res, true
+}
+
+// place moves a rect relative to the body to world coordinates.
+func place(r image.Rectangle, pos image.Rectangle, facingLeft bool) image.Rectangle {
+	if facingLeft {
+		w := pos.Dx()
+		r = image.Rect(w-r.Max.X, r.Min.Y, w-r.Min.X, r.Max.Y)
+	}
+	return r.Add(pos.Min)
+}
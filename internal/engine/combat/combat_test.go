@@ -0,0 +1,63 @@
+package combat
+
+import (
+	"image"
+	"testing"
+)
+
+func TestBoxesHitboxesActiveFrames(t *testing.T) {
+	boxes := NewBoxes[int]()
+	boxes.AddHitbox(1, HitboxDef{
+		Rect:      image.Rect(16, 4, 24, 12),
+		Frames:    []int{2, 3},
+		Damage:    1,
+		Knockback: image.Pt(2, -1),
+	})
+	pos := image.Rect(100, 50, 124, 74)
+
+	if got := boxes.Hitboxes(1, 0, pos, false); len(got) != 0 {
+		t.Fatalf("expected no hitbox on frame 0, got %v", got)
+	}
+
+	got := boxes.Hitboxes(1, 2, pos, false)
+	if len(got) != 1 {
+		t.Fatalf("expected 1 hitbox on frame 2, got %d", len(got))
+	}
+	if want := image.Rect(116, 54, 124, 62); got[0].Rect != want {
+		t.Errorf("expected rect %v, got %v", want, got[0].Rect)
+	}
+	if got[0].KnockbackX16 <= 0 {
+		t.Errorf("expected knockback to the right, got %d", got[0].KnockbackX16)
+	}
+
+	mirrored := boxes.Hitboxes(1, 3, pos, true)
+	if want := image.Rect(100, 54, 108, 62); mirrored[0].Rect != want {
+		t.Errorf("expected mirrored rect %v, got %v", want, mirrored[0].Rect)
+	}
+	if mirrored[0].KnockbackX16 >= 0 {
+		t.Errorf("expected knockback to the left, got %d", mirrored[0].KnockbackX16)
+	}
+}
+
+func TestBoxesHurtboxesFallback(t *testing.T) {
+	boxes := NewBoxes[int]()
+	boxes.AddHurtbox(1, image.Rect(4, 10, 20, 24))
+	pos := image.Rect(0, 0, 24, 24)
+
+	if _, ok := boxes.Hurtboxes(2, pos, false); ok {
+		t.Error("expected state without hurtboxes to report false")
+	}
+	rects, ok := boxes.Hurtboxes(1, pos, false)
+	if !ok || len(rects) != 1 || rects[0] != image.Rect(4, 10, 20, 24) {
+		t.Errorf("unexpected hurtboxes: %v", rects)
+	}
+}
+
+func TestCanHit(t *testing.T) {
+	if CanHit(TeamPlayer, TeamPlayer) {
+		t.Error("expected no friendly fire")
+	}
+	if !CanHit(TeamPlayer, TeamEnemy) || !CanHit(TeamNeutral, TeamPlayer) {
+		t.Error("expected opposing teams to hit each other")
+	}
+}
@@ -0,0 +1,278 @@
+package combat
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/utils/fp16"
+)
+
+// ProjectileConfig describes a projectile: a thrown stone flies with its own
+// velocity and gravity, while a crook swing follows its owner for a few frames.
+type ProjectileConfig struct {
+	Name        string  `json:"name"`
+	Image       string  `json:"image,omitempty"`
+	Width       int     `json:"width"`
+	Height      int     `json:"height"`
+	SpeedX      float64 `json:"speed_x"` // Pixels per frame, facing right.
+	SpeedY      float64 `json:"speed_y"`
+	Gravity     float64 `json:"gravity"`
+	Lifetime    int     `json:"lifetime"` // Frames.
+	Damage      int     `json:"damage"`
+	KnockbackX  int     `json:"knockback_x"`
+	KnockbackY  int     `json:"knockback_y"`
+	OffsetX     int     `json:"offset_x"` // Spawn offset from the owner, facing right.
+	OffsetY     int     `json:"offset_y"`
+	FollowOwner bool    `json:"follow_owner"`
+	Pierce      bool    `json:"pierce"` // Keep going after hitting a target.
+
+	image *ebiten.Image
+}
+
+// LoadProjectileConfigs reads a JSON array of projectile configs, keyed by name.
+func LoadProjectileConfigs(path string) (map[string]*ProjectileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*ProjectileConfig
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*ProjectileConfig, len(list))
+	for _, cfg := range list {
+		if cfg.Image != "" {
+			img, _, err := ebitenutil.NewImageFromFile(cfg.Image)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load projectile image %s: %w", cfg.Image, err)
+			}
+			cfg.image = img
+		}
+		res[cfg.Name] = cfg
+	}
+	return res, nil
+}
+
+// Projectile is a short-lived attack owned by a combatant. Projectiles are
+// not bodies of the space: they query it to find targets and obstacles.
+type Projectile struct {
+	config     *ProjectileConfig
+	owner      Combatant
+	team       Team
+	facingLeft bool
+
+	x16, y16   int
+	vx16, vy16 int
+	age        int
+	active     bool
+	hit        map[string]struct{}
+
+	imageOptions *ebiten.DrawImageOptions
+}
+
+func (p *Projectile) reset(cfg *ProjectileConfig, owner Combatant, facingLeft bool) {
+	p.config = cfg
+	p.owner = owner
+	p.team = owner.Team()
+	p.facingLeft = facingLeft
+	p.age = 0
+	p.active = true
+	clear(p.hit)
+
+	p.vx16 = fp16.FromFloat(cfg.SpeedX)
+	if facingLeft {
+		p.vx16 = -p.vx16
+	}
+	p.vy16 = fp16.FromFloat(cfg.SpeedY)
+	p.followOwner()
+}
+
+func (p *Projectile) followOwner() {
+	pos := p.owner.Position()
+	rect := place(
+		image.Rect(p.config.OffsetX, p.config.OffsetY, p.config.OffsetX+p.config.Width, p.config.OffsetY+p.config.Height),
+		pos,
+		p.facingLeft,
+	)
+	p.x16, p.y16 = fp16.To16(rect.Min.X), fp16.To16(rect.Min.Y)
+}
+
+func (p *Projectile) IsActive() bool {
+	return p.active
+}
+
+func (p *Projectile) Position() image.Rectangle {
+	x, y := fp16.From16(p.x16), fp16.From16(p.y16)
+	return image.Rect(x, y, x+p.config.Width, y+p.config.Height)
+}
+
+// Update moves the projectile and resolves it against the space: combatants of
+// another team take damage and obstructive bodies stop it.
+func (p *Projectile) Update(space body.BodiesSpace, system *System) {
+	if !p.active {
+		return
+	}
+
+	p.age++
+	if p.config.Lifetime > 0 && p.age > p.config.Lifetime {
+		p.active = false
+		return
+	}
+
+	if p.config.FollowOwner {
+		p.followOwner()
+	} else {
+		p.vy16 += fp16.FromFloat(p.config.Gravity)
+		p.x16 += p.vx16
+		p.y16 += p.vy16
+	}
+
+	if space == nil {
+		return
+	}
+
+	rect := p.Position()
+	for _, b := range space.Query(rect) {
+		root := Root(b)
+		if root == any(p.owner) {
+			continue
+		}
+
+		if target, ok := root.(Combatant); ok {
+			if !CanHit(p.team, target.Team()) {
+				continue
+			}
+			if _, ok := p.hit[target.ID()]; ok {
+				continue
+			}
+			if !overlapsAny(rect, target.Hurtboxes()) {
+				continue
+			}
+
+			kx := p.config.KnockbackX
+			if p.facingLeft {
+				kx = -kx
+			}
+			system.Apply(&DamageEvent{
+				Source:       p,
+				Target:       target,
+				Damage:       p.config.Damage,
+				KnockbackX16: fp16.To16(kx),
+				KnockbackY16: fp16.To16(p.config.KnockbackY),
+			})
+			p.hit[target.ID()] = struct{}{}
+			if !p.config.Pierce {
+				p.active = false
+				return
+			}
+			continue
+		}
+
+		if b.IsObstructive() && !p.config.FollowOwner && overlapsAny(rect, b.CollisionPosition()) {
+			p.active = false
+			return
+		}
+	}
+}
+
+func (p *Projectile) Image() *ebiten.Image {
+	return p.config.image
+}
+
+func (p *Projectile) ImageOptions() *ebiten.DrawImageOptions {
+	return p.imageOptions
+}
+
+func (p *Projectile) UpdateImageOptions() {
+	p.imageOptions.GeoM.Reset()
+	if p.facingLeft {
+		p.imageOptions.GeoM.Scale(-1, 1)
+		p.imageOptions.GeoM.Translate(float64(p.config.Width), 0)
+	}
+	pos := p.Position()
+	p.imageOptions.GeoM.Translate(float64(pos.Min.X), float64(pos.Min.Y))
+}
+
+func overlapsAny(r image.Rectangle, rects []image.Rectangle) bool {
+	for _, o := range rects {
+		if r.Overlaps(o) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectilePool reuses projectiles so that throwing and swinging don't allocate.
+type ProjectilePool struct {
+	system      *System
+	projectiles []*Projectile
+	active      []*Projectile
+}
+
+func NewProjectilePool(system *System, capacity int) *ProjectilePool {
+	p := &ProjectilePool{system: system}
+	for i := 0; i < capacity; i++ {
+		p.projectiles = append(p.projectiles, newProjectile())
+	}
+	p.active = make([]*Projectile, 0, capacity)
+	return p
+}
+
+func newProjectile() *Projectile {
+	return &Projectile{
+		hit:          make(map[string]struct{}),
+		imageOptions: &ebiten.DrawImageOptions{},
+	}
+}
+
+// Spawn activates a projectile from the pool, growing it if every projectile is in use.
+func (p *ProjectilePool) Spawn(cfg *ProjectileConfig, owner Combatant, facingLeft bool) *Projectile {
+	var proj *Projectile
+	for _, candidate := range p.projectiles {
+		if !candidate.active {
+			proj = candidate
+			break
+		}
+	}
+	if proj == nil {
+		proj = newProjectile()
+		p.projectiles = append(p.projectiles, proj)
+	}
+
+	proj.reset(cfg, owner, facingLeft)
+	p.active = append(p.active, proj)
+	return proj
+}
+
+func (p *ProjectilePool) Update(space body.BodiesSpace) {
+	n := 0
+	for _, proj := range p.active {
+		proj.Update(space, p.system)
+		if proj.active {
+			p.active[n] = proj
+			n++
+		}
+	}
+	clear(p.active[n:])
+	p.active = p.active[:n]
+}
+
+// Active returns the projectiles in flight. The slice must not be modified.
+func (p *ProjectilePool) Active() []*Projectile {
+	return p.active
+}
+
+func (p *ProjectilePool) Clear() {
+	for _, proj := range p.active {
+		proj.active = false
+	}
+	clear(p.active)
+	p.active = p.active[:0]
+}
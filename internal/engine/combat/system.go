@@ -0,0 +1,127 @@
+package combat
+
+import (
+	"image"
+
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/event"
+)
+
+// System resolves hitboxes against hurtboxes every frame and applies the damage.
+type System struct {
+	events             *event.Manager
+	InvulnerableFrames int
+
+	// Targets already hit by each attacker during its current attack, so a
+	// hitbox that stays active for several frames only hits once.
+	hits map[string]map[string]struct{}
+}
+
+func NewSystem(events *event.Manager) *System {
+	return &System{
+		events:             events,
+		InvulnerableFrames: DefaultInvulnerableFrames,
+		hits:               make(map[string]map[string]struct{}),
+	}
+}
+
+// CanHit reports whether attacker and target are enemies.
+func CanHit(attacker, target Team) bool {
+	return attacker == TeamNeutral || target == TeamNeutral || attacker != target
+}
+
+// Combatants returns the bodies in space that take part in combat.
+func Combatants(space body.BodiesSpace) []Combatant {
+	var res []Combatant
+	for _, b := range space.Bodies() {
+		if c, ok := Root(b).(Combatant); ok {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// Root returns the top-most owner of a body, e.g. the player that owns a collision rect.
+func Root(b body.Body) any {
+	if owner := b.LastOwner(); owner != nil {
+		return owner
+	}
+	return b
+}
+
+func (s *System) Update(space body.BodiesSpace) {
+	if space == nil {
+		return
+	}
+
+	combatants := Combatants(space)
+	for _, attacker := range combatants {
+		hitboxes := attacker.Hitboxes()
+		if len(hitboxes) == 0 {
+			delete(s.hits, attacker.ID())
+			continue
+		}
+
+		for _, target := range combatants {
+			if target.ID() == attacker.ID() || !CanHit(attacker.Team(), target.Team()) {
+				continue
+			}
+			hb, overlaps := firstOverlap(hitboxes, target.Hurtboxes())
+			if _, ok := s.hits[attacker.ID()][target.ID()]; ok {
+				// Hitboxes that stay active, e.g. of contact damage, hit
+				// again once the target left them
+				if !overlaps {
+					delete(s.hits[attacker.ID()], target.ID())
+				}
+				continue
+			}
+
+			if overlaps {
+				s.Apply(&DamageEvent{
+					Source:       attacker,
+					Target:       target,
+					Damage:       hb.Damage,
+					KnockbackX16: hb.KnockbackX16,
+					KnockbackY16: hb.KnockbackY16,
+				})
+				s.markHit(attacker.ID(), target.ID())
+			}
+		}
+	}
+}
+
+// Apply delivers a damage event to its target and publishes it if it landed.
+func (s *System) Apply(e *DamageEvent) bool {
+	target, ok := e.Target.(Damageable)
+	if !ok {
+		return false
+	}
+	if e.InvulnerableFrames == 0 {
+		e.InvulnerableFrames = s.InvulnerableFrames
+	}
+	if !target.TakeDamage(e) {
+		return false
+	}
+	if s.events != nil {
+		s.events.Publish(e)
+	}
+	return true
+}
+
+func (s *System) markHit(attackerID, targetID string) {
+	if s.hits[attackerID] == nil {
+		s.hits[attackerID] = make(map[string]struct{})
+	}
+	s.hits[attackerID][targetID] = struct{}{}
+}
+
+func firstOverlap(hitboxes []Hitbox, hurtboxes []image.Rectangle) (Hitbox, bool) {
+	for _, hb := range hitboxes {
+		for _, r := range hurtboxes {
+			if hb.Rect.Overlaps(r) {
+				return hb, true
+			}
+		}
+	}
+	return Hitbox{}, false
+}
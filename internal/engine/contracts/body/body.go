@@ -78,6 +78,17 @@ type Collidable interface {
 	ApplyValidPosition(distance16 int, isXAxis bool, space BodiesSpace) (x, y int, wasBlocked bool)
 }
 
+// Surface is implemented by bodies that change how the bodies on or against
+// them move, like tiles with properties.
+type Surface interface {
+	// Friction multiplies the ground friction; 1 is the default.
+	Friction() float64
+	// Hazard is the damage dealt to bodies touching the surface.
+	Hazard() int
+	// OneWay surfaces only block bodies coming from above.
+	OneWay() bool
+}
+
 type Obstacle interface {
 	Body
 	Collidable
@@ -97,6 +108,9 @@ type Touchable interface {
 	OnBlock(other Collidable)
 }
 
+// DefaultInvulnerableFrames is the i-frame window opened by a hit (2 seconds at 60fps).
+const DefaultInvulnerableFrames = 120
+
 type Alive interface {
 	Body
 	Health() int
@@ -16,6 +16,16 @@ type Scene interface {
 	SetAppContext(appContext any)
 }
 
+// Overlay is a scene pushed over other scenes, like a pause menu or a
+// dialogue. It chooses whether the scenes below keep updating and drawing
+// while it is on top, and whether they still receive input.
+type Overlay interface {
+	Scene
+	UpdatesBelow() bool
+	DrawsBelow() bool
+	CapturesInput() bool
+}
+
 type SceneFactory interface {
 	Create(sceneType SceneType, freshInstance bool) (Scene, error)
 
@@ -24,13 +34,23 @@ type SceneFactory interface {
 
 type SceneMap map[SceneType]func() Scene
 
+// SceneManager keeps a stack of scenes. NavigateTo and SwitchTo replace the
+// whole stack, while Push, Pop and Replace change its top, usually overlays.
 type SceneManager interface {
 	AudioManager() *audio.AudioManager
 	Draw(screen *ebiten.Image)
 	NavigateTo(sceneType SceneType, sceneTransition Transition, freshInstance bool)
+	// NavigateBack pops the top scene, or goes back to the scene navigated
+	// from when only one is left.
 	NavigateBack(sceneTransition Transition)
 	// SetFactory(factory SceneFactory)
 	SwitchTo(scene Scene)
+	Push(sceneType SceneType, freshInstance bool)
+	PushScene(scene Scene)
+	Pop()
+	Replace(sceneType SceneType, sceneTransition Transition, freshInstance bool)
+	// Top returns the scene on top of the stack.
+	Top() Scene
 	Update() error
 }
 
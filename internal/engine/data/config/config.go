@@ -27,6 +27,9 @@ type PhysicsConfig struct {
 	DownwardGravity int
 	// MaxFallSpeed is the terminal velocity for falling.
 	MaxFallSpeed int
+	// MaxStepHeight is the height, in pixels, a grounded actor walks up or down
+	// without jumping or falling, e.g. on slopes. 0 disables stepping.
+	MaxStepHeight int
 }
 
 type AppConfig struct {
@@ -40,6 +43,15 @@ type AppConfig struct {
 	CamDebug     bool
 	CollisionBox bool
 	NoSound      bool
+	// Captions shows text for sound effects and music
+	Captions bool
+
+	// Locale of the string tables, e.g. "pt-BR". Empty uses the default locale.
+	Locale string
+
+	// PostEffects are the names of the post-processing passes enabled at
+	// start, e.g. "crt" or "vignette".
+	PostEffects []string
 
 	// Transition
 	ScreenFlipSpeed float64
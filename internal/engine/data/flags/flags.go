@@ -0,0 +1,44 @@
+package flags
+
+import "strings"
+
+// Flags are named game states, like "met_dog", that dialogues and sequences
+// check and set.
+type Flags struct {
+	values map[string]bool
+}
+
+func New() *Flags {
+	return &Flags{values: make(map[string]bool)}
+}
+
+func (f *Flags) Set(name string, value bool) {
+	if value {
+		f.values[name] = true
+		return
+	}
+	delete(f.values, name)
+}
+
+func (f *Flags) Get(name string) bool {
+	return f.values[name]
+}
+
+// Check reports whether every condition holds. A condition is a flag name,
+// negated with a leading "!".
+func (f *Flags) Check(conditions ...string) bool {
+	for _, c := range conditions {
+		want := true
+		if name, ok := strings.CutPrefix(c, "!"); ok {
+			c, want = name, false
+		}
+		if f.Get(c) != want {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Flags) Clear() {
+	clear(f.values)
+}
@@ -0,0 +1,271 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DefaultLocale is the locale every other locale falls back to.
+const DefaultLocale = "en"
+
+// Args are the values of the %{name} placeholders of a message.
+type Args map[string]any
+
+// Message is a translated string, or its plural forms keyed by category
+// ("zero", "one", "few", "other"...). In JSON it is a string or an object.
+type Message struct {
+	Text   string
+	Plural map[string]string
+}
+
+func (m *Message) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &m.Text); err == nil {
+		return nil
+	}
+	return json.Unmarshal(data, &m.Plural)
+}
+
+// form returns the text for a plural category, falling back to "other".
+func (m Message) form(category string) string {
+	if m.Plural == nil {
+		return m.Text
+	}
+	if s, ok := m.Plural[category]; ok {
+		return s
+	}
+	return m.Plural["other"]
+}
+
+// Table maps keys, like "menu.start", to the messages of a locale.
+type Table map[string]Message
+
+// PluralRule returns the plural category of n.
+type PluralRule func(n int) string
+
+var pluralRules = map[string]PluralRule{
+	"en": func(n int) string {
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	},
+	"pt": func(n int) string {
+		if n == 0 || n == 1 {
+			return "one"
+		}
+		return "other"
+	},
+}
+
+// RegisterPluralRule sets the plural rule of a language, e.g. "pt" for pt-BR.
+func RegisterPluralRule(lang string, rule PluralRule) {
+	pluralRules[lang] = rule
+}
+
+func pluralRule(locale string) PluralRule {
+	lang, _, _ := strings.Cut(locale, "-")
+	if r, ok := pluralRules[lang]; ok {
+		return r
+	}
+	return pluralRules[DefaultLocale]
+}
+
+// Localizer looks up the messages of the current locale. Keys missing in it
+// fall back to the fallback locale, and then to the key itself.
+type Localizer struct {
+	fallback string
+	locale   string
+	tables   map[string]Table
+	onChange []func(locale string)
+}
+
+func New(fallback string) *Localizer {
+	return &Localizer{
+		fallback: fallback,
+		locale:   fallback,
+		tables:   make(map[string]Table),
+	}
+}
+
+// Add merges the messages of a table into a locale.
+func (l *Localizer) Add(locale string, t Table) {
+	table, ok := l.tables[locale]
+	if !ok {
+		table = make(Table, len(t))
+		l.tables[locale] = table
+	}
+	for k, m := range t {
+		table[k] = m
+	}
+}
+
+// LoadDir reads a string table per locale from the JSON files of a directory,
+// named after their locale, like "pt-BR.json".
+func (l *Localizer) LoadDir(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var t Table
+		if err := json.Unmarshal(data, &t); err != nil {
+			return fmt.Errorf("failed to load string table %s: %w", path, err)
+		}
+		l.Add(strings.TrimSuffix(filepath.Base(path), ".json"), t)
+	}
+	return nil
+}
+
+// Locales returns the loaded locales, sorted.
+func (l *Localizer) Locales() []string {
+	res := make([]string, 0, len(l.tables))
+	for locale := range l.tables {
+		res = append(res, locale)
+	}
+	slices.Sort(res)
+	return res
+}
+
+func (l *Localizer) Locale() string {
+	return l.locale
+}
+
+// SetLocale switches the language at runtime and notifies the listeners.
+func (l *Localizer) SetLocale(locale string) error {
+	if _, ok := l.tables[locale]; !ok {
+		return fmt.Errorf("unknown locale: %s", locale)
+	}
+	if locale == l.locale {
+		return nil
+	}
+	l.locale = locale
+	for _, fn := range l.onChange {
+		fn(locale)
+	}
+	return nil
+}
+
+// OnChange registers a function called when the locale changes.
+func (l *Localizer) OnChange(fn func(locale string)) {
+	l.onChange = append(l.onChange, fn)
+}
+
+func (l *Localizer) lookup(key string) (Message, string, bool) {
+	if m, ok := l.tables[l.locale][key]; ok {
+		return m, l.locale, true
+	}
+	if m, ok := l.tables[l.fallback][key]; ok {
+		return m, l.fallback, true
+	}
+	return Message{}, "", false
+}
+
+// Has reports whether key is translated in the current or the fallback locale.
+func (l *Localizer) Has(key string) bool {
+	_, _, ok := l.lookup(key)
+	return ok
+}
+
+// T returns the message of key with its placeholders replaced by args.
+// Unknown keys are returned as they are, so plain text can go through T.
+func (l *Localizer) T(key string, args ...Args) string {
+	m, _, ok := l.lookup(key)
+	if !ok {
+		return key
+	}
+	return format(m.form("other"), args...)
+}
+
+// N returns the plural form of key for n. n is also the %{count} argument.
+func (l *Localizer) N(key string, n int, args ...Args) string {
+	m, locale, ok := l.lookup(key)
+	if !ok {
+		return key
+	}
+	args = append(args, Args{"count": n})
+	return format(m.form(pluralRule(locale)(n)), args...)
+}
+
+// Missing returns, per locale, the keys other locales have and it lacks.
+func (l *Localizer) Missing() map[string][]string {
+	all := make(map[string]bool)
+	for _, t := range l.tables {
+		for k := range t {
+			all[k] = true
+		}
+	}
+
+	res := make(map[string][]string)
+	for locale, t := range l.tables {
+		for k := range all {
+			if _, ok := t[k]; !ok {
+				res[locale] = append(res[locale], k)
+			}
+		}
+		slices.Sort(res[locale])
+	}
+	return res
+}
+
+// format replaces the %{name} placeholders of s. Unknown names are kept.
+func format(s string, args ...Args) string {
+	if len(args) == 0 || !strings.Contains(s, "%{") {
+		return s
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(s, "%{")
+		if i < 0 {
+			break
+		}
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			break
+		}
+		b.WriteString(s[:i])
+		name := s[i+2 : i+end]
+		if v, ok := lookupArg(name, args); ok {
+			fmt.Fprint(&b, v)
+		} else {
+			b.WriteString(s[i : i+end+1])
+		}
+		s = s[i+end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+func lookupArg(name string, args []Args) (any, bool) {
+	for _, a := range args {
+		if v, ok := a[name]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+var std = New(DefaultLocale)
+
+// Default returns the localizer shared by the game.
+func Default() *Localizer {
+	return std
+}
+
+// T looks up key in the default localizer.
+func T(key string, args ...Args) string {
+	return std.T(key, args...)
+}
+
+// N looks up the plural form of key in the default localizer.
+func N(key string, n int, args ...Args) string {
+	return std.N(key, n, args...)
+}
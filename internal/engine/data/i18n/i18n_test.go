@@ -0,0 +1,46 @@
+package i18n
+
+import "testing"
+
+func TestLocalizer(t *testing.T) {
+	l := New("en")
+	l.Add("en", Table{
+		"greet": {Text: "Hello, %{name}!"},
+		"sheep": {Plural: map[string]string{"one": "%{count} sheep left", "other": "%{count} sheep left in %{place}"}},
+		"only":  {Text: "English only"},
+	})
+	l.Add("pt-BR", Table{
+		"greet": {Text: "Olá, %{name}!"},
+		"sheep": {Plural: map[string]string{"one": "%{count} ovelha", "other": "%{count} ovelhas"}},
+	})
+
+	changed := ""
+	l.OnChange(func(locale string) { changed = locale })
+	if err := l.SetLocale("pt-BR"); err != nil || changed != "pt-BR" {
+		t.Fatalf("SetLocale: %v, listener got %q", err, changed)
+	}
+	if err := l.SetLocale("fr"); err == nil {
+		t.Error("unknown locales should fail")
+	}
+
+	tests := []struct{ got, want string }{
+		{l.T("greet", Args{"name": "Dog"}), "Olá, Dog!"},
+		{l.T("only"), "English only"},
+		{l.T("not.a.key"), "not.a.key"},
+		{l.N("sheep", 0), "0 ovelha"},
+		{l.N("sheep", 2), "2 ovelhas"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+
+	_ = l.SetLocale("en")
+	if got := l.N("sheep", 3, Args{"place": "the hills"}); got != "3 sheep left in the hills" {
+		t.Errorf("N = %q", got)
+	}
+	if got := l.Missing()["pt-BR"]; len(got) != 1 || got[0] != "only" {
+		t.Errorf("Missing = %v", got)
+	}
+}
@@ -19,9 +19,22 @@ func (s ShapeRect) Rect() (x, y, width, height int) {
 
 // AssetData holds information about a single asset, including its path and collision areas.
 type AssetData struct {
-	Path           string      `json:"path"`
-	CollisionRects []ShapeRect `json:"collision_rect"`
-	Loop           *bool       `json:"loop,omitempty"`
+	Path           string       `json:"path"`
+	CollisionRects []ShapeRect  `json:"collision_rect"`
+	Loop           *bool        `json:"loop,omitempty"`
+	Hitboxes       []HitboxData `json:"hitboxes,omitempty"`
+	// Hurtboxes are the areas that can receive damage. When empty, the collision rects are used.
+	Hurtboxes []ShapeRect `json:"hurtboxes,omitempty"`
+}
+
+// HitboxData is an attack area, relative to the sprite facing right, that is
+// only active on the listed animation frames (all frames when empty).
+type HitboxData struct {
+	ShapeRect
+	Frames     []int `json:"frames,omitempty"`
+	Damage     int   `json:"damage"`
+	KnockbackX int   `json:"knockback_x"`
+	KnockbackY int   `json:"knockback_y"`
 }
 
 // SpriteData contains all data related to a sprite's appearance and behavior,
@@ -41,3 +54,53 @@ type ParticleData struct {
 	FrameRate   int     `json:"frame_rate"` // Ticks per frame
 	Scale       float64 `json:"scale"`
 }
+
+// RangeData is a closed interval used to randomize a value.
+type RangeData struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// CurveKeyData is a value at a normalized time of a particle's life (0 to 1).
+type CurveKeyData struct {
+	T float64 `json:"t"`
+	V float64 `json:"v"`
+}
+
+// ColorKeyData is a color at a normalized time of a particle's life (0 to 1).
+type ColorKeyData struct {
+	T float64 `json:"t"`
+	R float64 `json:"r"`
+	G float64 `json:"g"`
+	B float64 `json:"b"`
+}
+
+// EmitterShapeData is the area where particles are spawned, relative to the emitter.
+type EmitterShapeData struct {
+	Type   string  `json:"type"` // point, line, rect or circle
+	Width  float64 `json:"width,omitempty"`
+	Height float64 `json:"height,omitempty"`
+	Radius float64 `json:"radius,omitempty"`
+	// End point of a line, relative to the emitter.
+	X2 float64 `json:"x2,omitempty"`
+	Y2 float64 `json:"y2,omitempty"`
+}
+
+// EmitterData defines how a particle effect is emitted.
+type EmitterData struct {
+	Mode     string           `json:"mode"`               // continuous or burst
+	Rate     float64          `json:"rate,omitempty"`     // Particles per second, continuous mode
+	Count    int              `json:"count,omitempty"`    // Particles per burst
+	Duration int              `json:"duration,omitempty"` // Frames. Zero emits until stopped
+	Shape    EmitterShapeData `json:"shape"`
+	Speed    RangeData        `json:"speed"`    // Pixels per frame
+	Angle    RangeData        `json:"angle"`    // Degrees, 0 points right and 90 down
+	Lifetime RangeData        `json:"lifetime"` // Frames. Zero uses the frame strip duration
+	Gravity  float64          `json:"gravity,omitempty"`
+	Drag     float64          `json:"drag,omitempty"` // Velocity lost per frame, from 0 to 1
+	Collide  bool             `json:"collide,omitempty"`
+	Bounce   float64          `json:"bounce,omitempty"`
+	Color    []ColorKeyData   `json:"color,omitempty"`
+	Alpha    []CurveKeyData   `json:"alpha,omitempty"`
+	Scale    []CurveKeyData   `json:"scale,omitempty"`
+}
@@ -5,9 +5,11 @@ import (
 	"log"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/combat"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/animation"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 	"github.com/leandroatallah/firefly/internal/engine/entity/actors/movement"
+	"github.com/leandroatallah/firefly/internal/engine/entity/effects"
 	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
 	physicsmovement "github.com/leandroatallah/firefly/internal/engine/physics/movement"
 	"github.com/leandroatallah/firefly/internal/engine/physics/skill"
@@ -25,16 +27,23 @@ type Character struct {
 
 	Touchable body.Touchable
 
-	count                int
-	state                ActorState
-	movementState        movement.MovementState
-	movementModel        physicsmovement.MovementModel
-	movementBlockers     int
-	invulnerabilityTimer int
-	imageOptions         *ebiten.DrawImageOptions
+	count            int
+	state            ActorState
+	movementState    movement.MovementState
+	movementModel    physicsmovement.MovementModel
+	movementBlockers int
+	imageOptions     *ebiten.DrawImageOptions
 
 	skills []skill.Skill
 
+	stateMachine *StateMachine
+
+	combatBoxes *combat.Boxes[ActorStateEnum]
+	team        combat.Team
+
+	effects *effects.Stack
+	base    baseStats
+
 	StateTransitionHandler func(*Character) bool
 	OnStateChange          func(oldState, newState ActorStateEnum)
 	bodyphysics.Ownership
@@ -45,6 +54,17 @@ func (c *Character) SetStateTransitionHandler(handler func(*Character) bool) {
 	c.StateTransitionHandler = handler
 }
 
+// SetStateMachine replaces the default state transition logic with a
+// hierarchical state machine built from def.
+func (c *Character) SetStateMachine(def *StateMachineDef) {
+	c.stateMachine = NewStateMachine(def, c)
+	c.stateMachine.Start()
+}
+
+func (c *Character) StateMachine() *StateMachine {
+	return c.stateMachine
+}
+
 func NewCharacter(s sprites.SpriteMap, bodyRect *bodyphysics.Rect) *Character { // Modified signature
 	spriteEntity := sprites.NewSpriteEntity(s)
 	b := bodyphysics.NewBody(bodyRect)
@@ -58,7 +78,16 @@ func NewCharacter(s sprites.SpriteMap, bodyRect *bodyphysics.Rect) *Character {
 
 		SpriteEntity: spriteEntity,
 		imageOptions: &ebiten.DrawImageOptions{},
+		combatBoxes:  combat.NewBoxes[ActorStateEnum](),
+		base: baseStats{
+			speed:             movable.Speed(),
+			maxSpeed:          movable.MaxSpeed(),
+			jumpForce:         movable.JumpForceMultiplier(),
+			horizontalInertia: movable.HorizontalInertia(),
+		},
 	}
+	c.effects = effects.NewStack(c)
+	c.effects.OnDamage = c.damageOverTime
 	// Set the owner for all body components to this Character
 	// Body.Owner -> MovableBody (chosen as the primary physical representation)
 	b.SetOwner(movable)
@@ -141,6 +170,10 @@ func (c *Character) SetState(state ActorState) {
 		c.state.OnStart(c.count)
 		c.StateCollisionManager.RefreshCollisions()
 
+		if c.stateMachine != nil {
+			c.stateMachine.sync(c.state.State())
+		}
+
 		if c.OnStateChange != nil {
 			c.OnStateChange(oldState, c.state.State())
 		}
@@ -176,15 +209,19 @@ func (c *Character) MovementState() movement.MovementState {
 func (c *Character) Update(space body.BodiesSpace) error {
 	c.count++
 
+	c.effects.Update()
+	c.refreshStats()
+	stunned := c.IsStunned()
+
 	for _, s := range c.skills {
-		if activeSkill, ok := s.(skill.ActiveSkill); ok {
+		if activeSkill, ok := s.(skill.ActiveSkill); ok && !stunned {
 			activeSkill.HandleInput(c, c.movementModel.(*physicsmovement.PlatformMovementModel), space)
 		}
 		s.Update(c, c.movementModel.(*physicsmovement.PlatformMovementModel))
 	}
 
 	// Handle movement by Movement State - must happen BEFORE UpdateMovement
-	if c.movementState != nil {
+	if c.movementState != nil && !stunned {
 		c.movementState.Move(space)
 	}
 
@@ -238,19 +275,18 @@ func (c *Character) handleState() {
 		return
 	}
 
-	// Handle invulnerability timer
-	if c.invulnerabilityTimer > 0 {
-		c.invulnerabilityTimer--
-		if c.invulnerabilityTimer == 0 {
-			c.SetInvulnerability(false)
-		}
-	}
+	c.UpdateInvulnerability()
 
 	// Allow game-specific logic to override the default behavior
 	if c.StateTransitionHandler != nil && c.StateTransitionHandler(c) {
 		return
 	}
 
+	if c.stateMachine != nil {
+		c.stateMachine.Update()
+		return
+	}
+
 	setNewState := func(s ActorStateEnum) {
 		state, err := c.NewState(s)
 		if err != nil {
@@ -286,20 +322,16 @@ func (c *Character) handleState() {
 }
 
 func (c *Character) Hurt(damage int) {
-	if c.Invulnerable() {
+	if !c.AliveBody.Damage(damage, combat.DefaultInvulnerableFrames) {
 		return
 	}
 
-	c.LoseHealth(damage)
-
 	// Switch to Hurt state
 	state, err := c.NewState(Hurted)
 	if err != nil {
 		log.Fatal(err)
 	}
 	c.SetState(state)
-	c.SetInvulnerability(true)
-	c.invulnerabilityTimer = 120 // 2 seconds at 60fps
 }
 
 func (c *Character) SetTouchable(t body.Touchable) {
@@ -0,0 +1,99 @@
+package actors
+
+import (
+	"image"
+
+	"github.com/leandroatallah/firefly/internal/engine/combat"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/animation"
+)
+
+// CombatBoxes returns the hitboxes and hurtboxes declared for each state.
+func (c *Character) CombatBoxes() *combat.Boxes[ActorStateEnum] {
+	return c.combatBoxes
+}
+
+func (c *Character) Team() combat.Team {
+	return c.team
+}
+
+func (c *Character) SetTeam(team combat.Team) {
+	c.team = team
+}
+
+// AnimationFrame returns the frame of the current state animation being displayed.
+func (c *Character) AnimationFrame() int {
+	if c.state == nil {
+		return 0
+	}
+
+	frameRate := c.FrameRate()
+	if frameRate == 0 {
+		frameRate = 1
+	}
+	frame := c.state.GetAnimationCount(c.count) / frameRate
+
+	sprite := c.GetSpriteByState(c.state.State())
+	if sprite == nil || sprite.Image == nil || c.Position().Dx() == 0 {
+		return frame
+	}
+	frameCount := sprite.Image.Bounds().Dx() / c.Position().Dx()
+	if frameCount <= 1 {
+		return 0
+	}
+	if sprite.Loop {
+		return frame % frameCount
+	}
+	return min(frame, frameCount-1)
+}
+
+func (c *Character) facingLeft() bool {
+	return c.FaceDirection() == animation.FaceDirectionLeft
+}
+
+// Hitboxes returns the attack areas active on the current animation frame.
+func (c *Character) Hitboxes() []combat.Hitbox {
+	if c.state == nil {
+		return nil
+	}
+	return c.combatBoxes.Hitboxes(c.state.State(), c.AnimationFrame(), c.Position(), c.facingLeft())
+}
+
+// Hurtboxes returns the areas where the character can be hit. States without
+// hurtboxes fall back to the collision rects.
+func (c *Character) Hurtboxes() []image.Rectangle {
+	if c.state != nil {
+		if rects, ok := c.combatBoxes.Hurtboxes(c.state.State(), c.Position(), c.facingLeft()); ok {
+			return rects
+		}
+	}
+	return c.CollisionPosition()
+}
+
+// TakeDamage applies a damage event with its knockback. The damage goes
+// through the owner's Hurt, so wrappers can react to it (e.g. dying).
+func (c *Character) TakeDamage(e *combat.DamageEvent) bool {
+	if c.Invulnerable() {
+		return false
+	}
+
+	if e.KnockbackX16 != 0 || e.KnockbackY16 != 0 {
+		c.SetVelocity(e.KnockbackX16, e.KnockbackY16)
+	}
+
+	c.hurtOwner(e.Damage)
+
+	if e.InvulnerableFrames > 0 && c.InvulnerabilityTimer() < e.InvulnerableFrames {
+		c.SetInvulnerableFor(e.InvulnerableFrames)
+	}
+	return true
+}
+
+// hurtOwner calls Hurt on the top-most owner, so wrappers can react to the
+// damage (e.g. dying), falling back to the character itself.
+func (c *Character) hurtOwner(damage int) {
+	if hurter, ok := c.LastOwner().(interface{ Hurt(int) }); ok && hurter != any(c) {
+		hurter.Hurt(damage)
+		return
+	}
+	c.Hurt(damage)
+}
@@ -0,0 +1,91 @@
+package actors
+
+import (
+	"log"
+	"math"
+
+	"github.com/leandroatallah/firefly/internal/engine/entity/effects"
+)
+
+func init() {
+	RegisterStateHookFactory("apply_effect", func(name string) StateHook {
+		return func(c *Character) {
+			if err := c.effects.ApplyByName(name, "state"); err != nil {
+				log.Println(err)
+			}
+		}
+	})
+	RegisterStateHookFactory("remove_effect", func(name string) StateHook {
+		return func(c *Character) {
+			c.effects.Remove(name)
+		}
+	})
+}
+
+// baseStats are the stats set by the builder, before any effect is applied.
+type baseStats struct {
+	speed             int
+	maxSpeed          int
+	jumpForce         float64
+	horizontalInertia float64
+}
+
+// Effects returns the status effects active on the character.
+func (c *Character) Effects() *effects.Stack {
+	return c.effects
+}
+
+func (c *Character) SetSpeed(speed int) error {
+	if err := c.MovableBody.SetSpeed(speed); err != nil {
+		return err
+	}
+	c.base.speed = speed
+	c.refreshStats()
+	return nil
+}
+
+func (c *Character) SetMaxSpeed(maxSpeed int) error {
+	if err := c.MovableBody.SetMaxSpeed(maxSpeed); err != nil {
+		return err
+	}
+	c.base.maxSpeed = maxSpeed
+	c.refreshStats()
+	return nil
+}
+
+func (c *Character) SetJumpForceMultiplier(multiplier float64) {
+	c.base.jumpForce = multiplier
+	c.refreshStats()
+}
+
+func (c *Character) SetHorizontalInertia(inertia float64) {
+	c.base.horizontalInertia = inertia
+	c.refreshStats()
+}
+
+// refreshStats applies the active effects over the base stats.
+func (c *Character) refreshStats() {
+	speed := c.effects.Value(effects.StatSpeed, float64(c.base.speed))
+	maxSpeed := c.effects.Value(effects.StatMaxSpeed, float64(c.base.maxSpeed))
+	_ = c.MovableBody.SetSpeed(max(int(math.Round(speed)), 0))
+	_ = c.MovableBody.SetMaxSpeed(max(int(math.Round(maxSpeed)), 0))
+	c.MovableBody.SetJumpForceMultiplier(c.effects.Value(effects.StatJumpForce, c.base.jumpForce))
+	c.MovableBody.SetHorizontalInertia(c.effects.Value(effects.StatHorizontalInertia, c.base.horizontalInertia))
+}
+
+// IsStunned reports whether an effect is blocking the character's input and movement.
+func (c *Character) IsStunned() bool {
+	return c.effects.HasFlag(effects.FlagStun)
+}
+
+// damageOverTime applies the damage of effects such as poison. It doesn't
+// open an invulnerability window; the owner is only hurt when health runs out.
+func (c *Character) damageOverTime(damage int) {
+	if c.Health() == 0 {
+		return
+	}
+	c.LoseHealth(damage)
+	if c.Health() == 0 {
+		c.hurtOwner(0)
+	}
+}
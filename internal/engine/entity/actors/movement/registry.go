@@ -1,6 +1,9 @@
 package movement
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type MovementStateConstructor func(base BaseMovementState) MovementState
 
@@ -43,3 +46,28 @@ func GetMovementStateConstructor(state MovementStateEnum) (MovementStateConstruc
 	}
 	return constructor, nil
 }
+
+var builtinMovementStates = map[string]MovementStateEnum{
+	"input":        Input,
+	"idle":         Idle,
+	"rand":         Rand,
+	"chase":        Chase,
+	"dumb_chase":   DumbChase,
+	"patrol":       Patrol,
+	"avoid":        Avoid,
+	"side_to_side": SideToSide,
+}
+
+// GetMovementStateEnum finds a built-in or registered movement state by name.
+// Names are matched case-insensitively.
+func GetMovementStateEnum(name string) (MovementStateEnum, bool) {
+	if val, ok := builtinMovementStates[strings.ToLower(name)]; ok {
+		return val, true
+	}
+	for n, val := range movementStateEnums {
+		if strings.EqualFold(n, name) {
+			return val, true
+		}
+	}
+	return 0, false
+}
@@ -14,8 +14,14 @@ type SideToSideMovementState struct {
 	waitDuration int
 	waitTimer    int
 	isWaiting    bool
+	// Frames left before heading to a target again after a ledge or a wall
+	turnCooldown int
 }
 
+// pursuitCooldown is how long an actor keeps away from a ledge or a wall it
+// turned at before heading to its target again.
+const pursuitCooldown = 90
+
 // NewSideToSideMovementState creates a new SideToSideMovementState.
 func NewSideToSideMovementState(base BaseMovementState) *SideToSideMovementState {
 	return &SideToSideMovementState{
@@ -33,12 +39,32 @@ func WithWaitBeforeTurn(duration int) MovementStateOption {
 	}
 }
 
+// TurnTowards makes the actor head to the target, e.g. once it spots it,
+// unless a ledge or a wall is that way or it just turned at one.
+func (s *SideToSideMovementState) TurnTowards(target body.Body, space body.BodiesSpace) {
+	if s.isWaiting || s.turnCooldown > 0 || target == nil {
+		return
+	}
+	a, t := s.actor.Position(), target.Position()
+	ac, tc := a.Min.X+a.Max.X, t.Min.X+t.Max.X
+	if ac == tc || (tc > ac) == s.movingRight {
+		return
+	}
+	s.movingRight = !s.movingRight
+	if s.shouldTurn(space) {
+		s.movingRight = !s.movingRight
+	}
+}
+
 // Move executes the side-to-side movement logic. It checks for ledges and walls
 // to reverse direction and then applies movement.
 func (s *SideToSideMovementState) Move(space body.BodiesSpace) {
 	if s.actor.Immobile() {
 		return
 	}
+	if s.turnCooldown > 0 {
+		s.turnCooldown--
+	}
 
 	if s.isWaiting {
 		s.waitTimer--
@@ -50,6 +76,7 @@ func (s *SideToSideMovementState) Move(space body.BodiesSpace) {
 	}
 
 	if s.shouldTurn(space) {
+		s.turnCooldown = pursuitCooldown
 		if s.waitDuration > 0 {
 			s.isWaiting = true
 			s.waitTimer = s.waitDuration
@@ -0,0 +1,433 @@
+package actors
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+)
+
+// StateGuard decides whether a transition may fire for the given character.
+type StateGuard func(c *Character) bool
+
+// StateHook runs when a state (leaf or parent) is entered or exited.
+type StateHook func(c *Character)
+
+// StateNode is a node of a hierarchical state machine. Leaf nodes map to a
+// registered ActorState with the same name; nodes with children are parent
+// states that group leaves (e.g. "carrying" contains carry_idle, carry_walking...).
+type StateNode struct {
+	Name    string
+	Parent  string
+	Initial string // Child entered when a transition targets this parent state.
+	// External marks states entered from code (e.g. Hurt, GrabSheep) instead
+	// of by a transition, so they are not reported as unreachable.
+	External bool
+
+	OnEnter StateHook
+	OnExit  StateHook
+
+	children []string
+}
+
+// StateTransition moves the machine from a state to another when Guard passes.
+// Transitions declared on a parent state apply to all of its descendants, but
+// the ones closer to the current leaf are checked first.
+type StateTransition struct {
+	From      string
+	To        string
+	Guard     StateGuard
+	GuardName string
+}
+
+// StateMachineDef is the declarative (and shareable) part of a state machine.
+// Each character gets its own StateMachine built from a definition.
+type StateMachineDef struct {
+	Initial     string
+	nodes       map[string]*StateNode
+	order       []string
+	transitions []StateTransition
+}
+
+func NewStateMachineDef(initial string) *StateMachineDef {
+	return &StateMachineDef{
+		Initial: initial,
+		nodes:   make(map[string]*StateNode),
+	}
+}
+
+// AddState adds a node to the definition. Parents can be added after children.
+func (d *StateMachineDef) AddState(node StateNode) *StateMachineDef {
+	n := node
+	n.children = nil
+	if _, ok := d.nodes[n.Name]; !ok {
+		d.order = append(d.order, n.Name)
+	}
+	d.nodes[n.Name] = &n
+	d.linkChildren()
+	return d
+}
+
+// AddTransition appends a transition. Transitions from the same state are
+// evaluated in the order they were added.
+func (d *StateMachineDef) AddTransition(t StateTransition) *StateMachineDef {
+	d.transitions = append(d.transitions, t)
+	return d
+}
+
+// OnEnter sets the entry hook of an existing state.
+func (d *StateMachineDef) OnEnter(name string, hook StateHook) *StateMachineDef {
+	if n, ok := d.nodes[name]; ok {
+		n.OnEnter = hook
+	}
+	return d
+}
+
+// OnExit sets the exit hook of an existing state.
+func (d *StateMachineDef) OnExit(name string, hook StateHook) *StateMachineDef {
+	if n, ok := d.nodes[name]; ok {
+		n.OnExit = hook
+	}
+	return d
+}
+
+func (d *StateMachineDef) State(name string) (*StateNode, bool) {
+	n, ok := d.nodes[name]
+	return n, ok
+}
+
+func (d *StateMachineDef) linkChildren() {
+	for _, n := range d.nodes {
+		n.children = n.children[:0]
+	}
+	for _, name := range d.order {
+		n := d.nodes[name]
+		if p, ok := d.nodes[n.Parent]; ok {
+			p.children = append(p.children, name)
+		}
+	}
+}
+
+func (d *StateMachineDef) isLeaf(name string) bool {
+	n, ok := d.nodes[name]
+	return ok && len(n.children) == 0
+}
+
+// path returns the ancestors of a state, from the root down to the state itself.
+func (d *StateMachineDef) path(name string) []string {
+	var p []string
+	for cur := name; cur != ""; {
+		n, ok := d.nodes[cur]
+		if !ok {
+			break
+		}
+		p = append([]string{cur}, p...)
+		cur = n.Parent
+		if len(p) > len(d.nodes) {
+			break // cycle, reported by Validate
+		}
+	}
+	return p
+}
+
+// resolve descends through Initial children until a leaf is found.
+func (d *StateMachineDef) resolve(name string) string {
+	for i := 0; i <= len(d.nodes); i++ {
+		n, ok := d.nodes[name]
+		if !ok || len(n.children) == 0 {
+			return name
+		}
+		name = n.Initial
+	}
+	return name
+}
+
+// Validate checks that the definition is consistent: parents and transitions
+// reference known states, leaves are registered actor states, parent states
+// declare a valid initial child and every state can be reached.
+func (d *StateMachineDef) Validate() error {
+	var errs []string
+	addErr := func(format string, args ...any) {
+		errs = append(errs, fmt.Sprintf(format, args...))
+	}
+
+	if _, ok := d.nodes[d.Initial]; !ok {
+		addErr("initial state %q is not defined", d.Initial)
+	}
+
+	for _, name := range d.order {
+		n := d.nodes[name]
+		if n.Parent != "" {
+			if _, ok := d.nodes[n.Parent]; !ok {
+				addErr("state %q has unknown parent %q", name, n.Parent)
+			}
+		}
+		if len(d.path(name)) > len(d.nodes) {
+			addErr("state %q has a cyclic parent chain", name)
+			continue
+		}
+		if len(n.children) == 0 {
+			if _, ok := GetStateEnum(name); !ok {
+				addErr("leaf state %q is not a registered actor state", name)
+			}
+			continue
+		}
+		if n.Initial == "" {
+			addErr("parent state %q has no initial child", name)
+		} else if !d.isDescendant(n.Initial, name) {
+			addErr("initial %q of parent state %q is not one of its children", n.Initial, name)
+		}
+	}
+
+	for _, t := range d.transitions {
+		if _, ok := d.nodes[t.From]; !ok {
+			addErr("transition %s -> %s: unknown source state", t.From, t.To)
+		}
+		if _, ok := d.nodes[t.To]; !ok {
+			addErr("transition %s -> %s: unknown target state", t.From, t.To)
+		}
+	}
+
+	if len(errs) == 0 {
+		for _, name := range d.Unreachable() {
+			addErr("state %q is unreachable", name)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid state machine: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (d *StateMachineDef) isDescendant(name, ancestor string) bool {
+	for _, p := range d.path(name) {
+		if p == ancestor && p != name {
+			return true
+		}
+	}
+	return false
+}
+
+// Unreachable returns the states that can't be entered from the initial state
+// or from an External state by following transitions.
+func (d *StateMachineDef) Unreachable() []string {
+	reached := make(map[string]bool)
+	var queue []string
+
+	enter := func(name string) {
+		leaf := d.resolve(name)
+		if reached[leaf] {
+			return
+		}
+		for _, p := range d.path(leaf) {
+			reached[p] = true
+		}
+		queue = append(queue, leaf)
+	}
+
+	enter(d.Initial)
+	for _, name := range d.order {
+		if d.nodes[name].External {
+			enter(name)
+		}
+	}
+
+	for len(queue) > 0 {
+		leaf := queue[0]
+		queue = queue[1:]
+		active := d.path(leaf)
+		for _, t := range d.transitions {
+			for _, a := range active {
+				if t.From == a {
+					enter(t.To)
+					break
+				}
+			}
+		}
+	}
+
+	var res []string
+	for _, name := range d.order {
+		if !reached[name] {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+// Dot returns the machine in Graphviz format. Parent states are drawn as
+// clusters and transitions are labeled with their guard names.
+func (d *StateMachineDef) Dot() string {
+	var sb strings.Builder
+	sb.WriteString("digraph StateMachine {\n")
+	sb.WriteString("\tcompound=true;\n")
+	sb.WriteString("\tnode [shape=box, style=rounded];\n")
+	sb.WriteString("\t__start [shape=point];\n")
+
+	var writeNode func(name, indent string)
+	writeNode = func(name, indent string) {
+		n := d.nodes[name]
+		if len(n.children) == 0 {
+			attrs := ""
+			if n.External {
+				attrs = ", style=\"rounded,dashed\""
+			}
+			fmt.Fprintf(&sb, "%s%q [label=%q%s];\n", indent, name, name, attrs)
+			return
+		}
+		fmt.Fprintf(&sb, "%ssubgraph %q {\n", indent, "cluster_"+name)
+		fmt.Fprintf(&sb, "%s\tlabel=%q;\n", indent, name)
+		for _, c := range n.children {
+			writeNode(c, indent+"\t")
+		}
+		fmt.Fprintf(&sb, "%s}\n", indent)
+	}
+
+	roots := make([]string, 0)
+	for _, name := range d.order {
+		if d.nodes[name].Parent == "" {
+			roots = append(roots, name)
+		}
+	}
+	sort.Strings(roots)
+	for _, r := range roots {
+		writeNode(r, "\t")
+	}
+
+	// Edges must connect leaves; clusters are referenced through lhead/ltail.
+	edge := func(from, to, label string) {
+		attrs := []string{}
+		src, dst := d.resolve(from), d.resolve(to)
+		if !d.isLeaf(from) {
+			attrs = append(attrs, fmt.Sprintf("ltail=%q", "cluster_"+from))
+		}
+		if !d.isLeaf(to) {
+			attrs = append(attrs, fmt.Sprintf("lhead=%q", "cluster_"+to))
+		}
+		if label != "" {
+			attrs = append(attrs, fmt.Sprintf("label=%q", label))
+		}
+		fmt.Fprintf(&sb, "\t%q -> %q", src, dst)
+		if len(attrs) > 0 {
+			fmt.Fprintf(&sb, " [%s]", strings.Join(attrs, ", "))
+		}
+		sb.WriteString(";\n")
+	}
+
+	fmt.Fprintf(&sb, "\t__start -> %q;\n", d.resolve(d.Initial))
+	for _, t := range d.transitions {
+		edge(t.From, t.To, t.GuardName)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// StateMachine is the per-character instance of a StateMachineDef.
+type StateMachine struct {
+	def       *StateMachineDef
+	character *Character
+	current   string
+}
+
+func NewStateMachine(def *StateMachineDef, c *Character) *StateMachine {
+	return &StateMachine{def: def, character: c}
+}
+
+func (m *StateMachine) Def() *StateMachineDef {
+	return m.def
+}
+
+// Current returns the name of the active leaf state.
+func (m *StateMachine) Current() string {
+	return m.current
+}
+
+// IsIn reports whether the given state, or one of its children, is active.
+func (m *StateMachine) IsIn(name string) bool {
+	for _, p := range m.def.path(m.current) {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Start adopts the character's current state if the machine knows it,
+// otherwise it enters the initial state.
+func (m *StateMachine) Start() {
+	if m.character.state != nil {
+		m.sync(m.character.state.State())
+	}
+	if _, ok := m.def.nodes[m.current]; !ok {
+		m.TransitionTo(m.def.Initial)
+	}
+}
+
+// TransitionTo enters the given state, resolving parent states to their initial leaf.
+func (m *StateMachine) TransitionTo(name string) {
+	leaf := m.def.resolve(name)
+	enum, ok := GetStateEnum(leaf)
+	if !ok {
+		log.Printf("state machine: state %q is not registered", leaf)
+		return
+	}
+	state, err := m.character.NewState(enum)
+	if err != nil {
+		log.Printf("state machine: %v", err)
+		return
+	}
+	// SetState calls back into sync, which runs the exit and entry hooks.
+	m.character.SetState(state)
+}
+
+// Update fires the first transition whose guard passes. Transitions of the
+// current leaf are checked before the ones of its parents.
+func (m *StateMachine) Update() {
+	active := m.def.path(m.current)
+	for i := len(active) - 1; i >= 0; i-- {
+		for _, t := range m.def.transitions {
+			if t.From != active[i] {
+				continue
+			}
+			// Targeting the state we're already in is a no-op, keep looking.
+			if m.def.resolve(t.To) == m.current {
+				continue
+			}
+			if t.Guard != nil && !t.Guard(m.character) {
+				continue
+			}
+			m.TransitionTo(t.To)
+			return
+		}
+	}
+}
+
+// sync runs exit and entry hooks when the character state changes, whether the
+// change came from a transition or from code calling SetState directly.
+func (m *StateMachine) sync(newState ActorStateEnum) {
+	name, ok := GetStateName(newState)
+	if !ok || name == m.current {
+		return
+	}
+
+	oldPath := m.def.path(m.current)
+	newPath := m.def.path(name)
+
+	common := 0
+	for common < len(oldPath) && common < len(newPath) && oldPath[common] == newPath[common] {
+		common++
+	}
+
+	for i := len(oldPath) - 1; i >= common; i-- {
+		if n := m.def.nodes[oldPath[i]]; n.OnExit != nil {
+			n.OnExit(m.character)
+		}
+	}
+	m.current = name
+	for i := common; i < len(newPath); i++ {
+		if n := m.def.nodes[newPath[i]]; n.OnEnter != nil {
+			n.OnEnter(m.character)
+		}
+	}
+}
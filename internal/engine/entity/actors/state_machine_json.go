@@ -0,0 +1,148 @@
+package actors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	stateGuards        = make(map[string]StateGuard)
+	stateHooks         = make(map[string]StateHook)
+	stateHookFactories = make(map[string]func(arg string) StateHook)
+)
+
+// RegisterStateGuard makes a guard available to JSON state machines.
+// A guard name prefixed with "!" in JSON negates it.
+func RegisterStateGuard(name string, guard StateGuard) {
+	stateGuards[name] = guard
+}
+
+// RegisterStateHook makes an entry/exit hook available to JSON state machines.
+func RegisterStateHook(name string, hook StateHook) {
+	stateHooks[name] = hook
+}
+
+// RegisterStateHookFactory makes a hook with an argument available to JSON
+// state machines, written as "name:arg" (e.g. "apply_effect:carrying").
+func RegisterStateHookFactory(name string, factory func(arg string) StateHook) {
+	stateHookFactories[name] = factory
+}
+
+func init() {
+	RegisterStateGuard("idle", func(c *Character) bool { return c.IsIdle() })
+	RegisterStateGuard("walking", func(c *Character) bool { return c.IsWalking() })
+	RegisterStateGuard("falling", func(c *Character) bool { return c.IsFalling() })
+	RegisterStateGuard("going_up", func(c *Character) bool { return c.IsGoingUp() })
+	RegisterStateGuard("animation_finished", func(c *Character) bool { return c.IsAnimationFinished() })
+	RegisterStateGuard("dead", func(c *Character) bool { return c.Health() <= 0 })
+}
+
+type StateNodeData struct {
+	Name     string `json:"name"`
+	Parent   string `json:"parent,omitempty"`
+	Initial  string `json:"initial,omitempty"`
+	External bool   `json:"external,omitempty"`
+	OnEnter  string `json:"on_enter,omitempty"`
+	OnExit   string `json:"on_exit,omitempty"`
+}
+
+type StateTransitionData struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Guard string `json:"guard,omitempty"`
+}
+
+type StateMachineData struct {
+	Initial     string                `json:"initial"`
+	States      []StateNodeData       `json:"states"`
+	Transitions []StateTransitionData `json:"transitions"`
+}
+
+func lookupGuard(name string) (StateGuard, error) {
+	negate := strings.HasPrefix(name, "!")
+	guard, ok := stateGuards[strings.TrimPrefix(name, "!")]
+	if !ok {
+		return nil, fmt.Errorf("unknown guard %q", name)
+	}
+	if negate {
+		return func(c *Character) bool { return !guard(c) }, nil
+	}
+	return guard, nil
+}
+
+func lookupHook(name string) (StateHook, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if prefix, arg, found := strings.Cut(name, ":"); found {
+		factory, ok := stateHookFactories[prefix]
+		if !ok {
+			return nil, fmt.Errorf("unknown hook %q", name)
+		}
+		return factory(arg), nil
+	}
+	hook, ok := stateHooks[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown hook %q", name)
+	}
+	return hook, nil
+}
+
+// NewStateMachineDefFromData builds and validates a definition.
+func NewStateMachineDefFromData(data StateMachineData) (*StateMachineDef, error) {
+	def := NewStateMachineDef(data.Initial)
+
+	for _, s := range data.States {
+		onEnter, err := lookupHook(s.OnEnter)
+		if err != nil {
+			return nil, fmt.Errorf("state %q: %w", s.Name, err)
+		}
+		onExit, err := lookupHook(s.OnExit)
+		if err != nil {
+			return nil, fmt.Errorf("state %q: %w", s.Name, err)
+		}
+		def.AddState(StateNode{
+			Name:     s.Name,
+			Parent:   s.Parent,
+			Initial:  s.Initial,
+			External: s.External,
+			OnEnter:  onEnter,
+			OnExit:   onExit,
+		})
+	}
+
+	for _, t := range data.Transitions {
+		var guard StateGuard
+		if t.Guard != "" {
+			g, err := lookupGuard(t.Guard)
+			if err != nil {
+				return nil, fmt.Errorf("transition %s -> %s: %w", t.From, t.To, err)
+			}
+			guard = g
+		}
+		def.AddTransition(StateTransition{From: t.From, To: t.To, Guard: guard, GuardName: t.Guard})
+	}
+
+	if err := def.Validate(); err != nil {
+		return nil, err
+	}
+
+	return def, nil
+}
+
+// ParseJsonStateMachine loads a state machine definition from a JSON file.
+func ParseJsonStateMachine(path string) (*StateMachineDef, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var smData StateMachineData
+	if err := json.Unmarshal(data, &smData); err != nil {
+		return nil, err
+	}
+
+	return NewStateMachineDefFromData(smData)
+}
@@ -0,0 +1,128 @@
+package actors
+
+import (
+	"strings"
+	"testing"
+
+	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
+	"github.com/leandroatallah/firefly/internal/engine/render/sprites"
+)
+
+func newTestDef(moving *bool, hooks *[]string) *StateMachineDef {
+	record := func(s string) StateHook {
+		return func(*Character) { *hooks = append(*hooks, s) }
+	}
+	isMoving := func(*Character) bool { return *moving }
+	isStopped := func(*Character) bool { return !*moving }
+
+	return NewStateMachineDef("ground").
+		AddState(StateNode{Name: "ground", Initial: "idle", OnEnter: record("enter ground"), OnExit: record("exit ground")}).
+		AddState(StateNode{Name: "idle", Parent: "ground", OnEnter: record("enter idle"), OnExit: record("exit idle")}).
+		AddState(StateNode{Name: "walk", Parent: "ground", OnEnter: record("enter walk"), OnExit: record("exit walk")}).
+		AddState(StateNode{Name: "hurt", External: true, OnEnter: record("enter hurt")}).
+		AddTransition(StateTransition{From: "ground", To: "walk", Guard: isMoving, GuardName: "moving"}).
+		AddTransition(StateTransition{From: "ground", To: "idle", Guard: isStopped, GuardName: "!moving"}).
+		AddTransition(StateTransition{From: "hurt", To: "ground"})
+}
+
+func TestStateMachine_Transitions(t *testing.T) {
+	moving := false
+	var hooks []string
+	def := newTestDef(&moving, &hooks)
+	if err := def.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	c := NewCharacter(sprites.SpriteMap{}, bodyphysics.NewRect(0, 0, 8, 8))
+	c.SetStateMachine(def)
+
+	if got := c.StateMachine().Current(); got != "idle" {
+		t.Fatalf("Current() = %q, want idle", got)
+	}
+
+	moving = true
+	c.StateMachine().Update()
+	if c.State() != Walking {
+		t.Errorf("State() = %v, want Walking", c.State())
+	}
+	if !c.StateMachine().IsIn("ground") {
+		t.Errorf("IsIn(ground) = false, want true")
+	}
+
+	// Entering a state from code runs the hooks too.
+	hooks = nil
+	state, _ := c.NewState(Hurted)
+	c.SetState(state)
+	want := []string{"exit walk", "exit ground", "enter hurt"}
+	if strings.Join(hooks, ",") != strings.Join(want, ",") {
+		t.Errorf("hooks = %v, want %v", hooks, want)
+	}
+
+	// A transition to a parent state enters its initial child.
+	c.StateMachine().Update()
+	if got := c.StateMachine().Current(); got != "idle" {
+		t.Errorf("Current() = %q, want idle", got)
+	}
+}
+
+func TestStateMachineDef_Validate(t *testing.T) {
+	moving := false
+	var hooks []string
+
+	tests := []struct {
+		name    string
+		def     *StateMachineDef
+		wantErr string
+	}{
+		{
+			name: "unreachable state",
+			def: newTestDef(&moving, &hooks).
+				AddState(StateNode{Name: "fall"}),
+			wantErr: `state "fall" is unreachable`,
+		},
+		{
+			name: "unregistered leaf",
+			def: newTestDef(&moving, &hooks).
+				AddState(StateNode{Name: "swim", External: true}),
+			wantErr: `leaf state "swim" is not a registered actor state`,
+		},
+		{
+			name: "unknown target",
+			def: newTestDef(&moving, &hooks).
+				AddTransition(StateTransition{From: "idle", To: "jump"}),
+			wantErr: "unknown target state",
+		},
+		{
+			name: "initial outside parent",
+			def: newTestDef(&moving, &hooks).
+				AddState(StateNode{Name: "ground", Initial: "hurt"}),
+			wantErr: `initial "hurt" of parent state "ground" is not one of its children`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.def.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStateMachineDef_Dot(t *testing.T) {
+	moving := false
+	var hooks []string
+	dot := newTestDef(&moving, &hooks).Dot()
+
+	for _, want := range []string{
+		`subgraph "cluster_ground"`,
+		`__start -> "idle"`,
+		`"idle" -> "walk" [ltail="cluster_ground", label="moving"]`,
+		`"hurt" -> "idle" [lhead="cluster_ground"]`,
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("Dot() missing %q:\n%s", want, dot)
+		}
+	}
+}
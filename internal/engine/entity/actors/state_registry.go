@@ -37,3 +37,12 @@ func GetStateEnum(name string) (ActorStateEnum, bool) {
 	val, ok := stateEnums[name]
 	return val, ok
 }
+
+func GetStateName(state ActorStateEnum) (string, bool) {
+	for name, val := range stateEnums {
+		if val == state {
+			return name, true
+		}
+	}
+	return "", false
+}
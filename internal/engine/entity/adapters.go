@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
+	"github.com/leandroatallah/firefly/internal/engine/entity/items"
+)
+
+// FromActor creates an entity for an existing actor. The actor still updates
+// itself through a Script component, so it can be migrated to components
+// (AI, Skills, Movement) one piece at a time.
+func FromActor(w *World, a actors.ActorEntity) ID {
+	id := w.NewEntity()
+	x, y := a.GetPositionMin()
+	Add(w, id, Transform{X: x, Y: y})
+	Add(w, id, Body{Body: a})
+	Add(w, id, Sprite{Drawable: a})
+	Add(w, id, Health{Alive: a})
+	Add(w, id, Script{Update: a.Update})
+	Add(w, id, Ref{Value: a})
+	return id
+}
+
+// FromItem creates an entity for an existing item. Items marked as removed
+// are taken out of the space instead of being updated.
+func FromItem(w *World, i items.Item) ID {
+	id := w.NewEntity()
+	x, y := i.GetPositionMin()
+	Add(w, id, Transform{X: x, Y: y})
+	Add(w, id, Body{Body: i})
+	Add(w, id, Sprite{Drawable: i})
+	Add(w, id, Script{Update: func(space body.BodiesSpace) error {
+		if i.IsRemoved() {
+			space.RemoveBody(i)
+			return nil
+		}
+		return i.Update(space)
+	}})
+	Add(w, id, Ref{Value: i})
+	return id
+}
+
+// Adapt creates an entity for actors and items. It can be passed to SyncSpace.
+func Adapt(w *World, b body.Collidable) (ID, bool) {
+	// ActorEntity should come first. It can be confused with an item.
+	switch v := b.(type) {
+	case actors.ActorEntity:
+		return FromActor(w, v), true
+	case items.Item:
+		return FromItem(w, v), true
+	}
+	return 0, false
+}
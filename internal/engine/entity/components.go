@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/entity/actors/movement"
+	physicsmovement "github.com/leandroatallah/firefly/internal/engine/physics/movement"
+	"github.com/leandroatallah/firefly/internal/engine/physics/skill"
+)
+
+// Transform is the entity position in world pixels. It is kept in sync with
+// the Body component by TransformSystem.
+type Transform struct {
+	X, Y int
+}
+
+// Body links the entity to its physics body in the space.
+type Body struct {
+	Body body.MovableCollidable
+}
+
+// Movement applies a movement model (platform, top-down) to the Body.
+type Movement struct {
+	Model physicsmovement.MovementModel
+}
+
+// Sprite draws the entity.
+type Sprite struct {
+	Drawable body.Drawable
+	Hidden   bool
+}
+
+// Health exposes the alive body of the entity.
+type Health struct {
+	Alive body.Alive
+}
+
+// AI moves the Body with a movement state (chase, patrol, wander...).
+type AI struct {
+	State movement.MovementState
+}
+
+// Skills are updated against the Body and its platform Movement model.
+type Skills struct {
+	List []skill.Skill
+}
+
+// Script runs custom per-tick logic. Adapted actors and items keep their own
+// Update method here until their behaviour is moved into components.
+type Script struct {
+	Update func(space body.BodiesSpace) error
+}
+
+// Ref points back to the object an entity was adapted from.
+type Ref struct {
+	Value any
+}
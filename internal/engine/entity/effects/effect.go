@@ -0,0 +1,127 @@
+package effects
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Stat is an actor attribute that effects can modify.
+type Stat string
+
+const (
+	StatSpeed             Stat = "speed"
+	StatMaxSpeed          Stat = "max_speed"
+	StatJumpForce         Stat = "jump_force"
+	StatHorizontalInertia Stat = "horizontal_inertia"
+)
+
+// Op is how a modifier is combined with the base value of a stat:
+// set replaces it, then additions are summed and multiplications applied.
+type Op string
+
+const (
+	OpAdd Op = "add"
+	OpMul Op = "mul"
+	OpSet Op = "set"
+)
+
+// Stacking decides what happens when an effect is applied to an actor that already has it.
+type Stacking string
+
+const (
+	// StackRefresh restarts the duration. It is the default.
+	StackRefresh Stacking = "refresh"
+	// StackExtend adds the duration to the remaining time.
+	StackExtend Stacking = "extend"
+	// StackIntensity adds a stack, up to MaxStacks, and restarts the duration.
+	StackIntensity Stacking = "stack"
+	// StackIgnore keeps the current effect untouched.
+	StackIgnore Stacking = "ignore"
+)
+
+// FlagStun blocks input and movement states while the effect is active.
+const FlagStun = "stun"
+
+type Modifier struct {
+	Stat  Stat    `json:"stat"`
+	Op    Op      `json:"op"`
+	Value float64 `json:"value"`
+}
+
+// Definition describes an effect such as slow, haste, stun or poison.
+type Definition struct {
+	Name      string     `json:"name"`
+	Duration  int        `json:"duration"` // Frames. Zero lasts until the effect is removed.
+	Stacking  Stacking   `json:"stacking,omitempty"`
+	MaxStacks int        `json:"max_stacks,omitempty"`
+	Modifiers []Modifier `json:"modifiers,omitempty"`
+	Flags     []string   `json:"flags,omitempty"`
+
+	// Damage is dealt every Interval frames, e.g. poison.
+	Damage   int `json:"damage,omitempty"`
+	Interval int `json:"interval,omitempty"`
+}
+
+func (d *Definition) Validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("effect name is required")
+	}
+	switch d.Stacking {
+	case "", StackRefresh, StackExtend, StackIntensity, StackIgnore:
+	default:
+		return fmt.Errorf("effect %s: unknown stacking %q", d.Name, d.Stacking)
+	}
+	for _, m := range d.Modifiers {
+		switch m.Stat {
+		case StatSpeed, StatMaxSpeed, StatJumpForce, StatHorizontalInertia:
+		default:
+			return fmt.Errorf("effect %s: unknown stat %q", d.Name, m.Stat)
+		}
+		switch m.Op {
+		case OpAdd, OpMul, OpSet:
+		default:
+			return fmt.Errorf("effect %s: unknown op %q", d.Name, m.Op)
+		}
+	}
+	if d.Damage > 0 && d.Interval <= 0 {
+		return fmt.Errorf("effect %s: damage requires an interval", d.Name)
+	}
+	return nil
+}
+
+var definitions = make(map[string]*Definition)
+
+// Register makes an effect definition available by name.
+func Register(def *Definition) error {
+	if err := def.Validate(); err != nil {
+		return err
+	}
+	definitions[def.Name] = def
+	return nil
+}
+
+func Get(name string) (*Definition, bool) {
+	def, ok := definitions[name]
+	return def, ok
+}
+
+// LoadDefinitions registers every effect declared in a JSON array.
+func LoadDefinitions(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var defs []*Definition
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return err
+	}
+
+	for _, def := range defs {
+		if err := Register(def); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
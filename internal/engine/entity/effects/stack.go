@@ -0,0 +1,224 @@
+package effects
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/leandroatallah/firefly/internal/engine/event"
+)
+
+const (
+	EffectAppliedEventType = "effect_applied"
+	EffectRemovedEventType = "effect_removed"
+)
+
+type EffectAppliedEvent struct {
+	Target any
+	Effect string
+	Stacks int
+	Source string
+	// First is false when an active effect is refreshed, extended or
+	// stacked.
+	First bool
+}
+
+func (e *EffectAppliedEvent) Type() string {
+	return EffectAppliedEventType
+}
+
+type EffectRemovedEvent struct {
+	Target  any
+	Effect  string
+	Expired bool // False when the effect was removed before its duration ended.
+}
+
+func (e *EffectRemovedEvent) Type() string {
+	return EffectRemovedEventType
+}
+
+// Effect is an active instance of a definition on an actor.
+type Effect struct {
+	def       *Definition
+	source    string
+	remaining int
+	stacks    int
+	age       int
+}
+
+func (e *Effect) Name() string {
+	return e.def.Name
+}
+
+func (e *Effect) Stacks() int {
+	return e.stacks
+}
+
+// Remaining returns the frames left, or zero for effects without duration.
+func (e *Effect) Remaining() int {
+	return e.remaining
+}
+
+// Stack is the list of effects active on an actor.
+type Stack struct {
+	target  any
+	effects []*Effect
+	events  *event.Manager
+
+	// OnDamage is called for effects that deal damage over time.
+	OnDamage func(damage int)
+}
+
+func NewStack(target any) *Stack {
+	return &Stack{target: target}
+}
+
+// SetEventManager enables the applied and removed events.
+func (s *Stack) SetEventManager(events *event.Manager) {
+	s.events = events
+}
+
+// ApplyByName applies a registered effect.
+func (s *Stack) ApplyByName(name, source string) error {
+	def, ok := Get(name)
+	if !ok {
+		return fmt.Errorf("unknown effect: %s", name)
+	}
+	s.Apply(def, source)
+	return nil
+}
+
+// Apply adds an effect, following its stacking rule if it is already active.
+func (s *Stack) Apply(def *Definition, source string) {
+	if e := s.find(def.Name); e != nil {
+		switch def.Stacking {
+		case StackIgnore:
+			return
+		case StackExtend:
+			if def.Duration > 0 {
+				e.remaining += def.Duration
+			}
+		case StackIntensity:
+			if def.MaxStacks <= 0 || e.stacks < def.MaxStacks {
+				e.stacks++
+			}
+			e.remaining = def.Duration
+		default:
+			e.remaining = def.Duration
+		}
+		e.source = source
+		s.publish(&EffectAppliedEvent{Target: s.target, Effect: def.Name, Stacks: e.stacks, Source: source})
+		return
+	}
+
+	s.effects = append(s.effects, &Effect{
+		def:       def,
+		source:    source,
+		remaining: def.Duration,
+		stacks:    1,
+	})
+	s.publish(&EffectAppliedEvent{Target: s.target, Effect: def.Name, Stacks: 1, Source: source, First: true})
+}
+
+// Remove ends an effect before its duration.
+func (s *Stack) Remove(name string) {
+	i := slices.IndexFunc(s.effects, func(e *Effect) bool { return e.def.Name == name })
+	if i < 0 {
+		return
+	}
+	s.effects = slices.Delete(s.effects, i, i+1)
+	s.publish(&EffectRemovedEvent{Target: s.target, Effect: name})
+}
+
+// Clear removes every effect.
+func (s *Stack) Clear() {
+	for len(s.effects) > 0 {
+		s.Remove(s.effects[len(s.effects)-1].def.Name)
+	}
+}
+
+func (s *Stack) Has(name string) bool {
+	return s.find(name) != nil
+}
+
+// HasFlag reports whether any active effect sets the flag (e.g. FlagStun).
+func (s *Stack) HasFlag(flag string) bool {
+	for _, e := range s.effects {
+		if slices.Contains(e.def.Flags, flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Effects returns the active effects in the order they were applied.
+func (s *Stack) Effects() []*Effect {
+	return s.effects
+}
+
+// Update deals the damage over time and expires the effects whose duration ended.
+// It must be called once per frame.
+func (s *Stack) Update() {
+	n := 0
+	var expired []string
+	for _, e := range s.effects {
+		e.age++
+		if e.def.Damage > 0 && e.age%e.def.Interval == 0 && s.OnDamage != nil {
+			s.OnDamage(e.def.Damage * e.stacks)
+		}
+
+		if e.def.Duration > 0 {
+			e.remaining--
+			if e.remaining <= 0 {
+				expired = append(expired, e.def.Name)
+				continue
+			}
+		}
+		s.effects[n] = e
+		n++
+	}
+	clear(s.effects[n:])
+	s.effects = s.effects[:n]
+
+	for _, name := range expired {
+		s.publish(&EffectRemovedEvent{Target: s.target, Effect: name, Expired: true})
+	}
+}
+
+// Value returns base modified by the active effects. The last "set" replaces
+// the base, then additions are summed and multiplications applied. Stacked
+// effects apply their modifiers once per stack.
+func (s *Stack) Value(stat Stat, base float64) float64 {
+	add, mul := 0.0, 1.0
+	for _, e := range s.effects {
+		for _, m := range e.def.Modifiers {
+			if m.Stat != stat {
+				continue
+			}
+			switch m.Op {
+			case OpSet:
+				base = m.Value
+			case OpAdd:
+				add += m.Value * float64(e.stacks)
+			case OpMul:
+				mul *= math.Pow(m.Value, float64(e.stacks))
+			}
+		}
+	}
+	return (base + add) * mul
+}
+
+func (s *Stack) find(name string) *Effect {
+	for _, e := range s.effects {
+		if e.def.Name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+func (s *Stack) publish(e event.Event) {
+	if s.events != nil {
+		s.events.Publish(e)
+	}
+}
@@ -0,0 +1,119 @@
+package effects
+
+import (
+	"testing"
+
+	"github.com/leandroatallah/firefly/internal/engine/event"
+)
+
+func TestStackValue(t *testing.T) {
+	s := NewStack(nil)
+	s.Apply(&Definition{Name: "carrying", Modifiers: []Modifier{
+		{Stat: StatSpeed, Op: OpMul, Value: 0.5},
+		{Stat: StatHorizontalInertia, Op: OpSet, Value: 1},
+	}}, "")
+	s.Apply(&Definition{Name: "boots", Modifiers: []Modifier{
+		{Stat: StatSpeed, Op: OpAdd, Value: 2},
+	}}, "")
+
+	if got := s.Value(StatSpeed, 4); got != 3 {
+		t.Errorf("expected speed (4+2)*0.5 = 3, got %v", got)
+	}
+	if got := s.Value(StatHorizontalInertia, -1); got != 1 {
+		t.Errorf("expected inertia to be set to 1, got %v", got)
+	}
+	if got := s.Value(StatJumpForce, 1); got != 1 {
+		t.Errorf("expected unmodified jump force, got %v", got)
+	}
+}
+
+func TestStackStackingRules(t *testing.T) {
+	slow := &Definition{
+		Name: "slow", Duration: 10, Stacking: StackIntensity, MaxStacks: 2,
+		Modifiers: []Modifier{{Stat: StatSpeed, Op: OpMul, Value: 0.5}},
+	}
+	haste := &Definition{Name: "haste", Duration: 10, Stacking: StackExtend}
+	stun := &Definition{Name: "stun", Duration: 10, Stacking: StackIgnore, Flags: []string{FlagStun}}
+
+	s := NewStack(nil)
+	for i := 0; i < 3; i++ {
+		s.Apply(slow, "")
+		s.Apply(haste, "")
+		s.Apply(stun, "")
+	}
+
+	for _, e := range s.Effects() {
+		switch e.Name() {
+		case "slow":
+			if e.Stacks() != 2 {
+				t.Errorf("expected slow capped at 2 stacks, got %d", e.Stacks())
+			}
+		case "haste":
+			if e.Remaining() != 30 {
+				t.Errorf("expected haste extended to 30 frames, got %d", e.Remaining())
+			}
+		case "stun":
+			if e.Remaining() != 10 {
+				t.Errorf("expected stun to be ignored when reapplied, got %d", e.Remaining())
+			}
+		}
+	}
+	if got := s.Value(StatSpeed, 8); got != 2 {
+		t.Errorf("expected two stacks of slow to give 8*0.5*0.5 = 2, got %v", got)
+	}
+	if !s.HasFlag(FlagStun) {
+		t.Error("expected stun flag")
+	}
+}
+
+func TestStackExpiryAndEvents(t *testing.T) {
+	events := event.NewManager()
+	var applied, removed []string
+	events.Subscribe(EffectAppliedEventType, func(e event.Event) {
+		applied = append(applied, e.(*EffectAppliedEvent).Effect)
+	})
+	events.Subscribe(EffectRemovedEventType, func(e event.Event) {
+		evt := e.(*EffectRemovedEvent)
+		if !evt.Expired {
+			t.Errorf("expected %s to expire", evt.Effect)
+		}
+		removed = append(removed, evt.Effect)
+	})
+
+	damage := 0
+	s := NewStack(nil)
+	s.SetEventManager(events)
+	s.OnDamage = func(d int) { damage += d }
+	s.Apply(&Definition{Name: "poison", Duration: 6, Damage: 1, Interval: 2}, "")
+
+	for i := 0; i < 10; i++ {
+		s.Update()
+	}
+
+	if s.Has("poison") {
+		t.Error("expected poison to expire")
+	}
+	if damage != 3 {
+		t.Errorf("expected 3 damage ticks, got %d", damage)
+	}
+	if len(applied) != 1 || len(removed) != 1 {
+		t.Errorf("expected one applied and one removed event, got %v and %v", applied, removed)
+	}
+}
+
+func TestAppliedFirst(t *testing.T) {
+	events := event.NewManager()
+	var first []bool
+	events.Subscribe(EffectAppliedEventType, func(e event.Event) {
+		first = append(first, e.(*EffectAppliedEvent).First)
+	})
+
+	s := NewStack(nil)
+	s.SetEventManager(events)
+	burn := &Definition{Name: "burn", Duration: 10, Stacking: StackExtend}
+	s.Apply(burn, "")
+	s.Apply(burn, "")
+	if len(first) != 2 || !first[0] || first[1] {
+		t.Errorf("first = %v, want only the first application", first)
+	}
+}
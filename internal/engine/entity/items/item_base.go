@@ -7,6 +7,7 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/leandroatallah/firefly/internal/engine/app"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/entity/effects"
 	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
 	"github.com/leandroatallah/firefly/internal/engine/physics/space"
 	"github.com/leandroatallah/firefly/internal/engine/render/sprites"
@@ -24,6 +25,9 @@ type BaseItem struct {
 	imageOptions *ebiten.DrawImageOptions
 	state        ItemState
 	bodyphysics.Ownership
+
+	// Effects applied to the actor that picks up the item
+	pickupEffects []string
 }
 
 func NewBaseItem(id string, s sprites.SpriteMap, bodyRect *bodyphysics.Rect) *BaseItem {
@@ -86,7 +90,6 @@ func (b *BaseItem) SetTouchable(t body.Touchable) {
 	b.Touchable = t
 }
 
-
 func (b *BaseItem) Update(space body.BodiesSpace) error {
 	b.count++
 
@@ -130,6 +133,24 @@ func (b *BaseItem) SetRemoved(value bool) {
 	b.removed = value
 }
 
+func (b *BaseItem) PickupEffects() []string {
+	return b.pickupEffects
+}
+
+func (b *BaseItem) SetPickupEffects(names []string) {
+	b.pickupEffects = names
+}
+
+// ApplyPickupEffects applies the item effects to the actor that picked it up.
+func (b *BaseItem) ApplyPickupEffects(target *effects.Stack) error {
+	for _, name := range b.pickupEffects {
+		if err := target.ApplyByName(name, b.ID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *BaseItem) State() ItemStateEnum {
 	return b.state.State()
 }
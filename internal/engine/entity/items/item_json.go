@@ -9,6 +9,8 @@ import (
 
 type StatData struct {
 	Id string `json:"id"`
+	// Effects are applied to the actor that picks up the item (e.g. "haste").
+	Effects []string `json:"effects,omitempty"`
 }
 
 type ItemData struct {
@@ -0,0 +1,131 @@
+package prefabs
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/leandroatallah/firefly/internal/engine/data/schemas"
+	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
+)
+
+// MovementOptionsData holds the optional parameters of a movement state.
+type MovementOptionsData struct {
+	WaitBeforeTurn int `json:"wait_before_turn"`
+}
+
+// PerceptionData holds how far an actor sees.
+type PerceptionData struct {
+	// SightRange is how far, in pixels, the actor sees in full light.
+	SightRange float64 `json:"sight_range"`
+	// DarkSight is the part of the sight range left in complete darkness.
+	DarkSight float64 `json:"dark_sight"`
+}
+
+// Prefab declares everything needed to build an entity, so that Tiled objects
+// can reference it by name through a "prefab" property.
+type Prefab struct {
+	Name string `json:"name"`
+	// Kind selects the spawn function registered on the Spawner (e.g. "enemy", "npc").
+	Kind string `json:"kind"`
+
+	Sprites         schemas.SpriteData  `json:"sprites"`
+	Stats           actors.StatData     `json:"stats"`
+	MovementModel   string              `json:"movement_model"`
+	MovementState   string              `json:"movement_state"`
+	MovementOptions MovementOptionsData `json:"movement_options"`
+	Skills          []string            `json:"skills"`
+	Touch           string              `json:"touch"`
+	Perception      PerceptionData      `json:"perception"`
+}
+
+// reservedProperties are object properties used by the tilemap itself.
+var reservedProperties = map[string]bool{
+	"prefab":  true,
+	"body_id": true,
+}
+
+// WithOverrides returns a copy of the prefab with the given object properties
+// applied. Property names are JSON paths of prefab fields, using dots for
+// nested fields (e.g. "stats.speed" or "movement_options.wait_before_turn").
+// Array fields accept a comma separated list.
+func (p Prefab) WithOverrides(props map[string]string) (Prefab, error) {
+	if len(props) == 0 {
+		return p, nil
+	}
+
+	// Keep list fields as lists so they can be overridden
+	if p.Skills == nil {
+		p.Skills = []string{}
+	}
+
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return p, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return p, err
+	}
+
+	for name, value := range props {
+		if reservedProperties[name] {
+			continue
+		}
+		if err := setPath(fields, strings.Split(name, "."), value); err != nil {
+			log.Printf("prefab %s: ignoring property %q: %v", p.Name, name, err)
+		}
+	}
+
+	raw, err = json.Marshal(fields)
+	if err != nil {
+		return p, err
+	}
+	var res Prefab
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return p, fmt.Errorf("prefab %s: invalid override: %w", p.Name, err)
+	}
+	return res, nil
+}
+
+func setPath(fields map[string]any, path []string, value string) error {
+	current, ok := fields[path[0]]
+	if !ok {
+		return fmt.Errorf("unknown field %q", path[0])
+	}
+
+	if len(path) > 1 {
+		nested, ok := current.(map[string]any)
+		if !ok {
+			return fmt.Errorf("field %q is not an object", path[0])
+		}
+		return setPath(nested, path[1:], value)
+	}
+
+	switch current.(type) {
+	case string:
+		fields[path[0]] = value
+		return nil
+	case []any:
+		var list []any
+		if err := json.Unmarshal([]byte(value), &list); err != nil {
+			list = []any{}
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					list = append(list, item)
+				}
+			}
+		}
+		fields[path[0]] = list
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		// Plain strings are not valid JSON.
+		v = value
+	}
+	fields[path[0]] = v
+	return nil
+}
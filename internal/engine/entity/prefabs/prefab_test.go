@@ -0,0 +1,44 @@
+package prefabs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
+)
+
+func TestPrefab_WithOverrides(t *testing.T) {
+	base := Prefab{
+		Name:          "wolf",
+		Kind:          "enemy",
+		Stats:         actors.StatData{Health: 1, Speed: 3, MaxSpeed: 3},
+		MovementState: "side_to_side",
+		Touch:         "hurt",
+	}
+
+	got, err := base.WithOverrides(map[string]string{
+		"prefab":                            "wolf",
+		"body_id":                           "WOLF_1",
+		"stats.speed":                       "2",
+		"movement_state":                    "chase",
+		"movement_options.wait_before_turn": "30",
+		"skills":                            "jump, dash",
+		"unknown":                           "ignored",
+	})
+	if err != nil {
+		t.Fatalf("WithOverrides() error = %v", err)
+	}
+
+	want := base
+	want.Stats.Speed = 2
+	want.MovementState = "chase"
+	want.MovementOptions.WaitBeforeTurn = 30
+	want.Skills = []string{"jump", "dash"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WithOverrides() = %+v, want %+v", got, want)
+	}
+	if base.Stats.Speed != 3 {
+		t.Errorf("WithOverrides() modified the original prefab")
+	}
+}
@@ -0,0 +1,59 @@
+package prefabs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type Registry struct {
+	prefabs map[string]Prefab
+}
+
+func NewRegistry() *Registry {
+	return &Registry{prefabs: make(map[string]Prefab)}
+}
+
+// LoadRegistry reads every .json file in dir. A prefab without a name is
+// registered with its file name (e.g. "wolf.json" -> "wolf").
+func LoadRegistry(dir string) (*Registry, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := NewRegistry()
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var p Prefab
+		if err := json.Unmarshal(data, &p); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if p.Name == "" {
+			p.Name = strings.TrimSuffix(entry.Name(), ".json")
+		}
+		r.Add(p)
+	}
+
+	return r, nil
+}
+
+func (r *Registry) Add(p Prefab) {
+	r.prefabs[p.Name] = p
+}
+
+func (r *Registry) Get(name string) (Prefab, bool) {
+	p, ok := r.prefabs[name]
+	return p, ok
+}
@@ -0,0 +1,51 @@
+package prefabs
+
+import (
+	"fmt"
+
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+)
+
+// SpawnFunc builds an entity of a given kind from a prefab.
+type SpawnFunc func(p Prefab, x, y int, id string) (body.Collidable, error)
+
+// Spawner instantiates prefabs by name, dispatching on the prefab kind.
+type Spawner struct {
+	registry *Registry
+	kinds    map[string]SpawnFunc
+}
+
+func NewSpawner(registry *Registry) *Spawner {
+	return &Spawner{
+		registry: registry,
+		kinds:    make(map[string]SpawnFunc),
+	}
+}
+
+func (s *Spawner) RegisterKind(kind string, fn SpawnFunc) {
+	s.kinds[kind] = fn
+}
+
+func (s *Spawner) Registry() *Registry {
+	return s.registry
+}
+
+// Spawn creates the named prefab at x, y. Properties override prefab fields.
+func (s *Spawner) Spawn(name string, x, y int, id string, properties map[string]string) (body.Collidable, error) {
+	p, ok := s.registry.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown prefab: %s", name)
+	}
+
+	p, err := p.WithOverrides(properties)
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := s.kinds[p.Kind]
+	if !ok {
+		return nil, fmt.Errorf("prefab %s: unknown kind %q", name, p.Kind)
+	}
+
+	return fn(p, x, y, id)
+}
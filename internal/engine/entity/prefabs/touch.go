@@ -0,0 +1,18 @@
+package prefabs
+
+import "github.com/leandroatallah/firefly/internal/engine/contracts/body"
+
+// TouchBehaviour is called when other touches the entity built from a prefab.
+type TouchBehaviour func(self body.Collidable, other body.Collidable)
+
+var touchBehaviours = make(map[string]TouchBehaviour)
+
+// RegisterTouchBehaviour makes a behaviour available to the "touch" prefab field.
+func RegisterTouchBehaviour(name string, behaviour TouchBehaviour) {
+	touchBehaviours[name] = behaviour
+}
+
+func GetTouchBehaviour(name string) (TouchBehaviour, bool) {
+	b, ok := touchBehaviours[name]
+	return b, ok
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	physicsmovement "github.com/leandroatallah/firefly/internal/engine/physics/movement"
+	"github.com/leandroatallah/firefly/internal/engine/physics/skill"
+)
+
+// DefaultSystems returns the built-in systems in the same order that
+// actors.Character updates itself: scripts, skills, AI, movement and transform.
+func DefaultSystems() []System {
+	return []System{
+		SystemFunc(ScriptSystem),
+		SystemFunc(SkillSystem),
+		SystemFunc(AISystem),
+		SystemFunc(MovementSystem),
+		SystemFunc(TransformSystem),
+	}
+}
+
+func ScriptSystem(w *World) error {
+	return Each(w, func(id ID, s *Script) error {
+		if s.Update == nil {
+			return nil
+		}
+		return s.Update(w.Space())
+	})
+}
+
+func SkillSystem(w *World) error {
+	return Each2(w, func(id ID, s *Skills, b *Body) error {
+		m, ok := Get[Movement](w, id)
+		if !ok {
+			return nil
+		}
+		model, ok := m.Model.(*physicsmovement.PlatformMovementModel)
+		if !ok {
+			return nil
+		}
+		for _, sk := range s.List {
+			if active, ok := sk.(skill.ActiveSkill); ok {
+				active.HandleInput(b.Body, model, w.Space())
+			}
+			sk.Update(b.Body, model)
+		}
+		return nil
+	})
+}
+
+func AISystem(w *World) error {
+	return Each(w, func(id ID, ai *AI) error {
+		if ai.State != nil {
+			ai.State.Move(w.Space())
+		}
+		return nil
+	})
+}
+
+func MovementSystem(w *World) error {
+	return Each2(w, func(id ID, m *Movement, b *Body) error {
+		if m.Model == nil {
+			return nil
+		}
+		return m.Model.Update(b.Body, w.Space())
+	})
+}
+
+func TransformSystem(w *World) error {
+	return Each2(w, func(id ID, t *Transform, b *Body) error {
+		t.X, t.Y = b.Body.GetPositionMin()
+		return nil
+	})
+}
+
+// DrawSprites calls draw for every visible sprite, after updating its image options.
+func DrawSprites(w *World, draw func(id ID, d body.Drawable)) {
+	_ = Each(w, func(id ID, s *Sprite) error {
+		if s.Hidden || s.Drawable == nil {
+			return nil
+		}
+		s.Drawable.UpdateImageOptions()
+		draw(id, s.Drawable)
+		return nil
+	})
+}
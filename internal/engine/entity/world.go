@@ -0,0 +1,240 @@
+package entity
+
+import (
+	"reflect"
+
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+)
+
+// ID identifies an entity inside a World. Zero is never a valid ID.
+type ID uint32
+
+// System runs once per tick over the entities that have the components it needs.
+type System interface {
+	Update(w *World) error
+}
+
+// SystemFunc adapts a function to the System interface.
+type SystemFunc func(w *World) error
+
+func (f SystemFunc) Update(w *World) error {
+	return f(w)
+}
+
+type componentStore interface {
+	remove(id ID)
+}
+
+// store keeps the components of one type packed in insertion order, so that
+// systems iterate entities in a stable order.
+type store[T any] struct {
+	index map[ID]int
+	ids   []ID
+	data  []T
+}
+
+func newStore[T any]() *store[T] {
+	return &store[T]{index: make(map[ID]int)}
+}
+
+func (s *store[T]) set(id ID, c T) *T {
+	if i, ok := s.index[id]; ok {
+		s.data[i] = c
+		return &s.data[i]
+	}
+	s.index[id] = len(s.data)
+	s.ids = append(s.ids, id)
+	s.data = append(s.data, c)
+	return &s.data[len(s.data)-1]
+}
+
+func (s *store[T]) get(id ID) (*T, bool) {
+	i, ok := s.index[id]
+	if !ok {
+		return nil, false
+	}
+	return &s.data[i], true
+}
+
+func (s *store[T]) remove(id ID) {
+	i, ok := s.index[id]
+	if !ok {
+		return
+	}
+	delete(s.index, id)
+	s.ids = append(s.ids[:i], s.ids[i+1:]...)
+	s.data = append(s.data[:i], s.data[i+1:]...)
+	for j := i; j < len(s.ids); j++ {
+		s.index[s.ids[j]] = j
+	}
+}
+
+// World holds the entities, their components and the systems that update them.
+type World struct {
+	nextID  ID
+	alive   map[ID]struct{}
+	stores  map[reflect.Type]componentStore
+	systems []System
+	space   body.BodiesSpace
+
+	// Bodies mirrored from the space by SyncSpace.
+	bodies  map[body.Collidable]ID
+	ignored map[body.Collidable]struct{}
+}
+
+func NewWorld(space body.BodiesSpace) *World {
+	return &World{
+		alive:   make(map[ID]struct{}),
+		stores:  make(map[reflect.Type]componentStore),
+		space:   space,
+		bodies:  make(map[body.Collidable]ID),
+		ignored: make(map[body.Collidable]struct{}),
+	}
+}
+
+func (w *World) Space() body.BodiesSpace {
+	return w.space
+}
+
+func (w *World) NewEntity() ID {
+	w.nextID++
+	w.alive[w.nextID] = struct{}{}
+	return w.nextID
+}
+
+func (w *World) Alive(id ID) bool {
+	_, ok := w.alive[id]
+	return ok
+}
+
+// Destroy removes the entity and all of its components.
+func (w *World) Destroy(id ID) {
+	if !w.Alive(id) {
+		return
+	}
+	delete(w.alive, id)
+	for _, s := range w.stores {
+		s.remove(id)
+	}
+	for b, bid := range w.bodies {
+		if bid == id {
+			delete(w.bodies, b)
+		}
+	}
+}
+
+func (w *World) Len() int {
+	return len(w.alive)
+}
+
+func (w *World) AddSystem(systems ...System) {
+	w.systems = append(w.systems, systems...)
+}
+
+// Update runs the systems in the order they were added.
+func (w *World) Update() error {
+	for _, s := range w.systems {
+		if err := s.Update(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SyncSpace mirrors the space bodies into the world. Bodies added since the
+// last call are passed to adapt, which may create an entity for them, and the
+// entities of bodies that left the space are destroyed.
+func (w *World) SyncSpace(adapt func(w *World, b body.Collidable) (ID, bool)) {
+	if w.space == nil {
+		return
+	}
+
+	seen := make(map[body.Collidable]struct{})
+	for _, b := range w.space.Bodies() {
+		seen[b] = struct{}{}
+		if _, ok := w.bodies[b]; ok {
+			continue
+		}
+		if _, ok := w.ignored[b]; ok {
+			continue
+		}
+		if id, ok := adapt(w, b); ok {
+			w.bodies[b] = id
+		} else {
+			w.ignored[b] = struct{}{}
+		}
+	}
+
+	for b, id := range w.bodies {
+		if _, ok := seen[b]; !ok {
+			w.Destroy(id)
+		}
+	}
+	for b := range w.ignored {
+		if _, ok := seen[b]; !ok {
+			delete(w.ignored, b)
+		}
+	}
+}
+
+// EntityOf returns the entity created for a body by SyncSpace.
+func (w *World) EntityOf(b body.Collidable) (ID, bool) {
+	id, ok := w.bodies[b]
+	return id, ok
+}
+
+func storeOf[T any](w *World) *store[T] {
+	key := reflect.TypeFor[T]()
+	s, ok := w.stores[key]
+	if !ok {
+		s = newStore[T]()
+		w.stores[key] = s
+	}
+	return s.(*store[T])
+}
+
+// Add attaches a component to an entity, replacing any component of the same type.
+// The returned pointer is only valid until the next Add or Remove of that type.
+func Add[T any](w *World, id ID, c T) *T {
+	if !w.Alive(id) {
+		return nil
+	}
+	return storeOf[T](w).set(id, c)
+}
+
+func Get[T any](w *World, id ID) (*T, bool) {
+	return storeOf[T](w).get(id)
+}
+
+func Has[T any](w *World, id ID) bool {
+	_, ok := storeOf[T](w).index[id]
+	return ok
+}
+
+func Remove[T any](w *World, id ID) {
+	storeOf[T](w).remove(id)
+}
+
+// Each calls fn for every entity that has a component of type T.
+// Components must not be added or removed from fn.
+func Each[T any](w *World, fn func(id ID, c *T) error) error {
+	s := storeOf[T](w)
+	for i := 0; i < len(s.ids); i++ {
+		if err := fn(s.ids[i], &s.data[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Each2 calls fn for every entity that has both A and B components.
+func Each2[A, B any](w *World, fn func(id ID, a *A, b *B) error) error {
+	sb := storeOf[B](w)
+	return Each(w, func(id ID, a *A) error {
+		b, ok := sb.get(id)
+		if !ok {
+			return nil
+		}
+		return fn(id, a, b)
+	})
+}
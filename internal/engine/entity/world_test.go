@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
+	"github.com/leandroatallah/firefly/internal/engine/physics/space"
+)
+
+type tag struct {
+	Name string
+}
+
+func TestWorld_Components(t *testing.T) {
+	w := NewWorld(nil)
+	a, b, c := w.NewEntity(), w.NewEntity(), w.NewEntity()
+
+	Add(w, a, tag{"a"})
+	Add(w, b, tag{"b"})
+	Add(w, c, tag{"c"})
+	Add(w, b, Transform{X: 1, Y: 2})
+
+	if got, ok := Get[Transform](w, b); !ok || got.X != 1 || got.Y != 2 {
+		t.Errorf("Get[Transform](b) = %v, %v", got, ok)
+	}
+	if Has[Transform](w, a) {
+		t.Errorf("Has[Transform](a) = true, want false")
+	}
+
+	// Removing keeps the iteration order of the remaining entities.
+	Remove[tag](w, a)
+	var names []string
+	Each(w, func(id ID, c *tag) error {
+		names = append(names, c.Name)
+		return nil
+	})
+	if len(names) != 2 || names[0] != "b" || names[1] != "c" {
+		t.Errorf("Each[tag] = %v, want [b c]", names)
+	}
+
+	count := 0
+	Each2(w, func(id ID, c *tag, tr *Transform) error {
+		count++
+		return nil
+	})
+	if count != 1 {
+		t.Errorf("Each2[tag, Transform] visited %d entities, want 1", count)
+	}
+
+	w.Destroy(b)
+	if w.Alive(b) || Has[tag](w, b) || Has[Transform](w, b) {
+		t.Errorf("Destroy(b) kept the entity or its components")
+	}
+	if Add(w, b, tag{"b"}) != nil {
+		t.Errorf("Add on a destroyed entity should return nil")
+	}
+}
+
+func TestWorld_SyncSpace(t *testing.T) {
+	sp := space.NewSpace()
+	o1 := bodyphysics.NewObstacleRect(bodyphysics.NewRect(0, 0, 8, 8))
+	o1.SetID("O1")
+	o2 := bodyphysics.NewObstacleRect(bodyphysics.NewRect(8, 0, 8, 8))
+	o2.SetID("O2")
+	sp.AddBody(o1)
+	sp.AddBody(o2)
+
+	w := NewWorld(sp)
+	adapt := func(w *World, b body.Collidable) (ID, bool) {
+		if b.ID() == "O2" {
+			return 0, false
+		}
+		id := w.NewEntity()
+		Add(w, id, Ref{Value: b})
+		return id, true
+	}
+
+	w.SyncSpace(adapt)
+	id, ok := w.EntityOf(o1)
+	if !ok || w.Len() != 1 {
+		t.Fatalf("SyncSpace() created %d entities, want 1", w.Len())
+	}
+
+	sp.RemoveBody(o1)
+	w.SyncSpace(adapt)
+	if w.Alive(id) {
+		t.Errorf("entity of a removed body is still alive")
+	}
+}
@@ -10,26 +10,55 @@ type Listener func(e Event)
 
 // Manager handles event subscription and dispatching.
 type Manager struct {
-	listeners map[string][]Listener
+	listeners map[string][]subscriber
+	nextID    int
+}
+
+type subscriber struct {
+	id       int
+	listener Listener
+}
+
+// Subscription identifies a listener to unsubscribe.
+type Subscription struct {
+	eventType string
+	id        int
 }
 
 // NewManager creates a new event manager.
 func NewManager() *Manager {
 	return &Manager{
-		listeners: make(map[string][]Listener),
+		listeners: make(map[string][]subscriber),
 	}
 }
 
 // Subscribe adds a listener for a given event type.
-func (m *Manager) Subscribe(eventType string, listener Listener) {
-	m.listeners[eventType] = append(m.listeners[eventType], listener)
+func (m *Manager) Subscribe(eventType string, listener Listener) Subscription {
+	m.nextID++
+	m.listeners[eventType] = append(m.listeners[eventType], subscriber{id: m.nextID, listener: listener})
+	return Subscription{eventType: eventType, id: m.nextID}
+}
+
+// Unsubscribe removes a listener, e.g. when the scene that added it is
+// finished. It is safe to call from a listener.
+func (m *Manager) Unsubscribe(sub Subscription) {
+	subs := m.listeners[sub.eventType]
+	for i, s := range subs {
+		if s.id == sub.id {
+			// Copy, as Publish may be ranging over the old slice
+			kept := make([]subscriber, 0, len(subs)-1)
+			kept = append(kept, subs[:i]...)
+			m.listeners[sub.eventType] = append(kept, subs[i+1:]...)
+			return
+		}
+	}
 }
 
 // Publish dispatches an event to all registered listeners.
 func (m *Manager) Publish(e Event) {
 	if listeners, ok := m.listeners[e.Type()]; ok {
-		for _, listener := range listeners {
-			listener(e)
+		for _, s := range listeners {
+			s.listener(e)
 		}
 	}
 }
@@ -0,0 +1,22 @@
+package event
+
+import "testing"
+
+func TestUnsubscribe(t *testing.T) {
+	m := NewManager()
+	var calls []string
+	first := m.Subscribe("hit", func(Event) {
+		calls = append(calls, "first")
+	})
+	m.Subscribe("hit", func(Event) {
+		calls = append(calls, "second")
+		// Unsubscribing while publishing doesn't skip listeners
+		m.Unsubscribe(first)
+	})
+
+	m.Publish(GenericEvent{EventType: "hit"})
+	m.Publish(GenericEvent{EventType: "hit"})
+	if len(calls) != 3 || calls[2] != "second" {
+		t.Errorf("calls = %v, want first, second, second", calls)
+	}
+}
@@ -0,0 +1,159 @@
+package input
+
+import (
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// Action is a game input that can be bound to keys and gamepad buttons.
+type Action string
+
+const (
+	ActionLeft      Action = "left"
+	ActionRight     Action = "right"
+	ActionUp        Action = "up"
+	ActionDown      Action = "down"
+	ActionJump      Action = "jump"
+	ActionDash      Action = "dash"
+	ActionAttack    Action = "attack"
+	ActionConfirm   Action = "confirm"
+	ActionCancel    Action = "cancel"
+	ActionPause     Action = "pause"
+	ActionInventory Action = "inventory"
+)
+
+// Actions lists every action in the order shown in the key bindings screen.
+var Actions = []Action{
+	ActionLeft, ActionRight, ActionUp, ActionDown,
+	ActionJump, ActionDash, ActionAttack,
+	ActionConfirm, ActionCancel,
+	ActionPause, ActionInventory,
+}
+
+var defaultKeys = map[Action][]ebiten.Key{
+	ActionLeft:      {ebiten.KeyA, ebiten.KeyLeft},
+	ActionRight:     {ebiten.KeyD, ebiten.KeyRight},
+	ActionUp:        {ebiten.KeyW, ebiten.KeyUp},
+	ActionDown:      {ebiten.KeyS, ebiten.KeyDown},
+	ActionJump:      {ebiten.KeySpace},
+	ActionDash:      {ebiten.KeyShift},
+	ActionAttack:    {ebiten.KeyX},
+	ActionConfirm:   {ebiten.KeyEnter, ebiten.KeySpace},
+	ActionCancel:    {ebiten.KeyEscape, ebiten.KeyBackspace},
+	ActionPause:     {ebiten.KeyP, ebiten.KeyEscape},
+	ActionInventory: {ebiten.KeyTab, ebiten.KeyI},
+}
+
+var gamepadButtons = map[Action][]ebiten.StandardGamepadButton{
+	ActionLeft:      {ebiten.StandardGamepadButtonLeftLeft},
+	ActionRight:     {ebiten.StandardGamepadButtonLeftRight},
+	ActionUp:        {ebiten.StandardGamepadButtonLeftTop},
+	ActionDown:      {ebiten.StandardGamepadButtonLeftBottom},
+	ActionJump:      {ebiten.StandardGamepadButtonRightBottom},
+	ActionDash:      {ebiten.StandardGamepadButtonFrontBottomRight},
+	ActionAttack:    {ebiten.StandardGamepadButtonRightLeft},
+	ActionConfirm:   {ebiten.StandardGamepadButtonRightBottom},
+	ActionCancel:    {ebiten.StandardGamepadButtonRightRight},
+	ActionPause:     {ebiten.StandardGamepadButtonCenterRight},
+	ActionInventory: {ebiten.StandardGamepadButtonCenterLeft},
+}
+
+var keys = cloneKeys(defaultKeys)
+
+var (
+	isKeyJustPressed           = inpututil.IsKeyJustPressed
+	isGamepadButtonPressed     = isAnyGamepadButtonPressed
+	isGamepadButtonJustPressed = isAnyGamepadButtonJustPressed
+)
+
+func cloneKeys(m map[Action][]ebiten.Key) map[Action][]ebiten.Key {
+	res := make(map[Action][]ebiten.Key, len(m))
+	for a, k := range m {
+		res[a] = slices.Clone(k)
+	}
+	return res
+}
+
+// Keys returns the keys bound to an action.
+func Keys(a Action) []ebiten.Key {
+	return keys[a]
+}
+
+// PrimaryKey returns the first key bound to an action, shown in prompts.
+func PrimaryKey(a Action) ebiten.Key {
+	if k := keys[a]; len(k) > 0 {
+		return k[0]
+	}
+	return -1
+}
+
+// Bind replaces the keys of an action.
+func Bind(a Action, k ...ebiten.Key) {
+	keys[a] = slices.Clone(k)
+}
+
+// ResetBindings restores the default keys of every action.
+func ResetBindings() {
+	keys = cloneKeys(defaultKeys)
+}
+
+// captured is set while an overlay scene captures the input, so the scenes
+// below it read every action as released.
+var captured bool
+
+// SetCaptured sets whether actions read as released.
+func SetCaptured(c bool) {
+	captured = c
+}
+
+func IsActionPressed(a Action) bool {
+	if captured {
+		return false
+	}
+	return IsSomeKeyPressed(keys[a]...) || isGamepadButtonPressed(gamepadButtons[a])
+}
+
+func IsActionJustPressed(a Action) bool {
+	if captured {
+		return false
+	}
+	for _, k := range keys[a] {
+		if isKeyJustPressed(k) {
+			return true
+		}
+	}
+	return isGamepadButtonJustPressed(gamepadButtons[a])
+}
+
+// JustPressedKey returns a key pressed this frame, used to rebind an action.
+func JustPressedKey() (ebiten.Key, bool) {
+	pressed := inpututil.AppendJustPressedKeys(nil)
+	if len(pressed) == 0 {
+		return 0, false
+	}
+	return pressed[0], true
+}
+
+func isAnyGamepadButtonPressed(buttons []ebiten.StandardGamepadButton) bool {
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		for _, b := range buttons {
+			if ebiten.IsStandardGamepadButtonPressed(id, b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isAnyGamepadButtonJustPressed(buttons []ebiten.StandardGamepadButton) bool {
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		for _, b := range buttons {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, b) {
+				return true
+			}
+		}
+	}
+	return false
+}
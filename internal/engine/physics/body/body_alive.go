@@ -6,9 +6,10 @@ type AliveBody struct {
 
 	Ownership
 
-	health       int
-	maxHealth    int
-	invulnerable bool
+	health               int
+	maxHealth            int
+	invulnerable         bool
+	invulnerabilityTimer int
 }
 
 func NewAliveBody(body *Body) *AliveBody {
@@ -48,4 +49,38 @@ func (b *AliveBody) Invulnerable() bool {
 
 func (b *AliveBody) SetInvulnerability(value bool) {
 	b.invulnerable = value
+	if !value {
+		b.invulnerabilityTimer = 0
+	}
+}
+
+// SetInvulnerableFor makes the body invulnerable for the given number of frames.
+func (b *AliveBody) SetInvulnerableFor(frames int) {
+	b.invulnerable = frames > 0
+	b.invulnerabilityTimer = frames
+}
+
+func (b *AliveBody) InvulnerabilityTimer() int {
+	return b.invulnerabilityTimer
+}
+
+// UpdateInvulnerability counts down the invulnerability window. It must be called once per frame.
+func (b *AliveBody) UpdateInvulnerability() {
+	if b.invulnerabilityTimer > 0 {
+		b.invulnerabilityTimer--
+		if b.invulnerabilityTimer == 0 {
+			b.invulnerable = false
+		}
+	}
+}
+
+// Damage removes health and opens an invulnerability window (i-frames).
+// It returns false if the body was invulnerable and the damage was ignored.
+func (b *AliveBody) Damage(damage, invulnerableFrames int) bool {
+	if b.invulnerable {
+		return false
+	}
+	b.LoseHealth(damage)
+	b.SetInvulnerableFor(invulnerableFrames)
+	return true
 }
@@ -0,0 +1,65 @@
+package body
+
+import (
+	"image"
+
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+)
+
+// Blocks reports whether other blocks mover. One-way surfaces only block
+// bodies that are not going up and whose feet are at most 1 pixel into them.
+func Blocks(mover, other body.Collidable) bool {
+	if !other.IsObstructive() {
+		return false
+	}
+	s, ok := other.(body.Surface)
+	if !ok || !s.OneWay() {
+		return true
+	}
+
+	if m, ok := mover.(interface{ Velocity() (int, int) }); ok {
+		if _, vy16 := m.Velocity(); vy16 < 0 {
+			return false
+		}
+	}
+	return bottom(mover) <= top(other)+1
+}
+
+func bottom(b body.Collidable) int {
+	rects := b.CollisionPosition()
+	if len(rects) == 0 {
+		return b.Position().Max.Y
+	}
+	y := rects[0].Max.Y
+	for _, r := range rects[1:] {
+		y = max(y, r.Max.Y)
+	}
+	return y
+}
+
+func top(b body.Collidable) int {
+	rects := b.CollisionPosition()
+	if len(rects) == 0 {
+		return b.Position().Min.Y
+	}
+	y := rects[0].Min.Y
+	for _, r := range rects[1:] {
+		y = min(y, r.Min.Y)
+	}
+	return y
+}
+
+// OverlapsShapes reports whether rect overlaps the collision shapes of b, or
+// its position when it has none.
+func OverlapsShapes(b body.Collidable, rect image.Rectangle) bool {
+	rects := b.CollisionPosition()
+	if len(rects) == 0 {
+		return b.Position().Overlaps(rect)
+	}
+	for _, r := range rects {
+		if r.Overlaps(rect) {
+			return true
+		}
+	}
+	return false
+}
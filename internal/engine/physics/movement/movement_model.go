@@ -2,6 +2,7 @@ package movement
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 )
@@ -36,3 +37,15 @@ func NewMovementModel(model MovementModelEnum, playerMovementBlocker PlayerMovem
 		return nil, fmt.Errorf("unknown movement model type")
 	}
 }
+
+// ParseMovementModel returns the model enum from its name, e.g. "platform" or "top_down".
+func ParseMovementModel(name string) (MovementModelEnum, error) {
+	switch strings.ToLower(strings.ReplaceAll(name, "_", "")) {
+	case "topdown":
+		return TopDown, nil
+	case "platform":
+		return Platform, nil
+	default:
+		return 0, fmt.Errorf("unknown movement model: %s", name)
+	}
+}
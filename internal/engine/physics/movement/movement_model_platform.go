@@ -5,12 +5,14 @@ import (
 
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
 	"github.com/leandroatallah/firefly/internal/engine/utils/fp16"
 )
 
 type PlatformMovementModel struct {
 	playerMovementBlocker PlayerMovementBlocker
 	onGround              bool
+	ground                body.Collidable // What the body stands on, if on ground
 	maxFallSpeed          int
 	isScripted            bool
 	dashActive            bool
@@ -58,9 +60,12 @@ func (m *PlatformMovementModel) UpdateHorizontalVelocity(body body.MovableCollid
 			baseFriction := int(float64(fp16.To16(1)/2) * horizontalInertia)
 			friction := baseFriction
 
-			// Apply air friction multiplier if the player is in the air
+			// Apply air friction multiplier if the player is in the air, and
+			// the friction of the surface below if on the ground
 			if !m.onGround {
 				friction = int(float64(baseFriction) * cfg.Physics.AirFrictionMultiplier)
+			} else {
+				friction = int(float64(baseFriction) * m.groundFriction())
 			}
 
 			if vx16 > friction {
@@ -120,28 +125,33 @@ func (m *PlatformMovementModel) Update(body body.MovableCollidable, space body.B
 	}
 
 	// Apply horizontal movement to the body and check for collisions.
-	_, _, isBlockingX := body.ApplyValidPosition(vx16, true, space)
+	isBlockingX := m.moveX(body, space, vx16)
 	if isBlockingX {
 		vx16 = 0
 	}
+	if m.onGround && vy16 >= 0 {
+		m.snapDown(body, space)
+	}
 
 	// Apply vertical movement to the body and check for collisions.
 	_, _, isBlockingY := body.ApplyValidPosition(vy16, false, space)
 	vx16, vy16 = body.Velocity()
 
+	ground := m.findGround(body, space)
 	isGrounded := false
 	if isBlockingY {
 		if vy16 > 0 {
 			isGrounded = true
 		}
 	} else {
-		if vy16 >= 0 && m.CheckGround(body, space) {
+		if vy16 >= 0 && ground != nil {
 			isGrounded = true
 		}
 	}
 
 	if isGrounded {
 		m.onGround = true
+		m.ground = ground
 		// Set a small downward velocity to "stick" to the ground, ensuring it's less than the falling threshold.
 		if vy16 >= 0 {
 			vy16 = cfg.Physics.DownwardGravity - 1
@@ -149,8 +159,11 @@ func (m *PlatformMovementModel) Update(body body.MovableCollidable, space body.B
 		}
 	} else {
 		m.onGround = false
+		m.ground = nil
 	}
 
+	m.hurtByHazards(body, space)
+
 	if clampToPlayArea(body, space) {
 		vy16 = cfg.Physics.DownwardGravity - 1
 		body.SetVelocity(vx16, vy16)
@@ -188,6 +201,12 @@ func (m *PlatformMovementModel) SetDashActive(active bool, vx int) {
 }
 
 func (m *PlatformMovementModel) CheckGround(b body.MovableCollidable, space body.BodiesSpace) bool {
+	return m.findGround(b, space) != nil
+}
+
+// findGround returns the obstacle right below the body, or nil if it is not
+// standing on anything.
+func (m *PlatformMovementModel) findGround(b body.MovableCollidable, space body.BodiesSpace) body.Collidable {
 	collisionRects := b.CollisionPosition()
 	// If no specific collision shapes are defined, fall back to the main body shape.
 	if len(collisionRects) == 0 {
@@ -213,10 +232,145 @@ func (m *PlatformMovementModel) CheckGround(b body.MovableCollidable, space body
 			if c.ID() == b.ID() {
 				continue
 			}
-			if c.IsObstructive() {
+			if bodyphysics.Blocks(b, c) && bodyphysics.OverlapsShapes(c, checkRect) {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// groundFriction returns the friction multiplier of the ground.
+func (m *PlatformMovementModel) groundFriction() float64 {
+	if s, ok := m.ground.(body.Surface); ok {
+		return s.Friction()
+	}
+	return 1
+}
+
+// moveX moves the body horizontally, walking it up steps of at most
+// MaxStepHeight pixels when on the ground. It reports whether the body was
+// blocked.
+func (m *PlatformMovementModel) moveX(b body.MovableCollidable, space body.BodiesSpace, vx16 int) bool {
+	startX, _ := b.GetPositionMin()
+	_, _, isBlocking := b.ApplyValidPosition(vx16, true, space)
+	if !isBlocking || !m.onGround {
+		return isBlocking
+	}
+
+	step := 1
+	if vx16 < 0 {
+		step = -1
+	}
+	distance := fp16.From16(vx16)
+	if distance == 0 {
+		distance = step
+	}
+	for m.stepUp(b, space, step) {
+		x, _ := b.GetPositionMin()
+		left := distance - (x - startX)
+		if left*step <= 0 {
+			return false
+		}
+		if _, _, isBlocking = b.ApplyValidPosition(fp16.To16(left), true, space); !isBlocking {
+			return false
+		}
+	}
+	return true
+}
+
+// stepUp moves the body one pixel towards dir and up the lowest step that
+// frees it, if any is at most MaxStepHeight pixels high.
+func (m *PlatformMovementModel) stepUp(b body.MovableCollidable, space body.BodiesSpace, dir int) bool {
+	x16, y16 := b.GetPosition16()
+	for h := 1; h <= config.Get().Physics.MaxStepHeight; h++ {
+		// Stop at ceilings
+		b.SetPosition16(x16, y16-fp16.To16(h))
+		if m.blocked(b, space) {
+			break
+		}
+		b.SetPosition16(x16+fp16.To16(dir), y16-fp16.To16(h))
+		if !m.blocked(b, space) {
+			return true
+		}
+	}
+	b.SetPosition16(x16, y16)
+	return false
+}
+
+// snapDown keeps a body that was on the ground on it when it walks down a
+// step of at most MaxStepHeight pixels, like on slopes, instead of falling.
+func (m *PlatformMovementModel) snapDown(b body.MovableCollidable, space body.BodiesSpace) {
+	if m.findGround(b, space) != nil {
+		return
+	}
+	x16, y16 := b.GetPosition16()
+	for h := 1; h <= config.Get().Physics.MaxStepHeight; h++ {
+		b.SetPosition16(x16, y16+fp16.To16(h))
+		if m.blocked(b, space) {
+			break
+		}
+		if m.findGround(b, space) != nil {
+			return
+		}
+	}
+	b.SetPosition16(x16, y16)
+}
+
+// blocked reports whether the body, where it is, overlaps anything blocking
+// it. Unlike resolving the collisions, probing doesn't touch the bodies.
+func (m *PlatformMovementModel) blocked(b body.MovableCollidable, space body.BodiesSpace) bool {
+	collisionRects := b.CollisionPosition()
+	if len(collisionRects) == 0 {
+		collisionRects = []image.Rectangle{b.Position()}
+	}
+	for _, pos := range collisionRects {
+		for _, c := range space.Query(pos) {
+			if c.ID() == b.ID() {
+				continue
+			}
+			if bodyphysics.Blocks(b, c) && bodyphysics.OverlapsShapes(c, pos) {
 				return true
 			}
 		}
 	}
 	return false
 }
+
+// hurtByHazards damages the body with the most harmful hazard surface it
+// touches, opening invulnerability frames so it isn't damaged every frame.
+// The owner is hurt once its health runs out, e.g. to die.
+func (m *PlatformMovementModel) hurtByHazards(b body.MovableCollidable, space body.BodiesSpace) {
+	owner := b.LastOwner()
+	hurter, canHurt := owner.(interface{ Hurt(int) })
+	alive, canDamage := owner.(interface {
+		Damage(damage, invulnerableFrames int) bool
+		Health() int
+	})
+	if !canHurt && !canDamage {
+		return
+	}
+
+	damage := 0
+	for _, pos := range b.CollisionPosition() {
+		area := pos.Inset(-1)
+		for _, c := range space.Query(area) {
+			s, ok := c.(body.Surface)
+			if !ok || c.ID() == b.ID() || s.Hazard() <= damage {
+				continue
+			}
+			if bodyphysics.OverlapsShapes(c, area) {
+				damage = s.Hazard()
+			}
+		}
+	}
+	if damage == 0 {
+		return
+	}
+	if canDamage && (!alive.Damage(damage, body.DefaultInvulnerableFrames) || alive.Health() > 0) {
+		return
+	}
+	if canHurt {
+		hurter.Hurt(damage)
+	}
+}
@@ -1,6 +1,8 @@
 package skill
 
 import (
+	"fmt"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 	physicsmovement "github.com/leandroatallah/firefly/internal/engine/physics/movement"
@@ -44,3 +46,17 @@ func (s *SkillBase) Update(body body.MovableCollidable, model *physicsmovement.P
 func (s *SkillBase) IsActive() bool {
 	return s.state == StateActive
 }
+
+// NewSkill creates a skill from its name, as used by prefab files.
+func NewSkill(name string) (Skill, error) {
+	switch name {
+	case "jump":
+		return NewJumpSkill(), nil
+	case "horizontal_movement":
+		return NewHorizontalMovementSkill(), nil
+	case "dash":
+		return NewDashSkill(), nil
+	default:
+		return nil, fmt.Errorf("unknown skill: %s", name)
+	}
+}
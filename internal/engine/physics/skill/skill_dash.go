@@ -4,9 +4,9 @@ import (
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/animation"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/input"
 	physicsmovement "github.com/leandroatallah/firefly/internal/engine/physics/movement"
 	"github.com/leandroatallah/firefly/internal/engine/utils/fp16"
 	"github.com/leandroatallah/firefly/internal/engine/utils/timing"
@@ -16,9 +16,9 @@ import (
 type DashSkill struct {
 	SkillBase
 
-	canAirDash    bool
-	airDashUsed   bool
-	activationKey ebiten.Key
+	canAirDash  bool
+	airDashUsed bool
+	action      input.Action
 }
 
 // NewDashSkill creates a new DashSkill with default values.
@@ -30,20 +30,20 @@ func NewDashSkill() *DashSkill {
 			cooldown: timing.FromDuration(750 * time.Millisecond), // 45 frames
 			speed:    fp16.To16(10),
 		},
-		canAirDash:    true,
-		airDashUsed:   false,
-		activationKey: ebiten.KeyShift,
+		canAirDash:  true,
+		airDashUsed: false,
+		action:      input.ActionDash,
 	}
 }
 
 // ActivationKey returns the activation key for the dash skill.
 func (d *DashSkill) ActivationKey() ebiten.Key {
-	return d.activationKey
+	return input.PrimaryKey(d.action)
 }
 
 // HandleInput checks for the dash activation key.
 func (d *DashSkill) HandleInput(body body.MovableCollidable, model *physicsmovement.PlatformMovementModel, space body.BodiesSpace) {
-	if inpututil.IsKeyJustPressed(d.activationKey) {
+	if input.IsActionJustPressed(d.action) {
 		d.tryActivate(body, model, space)
 	}
 }
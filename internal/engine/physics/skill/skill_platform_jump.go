@@ -2,16 +2,16 @@ package skill
 
 import (
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	"github.com/leandroatallah/firefly/internal/engine/input"
 	physicsmovement "github.com/leandroatallah/firefly/internal/engine/physics/movement"
 	spacephysics "github.com/leandroatallah/firefly/internal/engine/physics/space"
 )
 
 type JumpSkill struct {
 	SkillBase
-	activationKey ebiten.Key
+	action input.Action
 
 	coyoteTimeCounter int
 	jumpBufferCounter int
@@ -24,17 +24,17 @@ func NewJumpSkill() *JumpSkill {
 		SkillBase: SkillBase{
 			state: StateReady,
 		},
-		activationKey: ebiten.KeySpace,
+		action: input.ActionJump,
 	}
 }
 
 func (s *JumpSkill) ActivationKey() ebiten.Key {
-	return s.activationKey
+	return input.PrimaryKey(s.action)
 }
 
 // HandleInput checks for the dash activation key.
 func (s *JumpSkill) HandleInput(body body.MovableCollidable, model *physicsmovement.PlatformMovementModel, space body.BodiesSpace) {
-	if inpututil.IsKeyJustPressed(s.activationKey) {
+	if input.IsActionJustPressed(s.action) {
 		s.tryActivate(body, model, space)
 	}
 }
@@ -42,8 +42,8 @@ func (s *HorizontalMovementSkill) HandleInput(body body.MovableCollidable, _ *ph
 	cfg := config.Get()
 	vx16, vy16 := body.Velocity()
 
-	moveLeft := input.IsSomeKeyPressed(ebiten.KeyA, ebiten.KeyLeft)
-	moveRight := input.IsSomeKeyPressed(ebiten.KeyD, ebiten.KeyRight)
+	moveLeft := input.IsActionPressed(input.ActionLeft)
+	moveRight := input.IsActionPressed(input.ActionRight)
 
 	horizontalInertia := cfg.Physics.HorizontalInertia
 	if val := body.HorizontalInertia(); val >= 0 {
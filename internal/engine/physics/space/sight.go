@@ -0,0 +1,62 @@
+package space
+
+import (
+	"image"
+	"math"
+
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+)
+
+// LineOfSight reports whether no obstructive body crosses the segment from
+// x0, y0 to x1, y1, e.g. for an actor to see another. One way surfaces are
+// seen through.
+func LineOfSight(s body.BodiesSpace, x0, y0, x1, y1 float64) bool {
+	area := image.Rect(int(math.Floor(x0)), int(math.Floor(y0)), int(math.Floor(x1)), int(math.Floor(y1))).Canon()
+	area.Max = area.Max.Add(image.Pt(1, 1))
+	for _, b := range s.Query(area) {
+		if !b.IsObstructive() {
+			continue
+		}
+		if surface, ok := b.(body.Surface); ok && surface.OneWay() {
+			continue
+		}
+		for _, r := range b.CollisionPosition() {
+			if SegmentHitsRect(x0, y0, x1, y1, r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SegmentHitsRect reports whether the segment from x0, y0 to x1, y1 crosses
+// the inside of r, clipping it to r as Liang-Barsky does.
+func SegmentHitsRect(x0, y0, x1, y1 float64, r image.Rectangle) bool {
+	dx, dy := x1-x0, y1-y0
+	t0, t1 := 0.0, 1.0
+	edges := [4][2]float64{
+		{-dx, x0 - float64(r.Min.X)},
+		{dx, float64(r.Max.X) - x0},
+		{-dy, y0 - float64(r.Min.Y)},
+		{dy, float64(r.Max.Y) - y0},
+	}
+	for _, e := range edges {
+		p, q := e[0], e[1]
+		if p == 0 {
+			if q <= 0 {
+				return false
+			}
+			continue
+		}
+		t := q / p
+		if p < 0 {
+			t0 = math.Max(t0, t)
+		} else {
+			t1 = math.Min(t1, t)
+		}
+		if t0 >= t1 {
+			return false
+		}
+	}
+	return true
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/tilemaplayer"
+	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
 )
 
 // Space centralizes physics bodies and collision resolution.
@@ -152,7 +153,7 @@ func (s *Space) ResolveCollisions(body body.Collidable) (touching bool, blocking
 		other.OnTouch(body)
 		touching = true
 
-		if other.IsObstructive() {
+		if bodyphysics.Blocks(body, other) {
 			body.OnBlock(other)
 			other.OnBlock(body)
 			blocking = true
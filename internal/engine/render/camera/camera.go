@@ -3,6 +3,7 @@ package camera
 import (
 	"image"
 	"image/color"
+	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
@@ -19,16 +20,23 @@ func init() {
 }
 
 type Controller struct {
-	cam              *kamera.Camera
-	target           body.Collidable
-	followTarget     body.Body
-	DeadZoneRadius   float64
-	SmoothingFactor  float64
-	isFollowing      bool
-	centerX, centerY float64
-	screenWidth      float64
-	screenHeight     float64
-	bounds           *image.Rectangle
+	cam          *kamera.Camera
+	target       body.Collidable
+	followTarget body.Body
+	targets      []body.Body
+	isFollowing  bool
+	centerX      float64
+	centerY      float64
+	screenWidth  float64
+	screenHeight float64
+	bounds       *image.Rectangle
+
+	rig        Rig
+	zones      []Zone
+	smoothTime float64 // Default of the camera
+	// Where the camera looks, before the look ahead
+	focusX, focusY float64
+	lookAheadX     float64
 }
 
 func NewController(x, y float64) *Controller {
@@ -49,6 +57,9 @@ func NewController(x, y float64) *Controller {
 		centerY:      y,
 		screenWidth:  float64(cfg.ScreenWidth),
 		screenHeight: float64(cfg.ScreenHeight),
+		smoothTime:   cam.SmoothOptions.SmoothDampTimeX,
+		focusX:       x,
+		focusY:       y,
 	}
 }
 
@@ -74,60 +85,135 @@ func (c *Controller) SetBounds(bounds *image.Rectangle) {
 	c.bounds = bounds
 }
 
+// Bounds returns the rectangle the camera movement is restricted to, if any.
+func (c *Controller) Bounds() *image.Rectangle {
+	return c.bounds
+}
+
+// SetSize sets the size of the view, e.g. to fit a viewport of a split
+// screen. The camera keeps its center.
+func (c *Controller) SetSize(w, h float64) {
+	if w == c.screenWidth && h == c.screenHeight {
+		return
+	}
+	x, y := c.cam.Center()
+	c.screenWidth, c.screenHeight = w, h
+	c.cam.SetSize(w, h)
+	c.cam.SetCenter(x, y)
+}
+
+// Size returns the size of the view.
+func (c *Controller) Size() (float64, float64) {
+	return c.screenWidth, c.screenHeight
+}
+
 func (c *Controller) SetCenter(x, y float64) {
 	c.centerX = x
 	c.centerY = y
+	c.snapTo(x, y)
+}
+
+// snapTo centers the camera on x, y without smoothing, dropping the look
+// ahead.
+func (c *Controller) snapTo(x, y float64) {
+	c.focusX, c.focusY = x, y
+	c.lookAheadX = 0
 	c.Kamera().SetCenter(x, y)
 }
 
 func (c *Controller) SetFollowTarget(b body.Body) {
+	c.targets = []body.Body{b}
 	c.followTarget = b
-	x, y := b.GetPositionMin()
-	c.Kamera().SetCenter(float64(x), float64(y))
+	// Snap to the center of the body, where the camera follows it
+	minX, minY, maxX, maxY := c.frame()
+	c.snapTo((minX+maxX)/2, (minY+maxY)/2)
 }
 
 func (c *Controller) Update() {
+	rig := c.rig
+	bounds := c.bounds
+
 	var targetX, targetY float64
-	if c.isFollowing && c.followTarget != nil {
-		x, y := c.followTarget.GetPositionMin()
-		// Use center of target for following
-		w, h := c.followTarget.GetShape().Width(), c.followTarget.GetShape().Height()
-		targetX = float64(x) + float64(w)/2
-		targetY = float64(y) + float64(h)/2
+	if c.isFollowing && len(c.targets) > 0 {
+		minX, minY, maxX, maxY := c.frame()
+		if z, ok := c.zoneAt((minX+maxX)/2, (minY+maxY)/2); ok {
+			rig = z.Apply(rig)
+			if z.Clamps() {
+				area := z.Area
+				bounds = &area
+			}
+		}
+		c.applySmoothing(rig)
+		c.applyZoom(rig)
+		targetX, targetY = c.follow(rig, c.cam.ZoomFactor)
 	} else {
 		targetX = c.centerX
 		targetY = c.centerY
 	}
 
-	if c.bounds != nil {
-		// Calculate viewport half-dimensions
-		halfW := c.screenWidth / 2
-		halfH := c.screenHeight / 2
+	if bounds != nil {
+		targetX, targetY = c.clamp(*bounds, targetX, targetY)
+	}
 
-		// Calculate min and max center positions
-		minX := float64(c.bounds.Min.X) + halfW
-		maxX := float64(c.bounds.Max.X) - halfW
-		minY := float64(c.bounds.Min.Y) + halfH
-		maxY := float64(c.bounds.Max.Y) - halfH
+	c.cam.LookAt(targetX, targetY)
+}
 
-		// Clamp targetX
-		if targetX < minX {
-			targetX = minX
-		}
-		if targetX > maxX {
-			targetX = maxX
-		}
+// clamp keeps the view within bounds, centering it on them when they are
+// smaller than the view.
+func (c *Controller) clamp(bounds image.Rectangle, x, y float64) (float64, float64) {
+	zoom := c.cam.ZoomFactor
+	if zoom <= 0 {
+		zoom = 1
+	}
+	// Calculate viewport half-dimensions
+	halfW := c.screenWidth / zoom / 2
+	halfH := c.screenHeight / zoom / 2
 
-		// Clamp targetY
-		if targetY < minY {
-			targetY = minY
-		}
-		if targetY > maxY {
-			targetY = maxY
-		}
+	// Calculate min and max center positions
+	minX := float64(bounds.Min.X) + halfW
+	maxX := float64(bounds.Max.X) - halfW
+	minY := float64(bounds.Min.Y) + halfH
+	maxY := float64(bounds.Max.Y) - halfH
+
+	if minX > maxX {
+		x = float64(bounds.Min.X+bounds.Max.X) / 2
+	} else {
+		x = math.Min(math.Max(x, minX), maxX)
 	}
+	if minY > maxY {
+		y = float64(bounds.Min.Y+bounds.Max.Y) / 2
+	} else {
+		y = math.Min(math.Max(y, minY), maxY)
+	}
+	return x, y
+}
 
-	c.cam.LookAt(targetX, targetY)
+// applySmoothing sets the smoothing of the camera from the rig.
+func (c *Controller) applySmoothing(rig Rig) {
+	t := rig.SmoothTime
+	if t <= 0 {
+		t = c.smoothTime
+	}
+	c.cam.SmoothOptions.SmoothDampTimeX = t
+	c.cam.SmoothOptions.SmoothDampTimeY = t
+}
+
+// zoomSpeed is the part of the way to the zoom goal covered every frame.
+const zoomSpeed = 0.1
+
+// applyZoom eases the zoom towards the one of the rig, zooming out to frame
+// several targets. Without zoom rules the zoom is left as is.
+func (c *Controller) applyZoom(rig Rig) {
+	framing := rig.MinZoom > 0 && len(c.targets) > 1
+	if rig.Zoom <= 0 && !framing {
+		return
+	}
+	zoom := rig.Zoom
+	if zoom <= 0 {
+		zoom = 1
+	}
+	goal := c.frameZoom(rig, zoom)
+	c.cam.ZoomFactor += (goal - c.cam.ZoomFactor) * zoomSpeed
 }
 
 func (c *Controller) Draw(
@@ -167,6 +253,24 @@ func (c *Controller) DrawCollisionBox(screen *ebiten.Image, b body.Collidable) {
 	}
 }
 
+// VisibleRect returns the world area shown on screen, including zoom,
+// rotation and shake.
+func (c *Controller) VisibleRect() image.Rectangle {
+	w, h := int(c.cam.Width), int(c.cam.Height)
+	r := image.Rectangle{Min: image.Pt(math.MaxInt, math.MaxInt), Max: image.Pt(math.MinInt, math.MinInt)}
+	for _, p := range [4]image.Point{{0, 0}, {w, 0}, {0, h}, {w, h}} {
+		x, y := c.cam.ScreenToWorld(p.X, p.Y)
+		if math.IsNaN(x) || math.IsNaN(y) {
+			return image.Rectangle{}
+		}
+		r.Min.X = min(r.Min.X, int(math.Floor(x)))
+		r.Min.Y = min(r.Min.Y, int(math.Floor(y)))
+		r.Max.X = max(r.Max.X, int(math.Ceil(x)))
+		r.Max.Y = max(r.Max.Y, int(math.Ceil(y)))
+	}
+	return r
+}
+
 // Useful for debugging
 func (c *Controller) Kamera() *kamera.Camera {
 	return c.cam
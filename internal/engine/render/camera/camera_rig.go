@@ -0,0 +1,228 @@
+package camera
+
+import (
+	"image"
+	"math"
+	"strconv"
+
+	"github.com/leandroatallah/firefly/internal/engine/contracts/animation"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/utils/fp16"
+)
+
+// Rig holds the rules the camera follows its targets with. The zero value
+// centers the camera on the targets, as it always did.
+type Rig struct {
+	// DeadZoneWidth and DeadZoneHeight are the size of the box, centered on
+	// the camera, the targets move in without moving the camera.
+	DeadZoneWidth  float64
+	DeadZoneHeight float64
+	// LookAhead is how far ahead of a single target, in the direction it
+	// moves or faces, the camera looks.
+	LookAhead float64
+	// LookAheadSpeed is the part of the way to the look ahead point covered
+	// every frame, in (0, 1]. 0 is the same as 1.
+	LookAheadSpeed float64
+	// VerticalOnLanding keeps the camera from moving vertically while the
+	// targets are in the air, unless they leave the middle half of the
+	// screen, like platformer cameras do.
+	VerticalOnLanding bool
+	// SmoothTime is the time, in seconds, the camera takes to catch up. 0
+	// keeps the current smoothing.
+	SmoothTime float64
+	// Zoom is the zoom factor. 0 keeps the current zoom, or 1 while framing
+	// several targets.
+	Zoom float64
+	// MinZoom is how far the camera zooms out to keep several targets in
+	// view, each with FramePadding pixels around it. 0 disables zooming out.
+	MinZoom      float64
+	FramePadding float64
+}
+
+// Zone is an area of the map that overrides the rig while the targets are in
+// it. Zones come from the "Camera" object layer of maps.
+type Zone struct {
+	Name string
+	Area image.Rectangle
+	// Properties override the rig fields of the same name in snake case,
+	// e.g. dead_zone_width or vertical_on_landing. A true "clamp" property
+	// keeps the camera in the zone.
+	Properties map[string]string
+}
+
+// Apply returns the rig with the overrides of the zone.
+func (z Zone) Apply(r Rig) Rig {
+	floats := map[string]*float64{
+		"dead_zone_width":  &r.DeadZoneWidth,
+		"dead_zone_height": &r.DeadZoneHeight,
+		"look_ahead":       &r.LookAhead,
+		"look_ahead_speed": &r.LookAheadSpeed,
+		"smooth_time":      &r.SmoothTime,
+		"zoom":             &r.Zoom,
+		"min_zoom":         &r.MinZoom,
+		"frame_padding":    &r.FramePadding,
+	}
+	for name, field := range floats {
+		if v, err := strconv.ParseFloat(z.Properties[name], 64); err == nil {
+			*field = v
+		}
+	}
+	if v, err := strconv.ParseBool(z.Properties["vertical_on_landing"]); err == nil {
+		r.VerticalOnLanding = v
+	}
+	return r
+}
+
+// Clamps reports whether the zone keeps the camera in it.
+func (z Zone) Clamps() bool {
+	v, _ := strconv.ParseBool(z.Properties["clamp"])
+	return v
+}
+
+// SetRig sets the rules the camera follows its targets with.
+func (c *Controller) SetRig(r Rig) {
+	c.rig = r
+}
+
+func (c *Controller) Rig() Rig {
+	return c.rig
+}
+
+// SetZones sets the areas that override the rig.
+func (c *Controller) SetZones(zones []Zone) {
+	c.zones = zones
+}
+
+func (c *Controller) Zones() []Zone {
+	return c.zones
+}
+
+// Targets returns the bodies the camera follows.
+func (c *Controller) Targets() []body.Body {
+	return c.targets
+}
+
+// zoneAt returns the first zone containing x, y, if any.
+func (c *Controller) zoneAt(x, y float64) (Zone, bool) {
+	p := image.Pt(int(math.Floor(x)), int(math.Floor(y)))
+	for _, z := range c.zones {
+		if p.In(z.Area) {
+			return z, true
+		}
+	}
+	return Zone{}, false
+}
+
+// frame returns the box around the centers of the targets.
+func (c *Controller) frame() (minX, minY, maxX, maxY float64) {
+	minX, minY = math.Inf(1), math.Inf(1)
+	maxX, maxY = math.Inf(-1), math.Inf(-1)
+	for _, t := range c.targets {
+		x, y := t.GetPositionMin()
+		w, h := t.GetShape().Width(), t.GetShape().Height()
+		cx, cy := float64(x)+float64(w)/2, float64(y)+float64(h)/2
+		minX, minY = math.Min(minX, cx), math.Min(minY, cy)
+		maxX, maxY = math.Max(maxX, cx), math.Max(maxY, cy)
+	}
+	return minX, minY, maxX, maxY
+}
+
+// airborne reports whether any target is jumping or falling.
+func (c *Controller) airborne() bool {
+	for _, t := range c.targets {
+		if m, ok := t.(interface {
+			IsFalling() bool
+			IsGoingUp() bool
+		}); ok && (m.IsFalling() || m.IsGoingUp()) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookAheadDirection returns -1, 0 or 1, the direction a single target moves
+// in, or faces when standing still.
+func (c *Controller) lookAheadDirection() float64 {
+	if len(c.targets) != 1 {
+		return 0
+	}
+	t := c.targets[0]
+	if m, ok := t.(interface{ Velocity() (int, int) }); ok {
+		// Ignore sub-pixel drifting
+		if vx16, _ := m.Velocity(); vx16 >= fp16.To16(1)/4 {
+			return 1
+		} else if vx16 <= -fp16.To16(1)/4 {
+			return -1
+		}
+	}
+	if f, ok := t.(interface {
+		FaceDirection() animation.FacingDirectionEnum
+	}); ok {
+		if f.FaceDirection() == animation.FaceDirectionLeft {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// follow moves the focus towards the targets following the rig, and returns
+// the point the camera should look at.
+func (c *Controller) follow(rig Rig, zoom float64) (float64, float64) {
+	minX, minY, maxX, maxY := c.frame()
+	tx, ty := (minX+maxX)/2, (minY+maxY)/2
+
+	dzW, dzH := rig.DeadZoneWidth, rig.DeadZoneHeight
+	if rig.VerticalOnLanding && c.airborne() {
+		dzH = math.Max(dzH, c.screenHeight/zoom/2)
+	}
+	c.focusX = followDeadZone(c.focusX, tx, dzW)
+	c.focusY = followDeadZone(c.focusY, ty, dzH)
+
+	speed := rig.LookAheadSpeed
+	if speed <= 0 || speed > 1 {
+		speed = 1
+	}
+	goal := c.lookAheadDirection() * rig.LookAhead
+	c.lookAheadX += (goal - c.lookAheadX) * speed
+
+	return c.focusX + c.lookAheadX, c.focusY
+}
+
+// followDeadZone moves focus the least needed to keep target within size
+// pixels of it.
+func followDeadZone(focus, target, size float64) float64 {
+	half := size / 2
+	switch {
+	case target > focus+half:
+		return target - half
+	case target < focus-half:
+		return target + half
+	}
+	return focus
+}
+
+// frameZoom returns the zoom that keeps all the targets in view, between
+// rig.MinZoom and zoom.
+func (c *Controller) frameZoom(rig Rig, zoom float64) float64 {
+	if rig.MinZoom <= 0 || len(c.targets) < 2 {
+		return zoom
+	}
+	minX, minY, maxX, maxY := c.frame()
+	w := maxX - minX + 2*rig.FramePadding
+	h := maxY - minY + 2*rig.FramePadding
+	fit := math.Min(c.screenWidth/math.Max(w, 1), c.screenHeight/math.Max(h, 1))
+	return math.Max(rig.MinZoom, math.Min(zoom, fit))
+}
+
+// SetFollowTargets makes the camera frame several bodies, e.g. two players.
+func (c *Controller) SetFollowTargets(targets ...body.Body) {
+	c.targets = targets
+	c.followTarget = nil
+	if len(targets) == 0 {
+		return
+	}
+	c.followTarget = targets[0]
+	minX, minY, maxX, maxY := c.frame()
+	c.snapTo((minX+maxX)/2, (minY+maxY)/2)
+}
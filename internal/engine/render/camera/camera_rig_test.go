@@ -0,0 +1,134 @@
+package camera
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
+	"github.com/leandroatallah/firefly/internal/engine/utils/fp16"
+)
+
+func newRigController(t *testing.T, rig Rig, targets ...*bodyphysics.MovableBody) *Controller {
+	t.Helper()
+	config.Set(&config.AppConfig{
+		ScreenWidth:  320,
+		ScreenHeight: 224,
+		Physics:      config.PhysicsConfig{DownwardGravity: 4},
+	})
+	c := NewController(0, 0)
+	c.SetFollowing(true)
+	c.SetRig(rig)
+	switch len(targets) {
+	case 1:
+		c.SetFollowTargets(targets[0])
+	case 2:
+		c.SetFollowTargets(targets[0], targets[1])
+	}
+	return c
+}
+
+// newTarget returns a 10x10 body centered at x, y.
+func newTarget(x, y int) *bodyphysics.MovableBody {
+	b := bodyphysics.NewMovableBody(bodyphysics.NewBody(bodyphysics.NewRect(0, 0, 10, 10)))
+	b.SetPosition(x-5, y-5)
+	return b
+}
+
+func TestRigDeadZone(t *testing.T) {
+	target := newTarget(100, 100)
+	c := newRigController(t, Rig{DeadZoneWidth: 40, DeadZoneHeight: 40}, target)
+
+	target.SetPosition(110-5, 100-5)
+	c.Update()
+	if c.focusX != 100 {
+		t.Errorf("focus moved to %v inside the dead zone", c.focusX)
+	}
+
+	target.SetPosition(130-5, 100-5)
+	c.Update()
+	if c.focusX != 110 {
+		t.Errorf("focus = %v, want the target at the dead zone edge, 110", c.focusX)
+	}
+}
+
+func TestRigVerticalOnLanding(t *testing.T) {
+	target := newTarget(100, 100)
+	c := newRigController(t, Rig{VerticalOnLanding: true}, target)
+
+	// Jumping
+	target.SetVelocity(0, -fp16.To16(2))
+	target.SetPosition(100-5, 60-5)
+	c.Update()
+	if c.focusY != 100 {
+		t.Errorf("focus moved to %v while in the air", c.focusY)
+	}
+
+	// Landed on a ledge
+	target.SetVelocity(0, 0)
+	c.Update()
+	if c.focusY != 60 {
+		t.Errorf("focus = %v after landing, want 60", c.focusY)
+	}
+}
+
+func TestRigLookAhead(t *testing.T) {
+	target := newTarget(100, 100)
+	c := newRigController(t, Rig{LookAhead: 24, LookAheadSpeed: 0.5}, target)
+
+	target.SetVelocity(-fp16.To16(1), 0)
+	for range 20 {
+		c.Update()
+	}
+	if math.Abs(c.lookAheadX+24) > 0.01 {
+		t.Errorf("look ahead = %v, want -24", c.lookAheadX)
+	}
+}
+
+func TestRigFramesTargets(t *testing.T) {
+	a, b := newTarget(0, 100), newTarget(600, 100)
+	c := newRigController(t, Rig{MinZoom: 0.25, FramePadding: 20}, a, b)
+
+	for range 200 {
+		c.Update()
+	}
+	// 640 pixels wide to fit in 320
+	if z := c.Kamera().ZoomFactor; math.Abs(z-0.5) > 0.01 {
+		t.Errorf("zoom = %v, want 0.5", z)
+	}
+	if x, y := c.Kamera().Center(); math.Abs(x-300) > 1 || math.Abs(y-100) > 1 {
+		t.Errorf("center = %v, %v, want 300, 100", x, y)
+	}
+}
+
+func TestZoneOverridesRig(t *testing.T) {
+	z := Zone{
+		Area: image.Rect(0, 0, 100, 100),
+		Properties: map[string]string{
+			"dead_zone_width":     "64",
+			"vertical_on_landing": "false",
+			"clamp":               "true",
+			"other":               "ignored",
+		},
+	}
+	rig := z.Apply(Rig{DeadZoneWidth: 20, LookAhead: 24, VerticalOnLanding: true})
+	if rig.DeadZoneWidth != 64 || rig.LookAhead != 24 || rig.VerticalOnLanding {
+		t.Errorf("rig = %+v", rig)
+	}
+	if !z.Clamps() {
+		t.Error("zone should clamp")
+	}
+
+	target := newTarget(50, 50)
+	c := newRigController(t, Rig{}, target)
+	c.SetZones([]Zone{z})
+	c.Update()
+	if c.focusX != 50 {
+		t.Errorf("focus = %v, want 50", c.focusX)
+	}
+	// Clamped to the zone, centered as it is smaller than the screen
+	if x, _ := c.Kamera().Center(); math.Abs(x-50) > 1 {
+		t.Errorf("center x = %v, want 50", x)
+	}
+}
@@ -0,0 +1,152 @@
+package lighting
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/render/camera"
+)
+
+// gradientSize is the size of the texture lights are drawn with.
+const gradientSize = 64
+
+// segments is how many triangles a whole circle of light is drawn with.
+const segments = 32
+
+// multiply blends the light map with what is drawn under it.
+var multiply = ebiten.Blend{
+	BlendFactorSourceRGB:        ebiten.BlendFactorDestinationColor,
+	BlendFactorSourceAlpha:      ebiten.BlendFactorZero,
+	BlendFactorDestinationRGB:   ebiten.BlendFactorZero,
+	BlendFactorDestinationAlpha: ebiten.BlendFactorOne,
+	BlendOperationRGB:           ebiten.BlendOperationAdd,
+	BlendOperationAlpha:         ebiten.BlendOperationAdd,
+}
+
+// newGradient returns a white circle fading out like lights do.
+func newGradient() *ebiten.Image {
+	img := image.NewRGBA(image.Rect(0, 0, gradientSize, gradientSize))
+	half := float64(gradientSize) / 2
+	for y := 0; y < gradientSize; y++ {
+		for x := 0; x < gradientSize; x++ {
+			d := math.Hypot(float64(x)+0.5-half, float64(y)+0.5-half) / half
+			if d >= 1 {
+				continue
+			}
+			v := uint8(falloff(d) * 0xff)
+			img.SetRGBA(x, y, color.RGBA{v, v, v, v})
+		}
+	}
+	return ebiten.NewImageFromImage(img)
+}
+
+// Draw multiplies what the camera sees, drawn on dst, with the light map.
+// Nothing is drawn in daylight.
+func (s *System) Draw(dst *ebiten.Image, cam *camera.Controller) {
+	if !s.Dark() {
+		return
+	}
+	if s.gradient == nil {
+		s.gradient = newGradient()
+	}
+
+	w, h := int(cam.Kamera().Width), int(cam.Kamera().Height)
+	if w <= 0 || h <= 0 {
+		return
+	}
+	for _, img := range []**ebiten.Image{&s.lightMap, &s.scratch} {
+		if *img == nil || (*img).Bounds().Size() != image.Pt(w, h) {
+			if *img != nil {
+				(*img).Deallocate()
+			}
+			*img = ebiten.NewImage(w, h)
+		}
+	}
+
+	s.lightMap.Fill(s.Ambient)
+	visible := cam.VisibleRect()
+	for _, l := range s.lights {
+		if !l.Bounds().Overlaps(visible) || l.strength() <= 0 {
+			continue
+		}
+		s.drawLight(l, cam)
+	}
+
+	dst.DrawImage(s.lightMap, &ebiten.DrawImageOptions{Blend: multiply})
+}
+
+// drawLight adds the light, with the shadows cut out, to the light map.
+func (s *System) drawLight(l *Light, cam *camera.Controller) {
+	k := cam.Kamera()
+	toScreen := func(x, y float64) (float32, float32) {
+		sx, sy := k.ApplyCameraTransformToPoint(x, y)
+		return float32(sx), float32(sy)
+	}
+
+	// A fan of triangles around the light, mapped on the gradient
+	start, sweep := 0.0, 2*math.Pi
+	if l.IsCone() {
+		start, sweep = l.direction()-l.Angle/2, l.Angle
+	}
+	n := max(2, int(math.Ceil(segments*sweep/(2*math.Pi))))
+
+	str := float32(l.strength())
+	r := float32(l.Color.R) / 0xff * str
+	g := float32(l.Color.G) / 0xff * str
+	b := float32(l.Color.B) / 0xff * str
+	half := float32(gradientSize) / 2
+
+	s.vertices, s.indices = s.vertices[:0], s.indices[:0]
+	cx, cy := toScreen(l.X, l.Y)
+	s.vertices = append(s.vertices, ebiten.Vertex{DstX: cx, DstY: cy, SrcX: half, SrcY: half, ColorR: r, ColorG: g, ColorB: b, ColorA: str})
+	bounds := image.Rect(int(cx), int(cy), int(cx)+1, int(cy)+1)
+	for i := 0; i <= n; i++ {
+		a := start + sweep*float64(i)/float64(n)
+		cos, sin := math.Cos(a), math.Sin(a)
+		x, y := toScreen(l.X+cos*l.Radius, l.Y+sin*l.Radius)
+		s.vertices = append(s.vertices, ebiten.Vertex{
+			DstX: x, DstY: y,
+			SrcX: half + float32(cos)*half, SrcY: half + float32(sin)*half,
+			ColorR: r, ColorG: g, ColorB: b, ColorA: str,
+		})
+		bounds = bounds.Union(image.Rect(int(math.Floor(float64(x))), int(math.Floor(float64(y))), int(math.Ceil(float64(x))), int(math.Ceil(float64(y)))))
+		if i > 0 {
+			s.indices = append(s.indices, 0, uint16(i), uint16(i+1))
+		}
+	}
+	bounds = bounds.Intersect(s.scratch.Bounds())
+	if bounds.Empty() {
+		return
+	}
+
+	area := s.scratch.SubImage(bounds).(*ebiten.Image)
+	area.Clear()
+	area.DrawTriangles(s.vertices, s.indices, s.gradient, &ebiten.DrawTrianglesOptions{Filter: ebiten.FilterLinear})
+
+	// Shadows clear the light behind the obstacles
+	s.vertices, s.indices = s.vertices[:0], s.indices[:0]
+	for _, rect := range s.casters(l, l.Bounds()) {
+		for _, q := range shadows(l, rect) {
+			i := uint16(len(s.vertices))
+			for _, p := range q {
+				x, y := toScreen(p[0], p[1])
+				s.vertices = append(s.vertices, ebiten.Vertex{DstX: x, DstY: y, SrcX: half, SrcY: half, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1})
+			}
+			s.indices = append(s.indices, i, i+1, i+2, i, i+2, i+3)
+			// Keep within the vertex limit of a draw call
+			if len(s.vertices) > math.MaxUint16-4 {
+				area.DrawTriangles(s.vertices, s.indices, s.gradient, &ebiten.DrawTrianglesOptions{Blend: ebiten.BlendClear})
+				s.vertices, s.indices = s.vertices[:0], s.indices[:0]
+			}
+		}
+	}
+	if len(s.indices) > 0 {
+		area.DrawTriangles(s.vertices, s.indices, s.gradient, &ebiten.DrawTrianglesOptions{Blend: ebiten.BlendClear})
+	}
+
+	opts := &ebiten.DrawImageOptions{Blend: ebiten.BlendLighter}
+	opts.GeoM.Translate(float64(bounds.Min.X), float64(bounds.Min.Y))
+	s.lightMap.DrawImage(area, opts)
+}
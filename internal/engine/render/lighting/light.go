@@ -0,0 +1,140 @@
+package lighting
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/leandroatallah/firefly/internal/engine/contracts/animation"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+)
+
+// Light is a point light, or a cone light when Angle is set, e.g. a lantern,
+// a campfire or a swarm of fireflies.
+type Light struct {
+	X, Y      float64 // Center, in world coordinates
+	Radius    float64
+	Color     color.NRGBA
+	Intensity float64
+	// Direction is where a cone light points, in radians, 0 pointing right
+	// and pi/2 down. Angle is how wide the cone is, 0 for a point light.
+	Direction float64
+	Angle     float64
+	// Flicker is how much the intensity varies over time, in [0, 1].
+	Flicker float64
+
+	// Body the light follows, offset from its center. Cone lights of bodies
+	// facing left point the other way.
+	body             body.Body
+	offsetX, offsetY float64
+	flicker          float64 // Current intensity factor of the flicker
+	seed             float64
+}
+
+// NewPointLight returns a white point light.
+func NewPointLight(x, y, radius float64) *Light {
+	return &Light{X: x, Y: y, Radius: radius, Color: color.NRGBA{0xff, 0xff, 0xff, 0xff}, Intensity: 1, flicker: 1}
+}
+
+// NewConeLight returns a white cone light pointing at direction, angle
+// radians wide.
+func NewConeLight(x, y, radius, direction, angle float64) *Light {
+	l := NewPointLight(x, y, radius)
+	l.Direction, l.Angle = direction, angle
+	return l
+}
+
+// Attach makes the light follow the center of the body, offset by x, y.
+func (l *Light) Attach(b body.Body, offsetX, offsetY float64) {
+	l.body, l.offsetX, l.offsetY = b, offsetX, offsetY
+	l.follow()
+}
+
+// Body returns the body the light follows, if any.
+func (l *Light) Body() body.Body {
+	return l.body
+}
+
+// IsCone reports whether the light is a cone light.
+func (l *Light) IsCone() bool {
+	return l.Angle > 0 && l.Angle < 2*math.Pi
+}
+
+// facingLeft reports whether the body of the light faces left.
+func (l *Light) facingLeft() bool {
+	f, ok := l.body.(interface {
+		FaceDirection() animation.FacingDirectionEnum
+	})
+	return ok && f.FaceDirection() == animation.FaceDirectionLeft
+}
+
+// follow moves the light to the body it follows.
+func (l *Light) follow() {
+	if l.body == nil {
+		return
+	}
+	r := l.body.Position()
+	ox := l.offsetX
+	if l.facingLeft() {
+		ox = -ox
+	}
+	l.X = float64(r.Min.X+r.Max.X)/2 + ox
+	l.Y = float64(r.Min.Y+r.Max.Y)/2 + l.offsetY
+}
+
+// direction returns where the cone points, mirrored when the body faces
+// left.
+func (l *Light) direction() float64 {
+	if l.body != nil && l.facingLeft() {
+		return math.Pi - l.Direction
+	}
+	return l.Direction
+}
+
+// update advances the flicker to the frame.
+func (l *Light) update(frame int) {
+	l.follow()
+	if l.Flicker <= 0 {
+		l.flicker = 1
+		return
+	}
+	t := float64(frame)
+	wave := 0.5 + 0.25*math.Sin(t*0.21+l.seed) + 0.25*math.Sin(t*0.57+l.seed*2)
+	l.flicker = 1 - l.Flicker*wave
+}
+
+// strength returns the intensity of the light after flickering.
+func (l *Light) strength() float64 {
+	return l.Intensity * l.flicker
+}
+
+// Bounds returns the area the light reaches, in world coordinates.
+func (l *Light) Bounds() image.Rectangle {
+	return image.Rect(
+		int(math.Floor(l.X-l.Radius)), int(math.Floor(l.Y-l.Radius)),
+		int(math.Ceil(l.X+l.Radius)), int(math.Ceil(l.Y+l.Radius)),
+	)
+}
+
+// levelAt returns how much the light lights x, y, shadows aside, in [0, 1].
+func (l *Light) levelAt(x, y float64) float64 {
+	dx, dy := x-l.X, y-l.Y
+	d := math.Hypot(dx, dy)
+	if d >= l.Radius {
+		return 0
+	}
+	if l.IsCone() && d > 0 {
+		diff := math.Remainder(math.Atan2(dy, dx)-l.direction(), 2*math.Pi)
+		if math.Abs(diff) > l.Angle/2 {
+			return 0
+		}
+	}
+	lum := (0.299*float64(l.Color.R) + 0.587*float64(l.Color.G) + 0.114*float64(l.Color.B)) / 0xff
+	return falloff(d/l.Radius) * l.strength() * lum
+}
+
+// falloff is the light left at t, the distance from the light relative to
+// its radius.
+func falloff(t float64) float64 {
+	return (1 - t) * (1 - t)
+}
@@ -0,0 +1,178 @@
+package lighting
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	physicsspace "github.com/leandroatallah/firefly/internal/engine/physics/space"
+)
+
+// Daylight is the ambient light of phases without darkness.
+var Daylight = color.NRGBA{0xff, 0xff, 0xff, 0xff}
+
+// System lights the world: a light map filled with the ambient light, with
+// each light added and the shadows of the obstacles cut out, multiplied with
+// what is drawn.
+type System struct {
+	// Ambient is the light everywhere, Daylight by default.
+	Ambient color.NRGBA
+
+	lights []*Light
+	space  body.BodiesSpace
+	frame  int
+
+	lightMap, scratch *ebiten.Image
+	gradient          *ebiten.Image
+	vertices          []ebiten.Vertex
+	indices           []uint16
+}
+
+// NewSystem returns a lighting system casting shadows from the obstructive
+// bodies of the space, which can be nil.
+func NewSystem(space body.BodiesSpace) *System {
+	return &System{Ambient: Daylight, space: space}
+}
+
+// Add adds lights.
+func (s *System) Add(lights ...*Light) {
+	for _, l := range lights {
+		l.seed = float64(len(s.lights)) * 1.7
+		l.update(s.frame)
+		s.lights = append(s.lights, l)
+	}
+}
+
+// Remove removes a light.
+func (s *System) Remove(l *Light) {
+	for i, other := range s.lights {
+		if other == l {
+			s.lights = append(s.lights[:i], s.lights[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *System) Lights() []*Light {
+	return s.lights
+}
+
+// Dark reports whether the ambient light is dimmer than daylight. Lights
+// are only drawn in the dark.
+func (s *System) Dark() bool {
+	return s.Ambient != Daylight
+}
+
+// Update moves the lights with their bodies and makes them flicker.
+func (s *System) Update() {
+	s.frame++
+	for _, l := range s.lights {
+		l.update(s.frame)
+	}
+}
+
+// LightAt returns the light level at x, y in world coordinates, from 0 in
+// complete darkness to 1, e.g. for enemies to see less in the dark.
+func (s *System) LightAt(x, y float64) float64 {
+	a := s.Ambient
+	level := (0.299*float64(a.R) + 0.587*float64(a.G) + 0.114*float64(a.B)) / 0xff
+	for _, l := range s.lights {
+		if level >= 1 {
+			break
+		}
+		v := l.levelAt(x, y)
+		if v > 0 && !s.occluded(l, x, y) {
+			level += v
+		}
+	}
+	return math.Min(level, 1)
+}
+
+// casters returns the collision rects casting shadows from the light: those
+// of obstructive bodies, one way surfaces letting light through.
+func (s *System) casters(l *Light, area image.Rectangle) []image.Rectangle {
+	if s.space == nil {
+		return nil
+	}
+	var rects []image.Rectangle
+	for _, b := range s.space.Query(area) {
+		if !b.IsObstructive() || (l.body != nil && b.ID() == l.body.ID()) {
+			continue
+		}
+		if surface, ok := b.(body.Surface); ok && surface.OneWay() {
+			continue
+		}
+		for _, r := range b.CollisionPosition() {
+			// Lights in walls are not put out
+			if !image.Pt(int(l.X), int(l.Y)).In(r) {
+				rects = append(rects, r)
+			}
+		}
+	}
+	return rects
+}
+
+// occluded reports whether an obstacle is between the light and x, y.
+func (s *System) occluded(l *Light, x, y float64) bool {
+	area := image.Rect(int(math.Floor(l.X)), int(math.Floor(l.Y)), int(math.Floor(x)), int(math.Floor(y))).Canon()
+	area.Max = area.Max.Add(image.Pt(1, 1))
+	for _, r := range s.casters(l, area) {
+		if physicsspace.SegmentHitsRect(l.X, l.Y, x, y, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// shadows returns the quads of the shadows the rect casts from the light,
+// one for each of its edges facing away from the light, reaching past the
+// light radius. The rect itself stays lit.
+func shadows(l *Light, r image.Rectangle) [][4][2]float64 {
+	x0, y0 := float64(r.Min.X), float64(r.Min.Y)
+	x1, y1 := float64(r.Max.X), float64(r.Max.Y)
+	// Edges with their outward normal
+	edges := [4]struct {
+		a, b   [2]float64
+		nx, ny float64
+	}{
+		{[2]float64{x0, y0}, [2]float64{x1, y0}, 0, -1},
+		{[2]float64{x1, y0}, [2]float64{x1, y1}, 1, 0},
+		{[2]float64{x1, y1}, [2]float64{x0, y1}, 0, 1},
+		{[2]float64{x0, y1}, [2]float64{x0, y0}, -1, 0},
+	}
+
+	project := func(p [2]float64) [2]float64 {
+		dx, dy := p[0]-l.X, p[1]-l.Y
+		d := math.Hypot(dx, dy)
+		if d == 0 {
+			return p
+		}
+		k := 2 * l.Radius / d
+		return [2]float64{p[0] + dx*k, p[1] + dy*k}
+	}
+
+	var quads [][4][2]float64
+	for _, e := range edges {
+		mx, my := (e.a[0]+e.b[0])/2, (e.a[1]+e.b[1])/2
+		if e.nx*(l.X-mx)+e.ny*(l.Y-my) >= 0 {
+			continue // Facing the light
+		}
+		quads = append(quads, [4][2]float64{e.a, e.b, project(e.b), project(e.a)})
+	}
+	return quads
+}
+
+// Sees reports whether an actor at x, y sees what is at tx, ty, its sight
+// range shrinking in the dark with the light level there.
+func (s *System) Sees(x, y, tx, ty, sightRange, darkSight float64) bool {
+	return math.Hypot(tx-x, ty-y) <= SightRange(sightRange, darkSight, s.LightAt(tx, ty))
+}
+
+// SightRange returns how far an actor sees at the light level, between
+// darkSight of its range in complete darkness and its whole range in full
+// light.
+func SightRange(sightRange, darkSight, level float64) float64 {
+	return sightRange * (darkSight + (1-darkSight)*level)
+}
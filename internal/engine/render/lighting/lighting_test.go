@@ -0,0 +1,105 @@
+package lighting
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
+	"github.com/leandroatallah/firefly/internal/engine/physics/space"
+)
+
+func newWall(x, y, w, h int) *bodyphysics.ObstacleRect {
+	o := bodyphysics.NewObstacleRect(bodyphysics.NewRect(x, y, w, h))
+	o.SetID("wall")
+	o.SetPosition(x, y)
+	o.AddCollisionBodies()
+	o.SetIsObstructive(true)
+	return o
+}
+
+func TestLightAt(t *testing.T) {
+	s := NewSystem(nil)
+	if l := s.LightAt(0, 0); l != 1 || s.Dark() {
+		t.Errorf("daylight level = %v", l)
+	}
+
+	s.Ambient = color.NRGBA{0, 0, 0, 0xff}
+	s.Add(NewPointLight(0, 0, 100))
+	if l := s.LightAt(0, 0); l != 1 {
+		t.Errorf("level at the light = %v, want 1", l)
+	}
+	if l := s.LightAt(50, 0); math.Abs(l-0.25) > 1e-9 {
+		t.Errorf("level halfway = %v, want 0.25", l)
+	}
+	if l := s.LightAt(100, 0); l != 0 {
+		t.Errorf("level out of reach = %v, want 0", l)
+	}
+}
+
+func TestConeLight(t *testing.T) {
+	s := NewSystem(nil)
+	s.Ambient = color.NRGBA{0, 0, 0, 0xff}
+	s.Add(NewConeLight(0, 0, 100, 0, math.Pi/2))
+
+	if l := s.LightAt(50, 10); l == 0 {
+		t.Error("in front of the cone should be lit")
+	}
+	if l := s.LightAt(-50, 0); l != 0 {
+		t.Errorf("behind the cone = %v, want 0", l)
+	}
+	if l := s.LightAt(10, 50); l != 0 {
+		t.Errorf("aside the cone = %v, want 0", l)
+	}
+}
+
+func TestShadows(t *testing.T) {
+	sp := space.NewSpace()
+	sp.AddBody(newWall(40, -10, 10, 20))
+	s := NewSystem(sp)
+	s.Ambient = color.NRGBA{0, 0, 0, 0xff}
+	s.Add(NewPointLight(0, 0, 100))
+
+	if l := s.LightAt(70, 0); l != 0 {
+		t.Errorf("behind the wall = %v, want 0", l)
+	}
+	if l := s.LightAt(30, 0); l == 0 {
+		t.Error("before the wall should be lit")
+	}
+	if l := s.LightAt(70, 40); l == 0 {
+		t.Error("past the side of the wall should be lit")
+	}
+
+	// The wall casts a shadow from its far edges only
+	quads := shadows(s.Lights()[0], image.Rect(40, -10, 50, 10))
+	if len(quads) != 3 {
+		t.Errorf("%d shadow quads, want the right, top and bottom edges", len(quads))
+	}
+}
+
+func TestSightRange(t *testing.T) {
+	if r := SightRange(100, 0.25, 1); r != 100 {
+		t.Errorf("range in full light = %v", r)
+	}
+	if r := SightRange(100, 0.25, 0); r != 25 {
+		t.Errorf("range in the dark = %v", r)
+	}
+}
+
+func TestSeesInShadow(t *testing.T) {
+	sp := space.NewSpace()
+	sp.AddBody(newWall(40, 30, 10, 20))
+	s := NewSystem(sp)
+	s.Ambient = color.NRGBA{0, 0, 0, 0xff}
+	s.Add(NewPointLight(0, 40, 200))
+
+	// Both targets are as far from an actor at 60, 10, one lit and the
+	// other behind the wall
+	if !s.Sees(60, 10, 30, 40, 96, 0.25) {
+		t.Error("the lit target should be seen")
+	}
+	if s.Sees(60, 10, 90, 40, 96, 0.25) {
+		t.Error("the target in shadow should not be seen")
+	}
+}
@@ -0,0 +1,96 @@
+package particles
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// maxBatchQuads keeps the vertex indices within uint16.
+const maxBatchQuads = (1 << 16) / 4
+
+// Viewport is the area of the world seen by the camera, used to skip
+// off-screen particles.
+type Viewport struct {
+	X, Y, Width, Height float64
+}
+
+func (v Viewport) intersects(x0, y0, x1, y1 float64) bool {
+	if v.Width <= 0 || v.Height <= 0 {
+		return true
+	}
+	return x1 >= v.X && x0 <= v.X+v.Width && y1 >= v.Y && y0 <= v.Y+v.Height
+}
+
+// batch accumulates the particle quads that share an image and draws them
+// with a single DrawTriangles call. Its buffers are reused between frames.
+type batch struct {
+	image    *ebiten.Image
+	vertices []ebiten.Vertex
+	indices  []uint16
+	options  ebiten.DrawTrianglesOptions
+
+	geoM ebiten.GeoM
+	view Viewport
+
+	// Quads drawn and culled during the last frame, for debugging.
+	drawn, culled int
+}
+
+func (b *batch) reset(geoM ebiten.GeoM, view Viewport) {
+	b.geoM = geoM
+	b.view = view
+	b.image = nil
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+	b.drawn, b.culled = 0, 0
+	// Particle color scales are premultiplied, like ebiten.ColorScale
+	b.options.ColorScaleMode = ebiten.ColorScaleModePremultipliedAlpha
+}
+
+func (b *batch) add(screen *ebiten.Image, p *Particle) {
+	src := p.srcRect()
+	w, h := float64(src.Dx())*p.Scale, float64(src.Dy())*p.Scale
+
+	// Particles are anchored at their bottom center
+	x0, y0 := p.X-w/2, p.Y-h
+	x1, y1 := x0+w, p.Y
+	if !b.view.intersects(x0, y0, x1, y1) {
+		b.culled++
+		return
+	}
+
+	if b.image != p.Config.Image || len(b.vertices)/4 >= maxBatchQuads {
+		b.flush(screen)
+		b.image = p.Config.Image
+	}
+
+	cr, cg, cb, ca := p.ColorScale.R(), p.ColorScale.G(), p.ColorScale.B(), p.ColorScale.A()
+	sx0, sy0 := float32(src.Min.X), float32(src.Min.Y)
+	sx1, sy1 := float32(src.Max.X), float32(src.Max.Y)
+
+	i := uint16(len(b.vertices))
+	b.vertices = append(b.vertices,
+		b.vertex(x0, y0, sx0, sy0, cr, cg, cb, ca),
+		b.vertex(x1, y0, sx1, sy0, cr, cg, cb, ca),
+		b.vertex(x0, y1, sx0, sy1, cr, cg, cb, ca),
+		b.vertex(x1, y1, sx1, sy1, cr, cg, cb, ca),
+	)
+	b.indices = append(b.indices, i, i+1, i+2, i+1, i+3, i+2)
+	b.drawn++
+}
+
+func (b *batch) vertex(x, y float64, sx, sy, r, g, bl, a float32) ebiten.Vertex {
+	dx, dy := b.geoM.Apply(x, y)
+	return ebiten.Vertex{
+		DstX: float32(dx), DstY: float32(dy),
+		SrcX: sx, SrcY: sy,
+		ColorR: r, ColorG: g, ColorB: bl, ColorA: a,
+	}
+}
+
+func (b *batch) flush(screen *ebiten.Image) {
+	if len(b.indices) > 0 && screen != nil {
+		screen.DrawTriangles(b.vertices, b.indices, b.image, &b.options)
+	}
+	b.vertices = b.vertices[:0]
+	b.indices = b.indices[:0]
+}
@@ -0,0 +1,73 @@
+package particles
+
+import (
+	"sort"
+
+	"github.com/leandroatallah/firefly/internal/engine/data/schemas"
+)
+
+// Curve maps the normalized life of a particle (0 to 1) to a value,
+// interpolating linearly between keys.
+type Curve []schemas.CurveKeyData
+
+func NewCurve(keys []schemas.CurveKeyData) Curve {
+	c := Curve(append([]schemas.CurveKeyData(nil), keys...))
+	sort.Slice(c, func(i, j int) bool { return c[i].T < c[j].T })
+	return c
+}
+
+// Eval returns the value at t, or def when the curve has no keys.
+func (c Curve) Eval(t, def float64) float64 {
+	switch {
+	case len(c) == 0:
+		return def
+	case t <= c[0].T:
+		return c[0].V
+	case t >= c[len(c)-1].T:
+		return c[len(c)-1].V
+	}
+
+	for i := 1; i < len(c); i++ {
+		if t <= c[i].T {
+			a, b := c[i-1], c[i]
+			return lerp(a.V, b.V, (t-a.T)/(b.T-a.T))
+		}
+	}
+	return c[len(c)-1].V
+}
+
+// ColorCurve is a Curve for the RGB channels.
+type ColorCurve []schemas.ColorKeyData
+
+func NewColorCurve(keys []schemas.ColorKeyData) ColorCurve {
+	c := ColorCurve(append([]schemas.ColorKeyData(nil), keys...))
+	sort.Slice(c, func(i, j int) bool { return c[i].T < c[j].T })
+	return c
+}
+
+// Eval returns the color at t, or white when the curve has no keys.
+func (c ColorCurve) Eval(t float64) (r, g, b float64) {
+	switch {
+	case len(c) == 0:
+		return 1, 1, 1
+	case t <= c[0].T:
+		return c[0].R, c[0].G, c[0].B
+	case t >= c[len(c)-1].T:
+		k := c[len(c)-1]
+		return k.R, k.G, k.B
+	}
+
+	for i := 1; i < len(c); i++ {
+		if t <= c[i].T {
+			a, k := c[i-1], c[i]
+			f := (t - a.T) / (k.T - a.T)
+			return lerp(a.R, k.R, f), lerp(a.G, k.G, f), lerp(a.B, k.B, f)
+		}
+	}
+	k := c[len(c)-1]
+	return k.R, k.G, k.B
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
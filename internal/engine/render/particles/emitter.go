@@ -0,0 +1,246 @@
+package particles
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"math/rand"
+
+	"github.com/leandroatallah/firefly/internal/engine/data/schemas"
+	"github.com/leandroatallah/firefly/internal/engine/utils/timing"
+)
+
+type EmitterMode int
+
+const (
+	EmitContinuous EmitterMode = iota
+	EmitBurst
+)
+
+type ShapeType int
+
+const (
+	ShapePoint ShapeType = iota
+	ShapeLine
+	ShapeRect
+	ShapeCircle
+)
+
+// Range is a closed interval that values are picked from at random.
+type Range struct {
+	Min, Max float64
+}
+
+func (r Range) Random() float64 {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	return r.Min + rand.Float64()*(r.Max-r.Min)
+}
+
+// EmitterConfig is the runtime version of schemas.EmitterData.
+type EmitterConfig struct {
+	Particle *Config
+
+	Mode     EmitterMode
+	Rate     float64 // Particles per second
+	Count    int     // Particles per burst
+	Duration int
+
+	Shape  ShapeType
+	Width  float64
+	Height float64
+	Radius float64
+	X2, Y2 float64
+
+	Speed    Range
+	Angle    Range // Degrees
+	Lifetime Range
+
+	Gravity float64
+	Drag    float64
+	Collide bool
+	Bounce  float64
+
+	Color ColorCurve
+	Alpha Curve
+	Scale Curve
+}
+
+func NewEmitterConfig(particle *Config, data schemas.EmitterData) (*EmitterConfig, error) {
+	cfg := &EmitterConfig{
+		Particle: particle,
+		Rate:     data.Rate,
+		Count:    data.Count,
+		Duration: data.Duration,
+		Width:    data.Shape.Width,
+		Height:   data.Shape.Height,
+		Radius:   data.Shape.Radius,
+		X2:       data.Shape.X2,
+		Y2:       data.Shape.Y2,
+		Speed:    Range(data.Speed),
+		Angle:    Range(data.Angle),
+		Lifetime: Range(data.Lifetime),
+		Gravity:  data.Gravity,
+		Drag:     data.Drag,
+		Collide:  data.Collide,
+		Bounce:   data.Bounce,
+		Color:    NewColorCurve(data.Color),
+		Alpha:    NewCurve(data.Alpha),
+		Scale:    NewCurve(data.Scale),
+	}
+
+	switch data.Mode {
+	case "", "continuous":
+		cfg.Mode = EmitContinuous
+	case "burst":
+		cfg.Mode = EmitBurst
+	default:
+		return nil, fmt.Errorf("unknown emitter mode: %s", data.Mode)
+	}
+
+	switch data.Shape.Type {
+	case "", "point":
+		cfg.Shape = ShapePoint
+	case "line":
+		cfg.Shape = ShapeLine
+	case "rect":
+		cfg.Shape = ShapeRect
+	case "circle":
+		cfg.Shape = ShapeCircle
+	default:
+		return nil, fmt.Errorf("unknown emitter shape: %s", data.Shape.Type)
+	}
+
+	return cfg, nil
+}
+
+// spawnOffset returns a random point of the shape, relative to the emitter.
+func (c *EmitterConfig) spawnOffset() (float64, float64) {
+	switch c.Shape {
+	case ShapeLine:
+		t := rand.Float64()
+		return c.X2 * t, c.Y2 * t
+	case ShapeRect:
+		return (rand.Float64() - 0.5) * c.Width, (rand.Float64() - 0.5) * c.Height
+	case ShapeCircle:
+		// sqrt keeps the distribution uniform over the area
+		r := c.Radius * math.Sqrt(rand.Float64())
+		a := rand.Float64() * 2 * math.Pi
+		return r * math.Cos(a), r * math.Sin(a)
+	}
+	return 0, 0
+}
+
+func (c *EmitterConfig) lifetime() int {
+	if life := int(c.Lifetime.Random()); life > 0 {
+		return life
+	}
+	if c.Particle != nil && c.Particle.FrameCount*c.Particle.FrameRate > 0 {
+		return c.Particle.FrameCount * c.Particle.FrameRate
+	}
+	return timing.TPS
+}
+
+// Anchor is something an emitter can follow, like an actor.
+type Anchor interface {
+	Position() image.Rectangle
+}
+
+// Emitter spawns particles over time, or all at once in burst mode.
+type Emitter struct {
+	Config *EmitterConfig
+
+	X, Y float64
+	// Offset from the anchor center when attached.
+	OffsetX, OffsetY float64
+
+	anchor  Anchor
+	age     int
+	pending float64
+	stopped bool
+	fired   bool
+}
+
+func NewEmitter(cfg *EmitterConfig, x, y float64) *Emitter {
+	return &Emitter{Config: cfg, X: x, Y: y}
+}
+
+// Attach makes the emitter follow the center of anchor.
+func (e *Emitter) Attach(anchor Anchor, offsetX, offsetY float64) {
+	e.anchor = anchor
+	e.OffsetX, e.OffsetY = offsetX, offsetY
+	e.follow()
+}
+
+func (e *Emitter) Anchor() Anchor {
+	return e.anchor
+}
+
+// Stop ends the emission. Particles already spawned live until they expire.
+func (e *Emitter) Stop() {
+	e.stopped = true
+}
+
+// IsDone reports whether the emitter won't spawn any more particles.
+func (e *Emitter) IsDone() bool {
+	if e.stopped {
+		return true
+	}
+	if e.Config.Mode == EmitBurst {
+		return e.fired
+	}
+	return e.Config.Duration > 0 && e.age >= e.Config.Duration
+}
+
+func (e *Emitter) follow() {
+	if e.anchor == nil {
+		return
+	}
+	pos := e.anchor.Position()
+	e.X = float64(pos.Min.X+pos.Dx()/2) + e.OffsetX
+	e.Y = float64(pos.Min.Y+pos.Dy()/2) + e.OffsetY
+}
+
+// Update spawns the particles due this frame into the system.
+func (e *Emitter) Update(s *System) {
+	if e.IsDone() {
+		return
+	}
+	e.follow()
+
+	if e.Config.Mode == EmitBurst {
+		for i := 0; i < max(e.Config.Count, 1); i++ {
+			s.Add(e.spawn())
+		}
+		e.fired = true
+		return
+	}
+
+	e.age++
+	e.pending += e.Config.Rate / float64(timing.TPS)
+	for e.pending >= 1 {
+		e.pending--
+		s.Add(e.spawn())
+	}
+}
+
+func (e *Emitter) spawn() Particle {
+	cfg := e.Config
+	ox, oy := cfg.spawnOffset()
+	speed := cfg.Speed.Random()
+	angle := cfg.Angle.Random() * math.Pi / 180
+	life := cfg.lifetime()
+
+	return Particle{
+		X:           e.X + ox,
+		Y:           e.Y + oy,
+		VelX:        math.Cos(angle) * speed,
+		VelY:        math.Sin(angle) * speed,
+		Duration:    life,
+		MaxDuration: life,
+		Scale:       1.0,
+		Config:      cfg.Particle,
+		Emitter:     cfg,
+	}
+}
@@ -0,0 +1,64 @@
+package particles
+
+import (
+	"testing"
+
+	"github.com/leandroatallah/firefly/internal/engine/data/schemas"
+)
+
+func TestCurveEval(t *testing.T) {
+	c := NewCurve([]schemas.CurveKeyData{{T: 1, V: 0}, {T: 0.5, V: 1}})
+
+	cases := map[float64]float64{0: 1, 0.5: 1, 0.75: 0.5, 1: 0}
+	for at, want := range cases {
+		if got := c.Eval(at, 42); got != want {
+			t.Errorf("Eval(%v) = %v, want %v", at, got, want)
+		}
+	}
+	if got := Curve(nil).Eval(0.5, 42); got != 42 {
+		t.Errorf("expected default for empty curve, got %v", got)
+	}
+}
+
+func TestEmitterModes(t *testing.T) {
+	burst, err := NewEmitterConfig(&Config{}, schemas.EmitterData{Mode: "burst", Count: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	continuous, err := NewEmitterConfig(&Config{}, schemas.EmitterData{
+		Mode:     "continuous",
+		Rate:     30,
+		Duration: 60,
+		Shape:    schemas.EmitterShapeData{Type: "circle", Radius: 4},
+		Lifetime: schemas.RangeData{Min: 200, Max: 200},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewSystem()
+	s.AddEmitter(NewEmitter(burst, 0, 0))
+	s.AddEmitter(NewEmitter(continuous, 10, 10))
+
+	s.Update()
+	if got := s.Len(); got != 5 {
+		t.Fatalf("expected the burst to spawn 5 particles, got %d", got)
+	}
+
+	for i := 0; i < 59; i++ {
+		s.Update()
+	}
+	// 30 particles per second over one second, plus the burst ones that expired.
+	if got := s.Len(); got != 30 {
+		t.Errorf("expected 30 continuous particles, got %d", got)
+	}
+	if len(s.Emitters()) != 0 {
+		t.Errorf("expected finished emitters to be removed, got %d", len(s.Emitters()))
+	}
+}
+
+func TestNewEmitterConfigRejectsUnknownShape(t *testing.T) {
+	if _, err := NewEmitterConfig(&Config{}, schemas.EmitterData{Shape: schemas.EmitterShapeData{Type: "star"}}); err == nil {
+		t.Error("expected an error for an unknown shape")
+	}
+}
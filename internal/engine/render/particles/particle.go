@@ -4,7 +4,6 @@ import (
 	"image"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/leandroatallah/firefly/internal/engine/render/camera"
 )
 
 // Config defines the properties of a particle type.
@@ -16,6 +15,18 @@ type Config struct {
 	FrameRate   int // Ticks per frame
 }
 
+// frameSize returns the size of a frame, defaulting to the whole image.
+func (c *Config) frameSize() (int, int) {
+	w, h := c.FrameWidth, c.FrameHeight
+	if w == 0 {
+		w = c.Image.Bounds().Dx()
+	}
+	if h == 0 {
+		h = c.Image.Bounds().Dy()
+	}
+	return w, h
+}
+
 // Particle represents an active particle instance.
 type Particle struct {
 	X, Y       float64
@@ -34,15 +45,32 @@ type Particle struct {
 	FrameTimer int
 
 	Config *Config
+	// Emitter holds the physics and over-life curves of emitted particles.
+	Emitter *EmitterConfig
 }
 
 // Update advances the particle state.
 func (p *Particle) Update() {
+	if e := p.Emitter; e != nil {
+		p.VelY += e.Gravity
+		p.VelX *= 1 - e.Drag
+		p.VelY *= 1 - e.Drag
+	}
+
 	p.X += p.VelX
 	p.Y += p.VelY
 	p.Duration--
 	p.Scale += p.ScaleSpeed
 
+	if e := p.Emitter; e != nil {
+		t := p.Life()
+		p.Scale = e.Scale.Eval(t, p.Scale)
+		r, g, b := e.Color.Eval(t)
+		p.ColorScale.Reset()
+		p.ColorScale.Scale(float32(r), float32(g), float32(b), 1)
+		p.ColorScale.ScaleAlpha(float32(e.Alpha.Eval(t, 1)))
+	}
+
 	if p.Config.FrameCount > 1 {
 		p.FrameTimer++
 		if p.FrameTimer >= p.Config.FrameRate {
@@ -55,76 +83,29 @@ func (p *Particle) Update() {
 	}
 }
 
+// Life returns how much of the particle's life has passed, from 0 to 1.
+func (p *Particle) Life() float64 {
+	if p.MaxDuration <= 0 {
+		return 1
+	}
+	return 1 - float64(p.Duration)/float64(p.MaxDuration)
+}
+
 func (p *Particle) IsExpired() bool {
 	return p.Duration <= 0
 }
 
-func (p *Particle) Draw(screen *ebiten.Image, cam *camera.Controller) {
-	if p.Config.Image == nil {
-		return
-	}
-
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Scale(p.Scale, p.Scale)
-	op.GeoM.Translate(p.X, p.Y)
-
-	// Center logic
-	w, h := p.Config.FrameWidth, p.Config.FrameHeight
-	if w == 0 {
-		w = p.Config.Image.Bounds().Dx()
-	}
-	if h == 0 {
-		h = p.Config.Image.Bounds().Dy()
-	}
-
-	// Center the sprite
-	op.GeoM.Translate(-float64(w)*p.Scale/2, -float64(h)*p.Scale)
-
-	op.ColorScale = p.ColorScale
-
-	var subImg *ebiten.Image
+// srcRect returns the area of the particle image for the current frame.
+func (p *Particle) srcRect() image.Rectangle {
+	w, h := p.Config.frameSize()
+	sx := 0
 	if p.Config.FrameCount > 1 {
-		sx := p.Frame * w
+		sx = p.Frame * w
 		// Clamp
 		if sx >= p.Config.Image.Bounds().Dx() {
 			sx = p.Config.Image.Bounds().Dx() - w
 		}
-		subImg = p.Config.Image.SubImage(image.Rect(sx, 0, sx+w, h)).(*ebiten.Image)
-	} else {
-		subImg = p.Config.Image
-	}
-
-	cam.Draw(subImg, op, screen)
-}
-
-// System manages a collection of particles.
-type System struct {
-	particles []*Particle
-}
-
-func NewSystem() *System {
-	return &System{
-		particles: make([]*Particle, 0, 100),
-	}
-}
-
-func (s *System) Add(p *Particle) {
-	s.particles = append(s.particles, p)
-}
-
-func (s *System) Update() {
-	activeParticles := s.particles[:0]
-	for _, p := range s.particles {
-		p.Update()
-		if !p.IsExpired() {
-			activeParticles = append(activeParticles, p)
-		}
-	}
-	s.particles = activeParticles
-}
-
-func (s *System) Draw(screen *ebiten.Image, cam *camera.Controller) {
-	for _, p := range s.particles {
-		p.Draw(screen, cam)
 	}
+	min := p.Config.Image.Bounds().Min
+	return image.Rect(min.X+sx, min.Y, min.X+sx+w, min.Y+h)
 }
@@ -0,0 +1,210 @@
+package particles
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/render/camera"
+)
+
+// DefaultCapacity is the number of particles a system created by NewSystem can hold.
+const DefaultCapacity = 2048
+
+// OverflowPolicy decides what happens when a particle is added to a full system.
+type OverflowPolicy int
+
+const (
+	// OverflowReplaceOldest recycles the particle spawned first.
+	OverflowReplaceOldest OverflowPolicy = iota
+	// OverflowDropNew discards the new particle.
+	OverflowDropNew
+)
+
+// System keeps a fixed-capacity pool of particles and the emitters that spawn
+// them. Particles are stored by value in a ring buffer ordered by spawn time,
+// so spawning and drawing don't allocate.
+type System struct {
+	particles []Particle
+	head      int // Index of the oldest particle
+	count     int
+	overflow  OverflowPolicy
+	dropped   int
+
+	emitters []*Emitter
+
+	// Space is used by particles whose emitter collides with obstacles.
+	space body.BodiesSpace
+
+	batch batch
+}
+
+func NewSystem() *System {
+	return NewSystemWithCapacity(DefaultCapacity, OverflowReplaceOldest)
+}
+
+func NewSystemWithCapacity(capacity int, overflow OverflowPolicy) *System {
+	return &System{
+		particles: make([]Particle, max(capacity, 1)),
+		overflow:  overflow,
+	}
+}
+
+func (s *System) SetSpace(space body.BodiesSpace) {
+	s.space = space
+}
+
+func (s *System) Len() int {
+	return s.count
+}
+
+func (s *System) Cap() int {
+	return len(s.particles)
+}
+
+// Dropped returns how many particles were discarded or recycled because the pool was full.
+func (s *System) Dropped() int {
+	return s.dropped
+}
+
+// At returns the i-th particle, from the oldest to the newest.
+func (s *System) At(i int) *Particle {
+	return &s.particles[(s.head+i)%len(s.particles)]
+}
+
+// Add copies p into the pool. It returns false when the pool is full and the
+// overflow policy drops new particles.
+func (s *System) Add(p Particle) bool {
+	if s.count < len(s.particles) {
+		*s.At(s.count) = p
+		s.count++
+		return true
+	}
+
+	s.dropped++
+	if s.overflow == OverflowDropNew {
+		return false
+	}
+	// The oldest slot becomes the newest one
+	s.particles[s.head] = p
+	s.head = (s.head + 1) % len(s.particles)
+	return true
+}
+
+// Clear removes every particle and emitter.
+func (s *System) Clear() {
+	s.head, s.count = 0, 0
+	clear(s.emitters)
+	s.emitters = s.emitters[:0]
+}
+
+// AddEmitter starts an emitter. It is removed once it's done emitting.
+func (s *System) AddEmitter(e *Emitter) {
+	s.emitters = append(s.emitters, e)
+}
+
+func (s *System) Emitters() []*Emitter {
+	return s.emitters
+}
+
+func (s *System) Update() {
+	activeEmitters := s.emitters[:0]
+	for _, e := range s.emitters {
+		e.Update(s)
+		if !e.IsDone() {
+			activeEmitters = append(activeEmitters, e)
+		}
+	}
+	clear(s.emitters[len(activeEmitters):])
+	s.emitters = activeEmitters
+
+	// Compact the live particles in place, keeping the spawn order
+	n := 0
+	for i := 0; i < s.count; i++ {
+		p := s.At(i)
+		x, y := p.X, p.Y
+		p.Update()
+		if p.Emitter != nil && p.Emitter.Collide {
+			s.collide(p, x, y)
+		}
+		if p.IsExpired() {
+			continue
+		}
+		if n != i {
+			*s.At(n) = *p
+		}
+		n++
+	}
+	s.count = n
+}
+
+// collide moves the particle back to its previous position when it enters an
+// obstacle, bouncing on the axis it crossed.
+func (s *System) collide(p *Particle, prevX, prevY float64) {
+	if s.space == nil || !s.isBlocked(p.X, p.Y) {
+		return
+	}
+
+	bounce := -p.Emitter.Bounce
+	switch {
+	case !s.isBlocked(prevX, p.Y):
+		p.X = prevX
+		p.VelX *= bounce
+	case !s.isBlocked(p.X, prevY):
+		p.Y = prevY
+		p.VelY *= bounce
+	default:
+		p.X, p.Y = prevX, prevY
+		p.VelX *= bounce
+		p.VelY *= bounce
+	}
+}
+
+func (s *System) isBlocked(x, y float64) bool {
+	px, py := int(math.Floor(x)), int(math.Floor(y))
+	for _, b := range s.space.Query(image.Rect(px, py, px+1, py+1)) {
+		if b.IsObstructive() {
+			return true
+		}
+	}
+	return false
+}
+
+// Draw renders the particles visible by the camera, batched by image.
+func (s *System) Draw(screen *ebiten.Image, cam *camera.Controller) {
+	var geoM ebiten.GeoM
+	cam.Kamera().ApplyCameraTransform(&geoM)
+	s.draw(screen, geoM, visibleViewport(cam))
+}
+
+// visibleViewport returns the world area the camera sees, accounting for its
+// zoom and rotation. It is empty, culling nothing, when the camera can't map
+// the screen back to the world.
+func visibleViewport(cam *camera.Controller) Viewport {
+	k := cam.Kamera()
+	w, h := int(k.Width), int(k.Height)
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range [4]image.Point{{0, 0}, {w, 0}, {0, h}, {w, h}} {
+		x, y := k.ScreenToWorld(p.X, p.Y)
+		if math.IsNaN(x) || math.IsNaN(y) {
+			return Viewport{}
+		}
+		minX, minY = math.Min(minX, x), math.Min(minY, y)
+		maxX, maxY = math.Max(maxX, x), math.Max(maxY, y)
+	}
+	return Viewport{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+}
+
+func (s *System) draw(screen *ebiten.Image, geoM ebiten.GeoM, view Viewport) {
+	s.batch.reset(geoM, view)
+	for i := 0; i < s.count; i++ {
+		p := s.At(i)
+		if p.Config == nil || p.Config.Image == nil {
+			continue
+		}
+		s.batch.add(screen, p)
+	}
+	s.batch.flush(screen)
+}
@@ -0,0 +1,110 @@
+package particles
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/data/schemas"
+)
+
+func TestSystemOverflowPolicy(t *testing.T) {
+	cfg := &Config{}
+
+	replace := NewSystemWithCapacity(3, OverflowReplaceOldest)
+	drop := NewSystemWithCapacity(3, OverflowDropNew)
+	for i := 0; i < 5; i++ {
+		p := Particle{X: float64(i), Duration: 10, Config: cfg}
+		replace.Add(p)
+		drop.Add(p)
+	}
+
+	for i, want := range []float64{2, 3, 4} {
+		if got := replace.At(i).X; got != want {
+			t.Errorf("replace oldest: particle %d is %v, want %v", i, got, want)
+		}
+	}
+	for i, want := range []float64{0, 1, 2} {
+		if got := drop.At(i).X; got != want {
+			t.Errorf("drop new: particle %d is %v, want %v", i, got, want)
+		}
+	}
+	if replace.Dropped() != 2 || drop.Dropped() != 2 {
+		t.Errorf("expected 2 dropped particles, got %d and %d", replace.Dropped(), drop.Dropped())
+	}
+}
+
+func TestSystemUpdateKeepsSpawnOrder(t *testing.T) {
+	s := NewSystemWithCapacity(4, OverflowReplaceOldest)
+	for i := 0; i < 6; i++ {
+		// Odd particles expire on the first update
+		s.Add(Particle{X: float64(i), Duration: 1 + i%2, Config: &Config{}})
+	}
+	s.Update()
+
+	if s.Len() != 2 {
+		t.Fatalf("expected 2 particles, got %d", s.Len())
+	}
+	if s.At(0).X != 3 || s.At(1).X != 5 {
+		t.Errorf("expected particles 3 and 5, got %v and %v", s.At(0).X, s.At(1).X)
+	}
+}
+
+func TestBatchCulling(t *testing.T) {
+	img := ebiten.NewImage(1, 1)
+	s := NewSystemWithCapacity(10, OverflowDropNew)
+	s.Add(Particle{X: 10, Y: 10, Duration: 10, Scale: 1, Config: &Config{Image: img}})
+	s.Add(Particle{X: 500, Y: 10, Duration: 10, Scale: 1, Config: &Config{Image: img}})
+
+	s.draw(nil, ebiten.GeoM{}, Viewport{Width: 320, Height: 240})
+	if s.batch.drawn != 1 || s.batch.culled != 1 {
+		t.Errorf("expected 1 drawn and 1 culled, got %d and %d", s.batch.drawn, s.batch.culled)
+	}
+}
+
+// BenchmarkSystem5000 updates and draws 5,000 particles per frame on an
+// offscreen image. The allocations reported are per frame, once the pool and
+// buffers are warm.
+func BenchmarkSystem5000(b *testing.B) {
+	const n = 5000
+
+	img := ebiten.NewImage(1, 1)
+	img.Fill(color.White)
+	emitter, err := NewEmitterConfig(&Config{Image: img}, schemaContinuous())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	s := NewSystemWithCapacity(n, OverflowReplaceOldest)
+	e := NewEmitter(emitter, 160, 120)
+	for i := 0; i < n; i++ {
+		s.Add(e.spawn())
+	}
+	s.AddEmitter(e)
+	view := Viewport{Width: 320, Height: 240}
+	screen := ebiten.NewImage(320, 240)
+
+	// Warm up the vertex buffers
+	s.draw(screen, ebiten.GeoM{}, view)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.Update()
+		s.draw(screen, ebiten.GeoM{}, view)
+	}
+}
+
+func schemaContinuous() schemas.EmitterData {
+	return schemas.EmitterData{
+		Mode:     "continuous",
+		Rate:     5000,
+		Shape:    schemas.EmitterShapeData{Type: "circle", Radius: 100},
+		Speed:    schemas.RangeData{Min: 0.5, Max: 2},
+		Angle:    schemas.RangeData{Min: 0, Max: 360},
+		Lifetime: schemas.RangeData{Min: 60, Max: 120},
+		Gravity:  0.05,
+		Drag:     0.01,
+		Alpha:    []schemas.CurveKeyData{{T: 0, V: 1}, {T: 1, V: 0}},
+	}
+}
@@ -0,0 +1,221 @@
+package postfx
+
+import (
+	"embed"
+	"fmt"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+//go:embed shaders/*.kage
+var shaders embed.FS
+
+// DefaultPassNames are the names of the built-in passes, in the order they
+// run: the distortions first, then the color changes, and the screen
+// effects last.
+var DefaultPassNames = []string{"water", "palette", "chromatic", "crt", "vignette"}
+
+// DefaultPalette is the palette of the palette swap, from the darkest color
+// to the lightest, as premultiplied RGBA.
+var DefaultPalette = []float32{
+	0.06, 0.22, 0.06, 1,
+	0.19, 0.38, 0.19, 1,
+	0.55, 0.67, 0.06, 1,
+	0.61, 0.74, 0.06, 1,
+}
+
+// Pass is a Kage shader run over the whole screen.
+//
+// Besides its own uniforms, each pass gets the uniforms of the pipeline and:
+//   - Time, the seconds since the pipeline started
+//   - Intensity, 1 when enabled, or decaying from 1 to 0 while pulsed
+type Pass struct {
+	Name    string
+	Enabled bool
+	// Uniforms are the uniforms of this pass, e.g. the palette of a palette
+	// swap.
+	Uniforms map[string]any
+
+	shader      *ebiten.Shader
+	pulse       int // Frames left of a pulse
+	pulseFrames int
+}
+
+// NewPass compiles the Kage source of a pass.
+func NewPass(name string, src []byte) (*Pass, error) {
+	shader, err := ebiten.NewShader(src)
+	if err != nil {
+		return nil, fmt.Errorf("compiling %s pass: %w", name, err)
+	}
+	return &Pass{Name: name, shader: shader, Uniforms: map[string]any{}}, nil
+}
+
+// Active reports whether the pass runs this frame.
+func (p *Pass) Active() bool {
+	return p.Enabled || p.pulse > 0
+}
+
+// Intensity returns how strong the pass is, in [0, 1].
+func (p *Pass) Intensity() float64 {
+	if p.Enabled || p.pulseFrames == 0 {
+		return 1
+	}
+	return float64(p.pulse) / float64(p.pulseFrames)
+}
+
+// Pipeline runs an ordered chain of passes over what the scenes draw. Scenes
+// draw into the image returned by Target, and Apply draws it to the screen
+// through the active passes.
+type Pipeline struct {
+	passes   []*Pass
+	uniforms map[string]any
+	frames   int
+
+	active  []*Pass
+	buffers [2]*ebiten.Image
+}
+
+// NewPipeline returns a pipeline running the passes in order.
+func NewPipeline(passes ...*Pass) *Pipeline {
+	return &Pipeline{passes: passes, uniforms: map[string]any{}}
+}
+
+// NewDefaultPipeline returns a pipeline with the built-in passes, all
+// disabled.
+func NewDefaultPipeline() (*Pipeline, error) {
+	var passes []*Pass
+	for _, name := range DefaultPassNames {
+		src, err := shaders.ReadFile("shaders/" + name + ".kage")
+		if err != nil {
+			return nil, err
+		}
+		pass, err := NewPass(name, src)
+		if err != nil {
+			return nil, err
+		}
+		passes = append(passes, pass)
+	}
+	p := NewPipeline(passes...)
+	p.Pass("palette").Uniforms["Palette"] = DefaultPalette
+	return p, nil
+}
+
+// Pass returns the pass of the given name, or nil if there is none.
+func (p *Pipeline) Pass(name string) *Pass {
+	for _, pass := range p.passes {
+		if pass.Name == name {
+			return pass
+		}
+	}
+	return nil
+}
+
+// SetEnabled turns the pass of the given name on or off. It returns false if
+// there is no such pass.
+func (p *Pipeline) SetEnabled(name string, enabled bool) bool {
+	pass := p.Pass(name)
+	if pass == nil {
+		return false
+	}
+	pass.Enabled = enabled
+	return true
+}
+
+// Pulse runs the pass of the given name for a number of frames, fading out,
+// e.g. a chromatic aberration when the player is hurt. It returns false if
+// there is no such pass.
+func (p *Pipeline) Pulse(name string, frames int) bool {
+	pass := p.Pass(name)
+	if pass == nil {
+		return false
+	}
+	pass.pulse, pass.pulseFrames = frames, frames
+	return true
+}
+
+// SetUniform sets a uniform given to all passes, e.g. the position of the
+// player on screen.
+func (p *Pipeline) SetUniform(name string, value any) {
+	p.uniforms[name] = value
+}
+
+// Update advances the time and the pulses.
+func (p *Pipeline) Update() {
+	p.frames++
+	for _, pass := range p.passes {
+		if pass.pulse > 0 {
+			pass.pulse--
+		}
+	}
+}
+
+// Target returns the image the scenes draw to this frame, the screen itself
+// when no pass is active.
+func (p *Pipeline) Target(screen *ebiten.Image) *ebiten.Image {
+	p.active = p.active[:0]
+	for _, pass := range p.passes {
+		if pass.Active() {
+			p.active = append(p.active, pass)
+		}
+	}
+	if len(p.active) == 0 {
+		return screen
+	}
+
+	size := screen.Bounds().Size()
+	for i, b := range p.buffers {
+		if b == nil || b.Bounds().Size() != size {
+			if b != nil {
+				b.Deallocate()
+			}
+			p.buffers[i] = ebiten.NewImage(size.X, size.Y)
+		}
+	}
+	p.buffers[0].Clear()
+	return p.buffers[0]
+}
+
+// Apply draws the image returned by Target to the screen through the active
+// passes.
+func (p *Pipeline) Apply(screen *ebiten.Image) {
+	if len(p.active) == 0 {
+		return
+	}
+
+	src := p.buffers[0]
+	for i, pass := range p.active {
+		dst := screen
+		if i < len(p.active)-1 {
+			dst = p.buffers[(i+1)%2]
+			dst.Clear()
+		}
+
+		opts := &ebiten.DrawRectShaderOptions{Uniforms: p.uniformsOf(pass)}
+		opts.Images[0] = src
+		origin := dst.Bounds().Min
+		opts.GeoM.Translate(float64(origin.X), float64(origin.Y))
+		size := src.Bounds().Size()
+		dst.DrawRectShader(size.X, size.Y, pass.shader, opts)
+		src = dst
+	}
+}
+
+// uniformsOf returns the uniforms given to the pass.
+func (p *Pipeline) uniformsOf(pass *Pass) map[string]any {
+	u := make(map[string]any, len(p.uniforms)+len(pass.Uniforms)+2)
+	for k, v := range p.uniforms {
+		u[k] = v
+	}
+	for k, v := range pass.Uniforms {
+		u[k] = v
+	}
+	u["Time"] = float32(p.frames) / float32(ebiten.TPS())
+	u["Intensity"] = float32(pass.Intensity())
+	return u
+}
+
+// ScreenPoint converts a point to the value of a vec2 uniform.
+func ScreenPoint(pt image.Point) []float32 {
+	return []float32{float32(pt.X), float32(pt.Y)}
+}
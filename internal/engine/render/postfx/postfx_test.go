@@ -0,0 +1,47 @@
+package postfx
+
+import (
+	"testing"
+)
+
+func TestDefaultPassesCompile(t *testing.T) {
+	p, err := NewDefaultPipeline()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range DefaultPassNames {
+		if pass := p.Pass(name); pass == nil || pass.Active() {
+			t.Errorf("pass %s = %+v, want a disabled pass", name, pass)
+		}
+	}
+}
+
+func TestToggleAndPulse(t *testing.T) {
+	p, err := NewDefaultPipeline()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.SetEnabled("bloom", true) || p.Pulse("bloom", 10) {
+		t.Error("unknown passes should not be toggled")
+	}
+
+	if !p.SetEnabled("crt", true) || !p.Pass("crt").Active() {
+		t.Error("crt should be enabled")
+	}
+
+	chromatic := p.Pass("chromatic")
+	p.Pulse("chromatic", 4)
+	if !chromatic.Active() || chromatic.Intensity() != 1 {
+		t.Errorf("pulsed pass active %v, intensity %v", chromatic.Active(), chromatic.Intensity())
+	}
+	p.Update()
+	if chromatic.Intensity() != 0.75 {
+		t.Errorf("intensity = %v, want 0.75", chromatic.Intensity())
+	}
+	for range 3 {
+		p.Update()
+	}
+	if chromatic.Active() {
+		t.Error("pulse should be over")
+	}
+}
@@ -1,24 +1,43 @@
 package tilemap
 
 import (
+	"encoding/json"
 	"fmt"
+	"image"
 	_ "image/png"
 	"log"
 	"math"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/event"
 )
 
 type Tilemap struct {
-	Height       int        `json:"height"`
-	Width        int        `json:"width"`
-	Infinite     bool       `json:"infinite"`
-	Layers       []*Layer   `json:"layers"`
-	Tileheight   int        `json:"tileheight"`
-	Tilewidth    int        `json:"tilewidth"`
-	Tilesets     []*Tileset `json:"tilesets"`
-	image        *ebiten.Image
-	imageOptions *ebiten.DrawImageOptions
+	Height          int     `json:"height"`
+	Width           int     `json:"width"`
+	Infinite        bool    `json:"infinite"`
+	Orientation     string  `json:"orientation"`
+	Renderorder     string  `json:"renderorder"`
+	Backgroundcolor string  `json:"backgroundcolor,omitempty"`
+	Parallaxoriginx float64 `json:"parallaxoriginx,omitempty"`
+	Parallaxoriginy float64 `json:"parallaxoriginy,omitempty"`
+	// Layers are the tile, object and image layers in drawing order. The
+	// layers of groups are flattened into it when the map is loaded.
+	Layers     []*Layer   `json:"layers"`
+	Tileheight int        `json:"tileheight"`
+	Tilewidth  int        `json:"tilewidth"`
+	Tilesets   []*Tileset `json:"tilesets"`
+	Properties []Property `json:"properties,omitempty"`
+
+	// Ticks the tile animations have run for
+	tick int
+
+	// Kept in sync by SetTile
+	space                  body.BodiesSpace
+	endpointTriggerFactory func(id string) body.Touchable
+	events                 *event.Manager
 }
 
 type Property struct {
@@ -27,18 +46,104 @@ type Property struct {
 	Value string `json:"value"`
 }
 
+// UnmarshalJSON keeps Value as a string for int, float and bool properties.
+func (p *Property) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Name  string          `json:"name"`
+		Type  string          `json:"type"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	p.Name = raw.Name
+	p.Type = raw.Type
+	p.Value = ""
+	if len(raw.Value) > 0 {
+		var str string
+		if err := json.Unmarshal(raw.Value, &str); err == nil {
+			p.Value = str
+		} else {
+			p.Value = string(raw.Value)
+		}
+	}
+	return nil
+}
+
+func findProperty(props []Property, name string) (string, bool) {
+	for _, p := range props {
+		if p.Name == name {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// Property returns the value of the named property.
+func (o *Obstacle) Property(name string) (string, bool) {
+	return findProperty(o.Properties, name)
+}
+
+// Property returns the value of the named property of the map.
+func (t *Tilemap) Property(name string) (string, bool) {
+	return findProperty(t.Properties, name)
+}
+
+// Layer is a tile, object, image or group layer. Tile data can be plain,
+// or base64 encoded and compressed; it is decoded into Data either way.
 type Layer struct {
-	Data    []int       `json:"data"`
-	Height  int         `json:"height"`
-	Id      int         `json:"id"`
-	Name    string      `json:"name"`
-	Opacity int         `json:"opacity"`
-	Type    string      `json:"type"`
-	Visible bool        `json:"visible"`
-	Width   int         `json:"width"`
-	X       int         `json:"x"`
-	Y       int         `json:"y"`
-	Objects []*Obstacle `json:"objects"`
+	Data        []int       `json:"data"`
+	Encoding    string      `json:"encoding,omitempty"`
+	Compression string      `json:"compression,omitempty"`
+	Height      int         `json:"height"`
+	Id          int         `json:"id"`
+	Name        string      `json:"name"`
+	Class       string      `json:"class,omitempty"`
+	Opacity     float64     `json:"opacity"`
+	Type        string      `json:"type"`
+	Visible     bool        `json:"visible"`
+	Width       int         `json:"width"`
+	X           int         `json:"x"`
+	Y           int         `json:"y"`
+	Offsetx     float64     `json:"offsetx,omitempty"`
+	Offsety     float64     `json:"offsety,omitempty"`
+	Parallaxx   float64     `json:"parallaxx"`
+	Parallaxy   float64     `json:"parallaxy"`
+	Tintcolor   string      `json:"tintcolor,omitempty"`
+	Properties  []Property  `json:"properties,omitempty"`
+	Draworder   string      `json:"draworder,omitempty"`
+	Objects     []*Obstacle `json:"objects"`
+
+	// Image layers
+	Image            string `json:"image,omitempty"`
+	Imagewidth       int    `json:"imagewidth,omitempty"`
+	Imageheight      int    `json:"imageheight,omitempty"`
+	Repeatx          bool   `json:"repeatx,omitempty"`
+	Repeaty          bool   `json:"repeaty,omitempty"`
+	Transparentcolor string `json:"transparentcolor,omitempty"`
+
+	// Group layers
+	Layers []*Layer `json:"layers,omitempty"`
+
+	// Parent is the group the layer is in, nil at the top level.
+	Parent      *Layer        `json:"-"`
+	EbitenImage *ebiten.Image `json:"-"` // Image of image layers
+
+	// Baked chunks of tile layers, by chunk coordinates
+	chunks map[image.Point]*chunk
+	// Bodies of obstacle and endpoint tiles, by tile coordinates
+	bodies map[image.Point]*TileBody
+}
+
+// Property returns the value of the named property of the layer.
+func (l *Layer) Property(name string) (string, bool) {
+	return findProperty(l.Properties, name)
+}
+
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
 }
 
 type Obstacle struct {
@@ -46,18 +151,26 @@ type Obstacle struct {
 	Height     float64    `json:"height"`
 	Id         int        `json:"id"`
 	Name       string     `json:"name"`
+	Class      string     `json:"class,omitempty"`
 	Rotation   float64    `json:"rotation"`
 	Type       string     `json:"type"`
 	Visible    bool       `json:"visible"`
 	Width      float64    `json:"width"`
 	X          float64    `json:"x"`
 	Y          float64    `json:"y"`
+	Ellipse    bool       `json:"ellipse,omitempty"`
+	Point      bool       `json:"point,omitempty"`
+	Polygon    []Point    `json:"polygon,omitempty"`
+	Polyline   []Point    `json:"polyline,omitempty"`
 	Properties []Property `json:"properties"`
 }
 
+// Tileset is embedded in the map, or loaded from the external .tsj file in
+// Source.
 type Tileset struct {
 	Columns          int           `json:"columns"`
 	Firstgid         int           `json:"firstgid"`
+	Source           string        `json:"source,omitempty"`
 	Image            string        `json:"image"`
 	Imageheight      int           `json:"imageheight"`
 	Imagewidth       int           `json:"imagewidth"`
@@ -68,25 +181,11 @@ type Tileset struct {
 	Tileheight       int           `json:"tileheight"`
 	Tilewidth        int           `json:"tilewidth"`
 	Transparentcolor string        `json:"transparentcolor"`
+	Tiles            []*Tile       `json:"tiles,omitempty"`
 	EbitenImage      *ebiten.Image `json:"-"`
-}
 
-func (t *Tilemap) Image(screen *ebiten.Image) (*ebiten.Image, error) {
-	if t.image == nil {
-		img, err := t.ParseToImage(screen)
-		if err != nil {
-			return nil, err
-		}
-		t.image = img
-	}
-
-	t.Reset(screen)
-
-	return t.image, nil
-}
-
-func (t *Tilemap) ImageOptions() *ebiten.DrawImageOptions {
-	return t.imageOptions
+	// Animations by local tile ID
+	animations map[int]*animation
 }
 
 // GetPlayerStartPosition searches for a layer named "PlayerStart" in the tilemap's object layers.
@@ -133,6 +232,11 @@ func (t *Tilemap) GetItemsPositionID() []*ItemPosition {
 
 	itemCount := 0
 	for _, obj := range layer.Objects {
+		// Objects with a prefab are created by the prefab spawner
+		if _, ok := obj.Property("prefab"); ok {
+			continue
+		}
+
 		x16 := int(math.Round(obj.X))
 		yValue := obj.Y
 		if obj.Gid > 0 {
@@ -140,10 +244,11 @@ func (t *Tilemap) GetItemsPositionID() []*ItemPosition {
 		}
 		y16 := int(math.Round(yValue))
 		if firstgid == 0 {
-			firstgid = obj.Gid
+			firstgid, _ = SplitGid(obj.Gid)
 			ts = t.findTileset(firstgid)
 		}
-		itemType := tilesetSourceID(ts, obj.Gid)
+		gid, _ := SplitGid(obj.Gid)
+		itemType := tilesetSourceID(ts, gid)
 
 		var id string
 		for _, p := range obj.Properties {
@@ -164,105 +269,50 @@ func (t *Tilemap) GetItemsPositionID() []*ItemPosition {
 	return res
 }
 
-type EnemyPosition struct {
-	X, Y      int
-	EnemyType string
-	ID        string
+type PrefabPosition struct {
+	X, Y       int
+	Prefab     string
+	ID         string
+	Properties map[string]string
 }
 
-func (t *Tilemap) GetEnemiesPositionID() []*EnemyPosition {
+// GetPrefabPositions returns every object, on any object layer, that has a
+// "prefab" property. The remaining properties are returned as overrides.
+func (t *Tilemap) GetPrefabPositions() []*PrefabPosition {
 	if t == nil {
 		return nil
 	}
 
-	res := []*EnemyPosition{}
-
-	layer, found := t.FindLayerByName("Enemies")
-	if !found {
-		log.Printf("Enemies layer not found in tilemap")
-		return nil
-	}
-
-	enemyCount := 0
-	for _, obj := range layer.Objects {
-		x16 := int(math.Round(obj.X))
-		yValue := obj.Y
-		if obj.Gid > 0 {
-			yValue -= obj.Height
-		}
-		y16 := int(math.Round(yValue))
+	res := []*PrefabPosition{}
+	prefabCount := make(map[string]int)
 
-		var id, enemyType string
-		for _, p := range obj.Properties {
-			if p.Name == "body_id" {
-				id = p.Value
+	for _, layer := range t.Layers {
+		for _, obj := range layer.Objects {
+			prefab, ok := obj.Property("prefab")
+			if !ok || prefab == "" {
+				continue
 			}
-			if p.Name == "enemy_type" {
-				enemyType = p.Value
-			}
-		}
 
-		if id == "" {
-			if enemyType == "" {
-				enemyType = "UNKNOWN"
+			x16 := int(math.Round(obj.X))
+			yValue := obj.Y
+			if obj.Gid > 0 {
+				yValue -= obj.Height
 			}
-			id = fmt.Sprintf("%s_%d", enemyType, enemyCount)
-			enemyCount++
-		}
-
-		res = append(res, &EnemyPosition{X: x16, Y: y16, EnemyType: enemyType, ID: id})
-	}
-
-	return res
-}
-
-type NpcPosition struct {
-	X, Y    int
-	NpcType string
-	ID      string
-}
-
-func (t *Tilemap) GetNpcsPositionID() []*NpcPosition {
-	if t == nil {
-		return nil
-	}
+			y16 := int(math.Round(yValue))
 
-	res := []*NpcPosition{}
-
-	layer, found := t.FindLayerByName("NPCs") // Assumes a layer named "NPCs"
-	if !found {
-		log.Printf("NPCs layer not found in tilemap")
-		return nil
-	}
-
-	npcCount := 0
-	for _, obj := range layer.Objects {
-		x16 := int(math.Round(obj.X))
-		yValue := obj.Y
-		if obj.Gid > 0 {
-			yValue -= obj.Height
-		}
-		y16 := int(math.Round(yValue))
-
-		var id, npcType string
-		for _, p := range obj.Properties {
-			if p.Name == "body_id" {
-				id = p.Value
-			}
-			if p.Name == "npc_type" {
-				npcType = p.Value
+			props := make(map[string]string, len(obj.Properties))
+			for _, p := range obj.Properties {
+				props[p.Name] = p.Value
 			}
-		}
 
-		if id == "" {
-			if npcType == "" {
-				npcType = "UNKNOWN"
+			id := props["body_id"]
+			if id == "" {
+				id = fmt.Sprintf("%s_%d", strings.ToUpper(prefab), prefabCount[prefab])
+				prefabCount[prefab]++
 			}
-			id = fmt.Sprintf("%s_%d", npcType, npcCount)
-			npcCount++
-		}
 
-		res = append(res, &NpcPosition{X: x16, Y: y16, NpcType: npcType, ID: id})
+			res = append(res, &PrefabPosition{X: x16, Y: y16, Prefab: prefab, ID: id, Properties: props})
+		}
 	}
 
 	return res
@@ -0,0 +1,33 @@
+package tilemap
+
+import (
+	"image"
+
+	"github.com/leandroatallah/firefly/internal/engine/render/camera"
+)
+
+// CameraZones returns the rectangles of the "Camera" object layer as camera
+// zones, their properties overriding the rig of the camera.
+func (t *Tilemap) CameraZones() []camera.Zone {
+	layer, found := t.FindLayerByName("Camera")
+	if !found {
+		return nil
+	}
+
+	var zones []camera.Zone
+	for _, obj := range layer.Objects {
+		if obj.Point || len(obj.Polygon) > 0 || len(obj.Polyline) > 0 {
+			continue
+		}
+		z := camera.Zone{
+			Name:       obj.Name,
+			Area:       image.Rect(int(obj.X), int(obj.Y), int(obj.X+obj.Width), int(obj.Y+obj.Height)),
+			Properties: make(map[string]string, len(obj.Properties)),
+		}
+		for _, p := range obj.Properties {
+			z.Properties[p.Name] = p.Value
+		}
+		zones = append(zones, z)
+	}
+	return zones
+}
@@ -0,0 +1,202 @@
+package tilemap
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/render/camera"
+)
+
+// ChunkSize is the width and height, in tiles, of the chunks tile layers are
+// baked into.
+const ChunkSize = 16
+
+// chunk is a baked part of a tile layer. Chunks without static tiles have no
+// image. Animated tiles are not baked, but drawn over the chunk every frame.
+type chunk struct {
+	image    *ebiten.Image
+	dirty    bool
+	animated []image.Point // Tiles, relative to the chunk
+}
+
+// chunkTiles returns the tiles covered by a chunk, cut at the layer edges.
+func (l *Layer) chunkTiles(c image.Point) image.Rectangle {
+	r := image.Rect(c.X*ChunkSize, c.Y*ChunkSize, (c.X+1)*ChunkSize, (c.Y+1)*ChunkSize)
+	return r.Intersect(image.Rect(0, 0, l.Width, l.Height))
+}
+
+// chunk returns a chunk of a layer, baking it if it is new or was
+// invalidated.
+func (t *Tilemap) chunk(l *Layer, c image.Point) *chunk {
+	if l.chunks == nil {
+		l.chunks = make(map[image.Point]*chunk)
+	}
+	ch, ok := l.chunks[c]
+	if !ok {
+		ch = &chunk{dirty: true}
+		l.chunks[c] = ch
+	}
+	if ch.dirty {
+		t.bakeChunk(l, c, ch)
+	}
+	return ch
+}
+
+func (t *Tilemap) bakeChunk(l *Layer, c image.Point, ch *chunk) {
+	ch.dirty = false
+	ch.animated = ch.animated[:0]
+	if ch.image != nil {
+		ch.image.Clear()
+	}
+
+	tiles := l.chunkTiles(c)
+	for y := tiles.Min.Y; y < tiles.Max.Y; y++ {
+		for x := tiles.Min.X; x < tiles.Max.X; x++ {
+			i := y*l.Width + x
+			if i >= len(l.Data) || l.Data[i] == 0 {
+				continue
+			}
+			if gid, _ := SplitGid(l.Data[i]); t.isAnimated(gid) {
+				ch.animated = append(ch.animated, image.Pt(x-tiles.Min.X, y-tiles.Min.Y))
+				continue
+			}
+			tile, op := t.tileImage(l.Data[i])
+			if tile == nil {
+				continue
+			}
+
+			if ch.image == nil {
+				ch.image = ebiten.NewImage(tiles.Dx()*t.Tilewidth, tiles.Dy()*t.Tileheight)
+			}
+			op.GeoM.Translate(float64((x-tiles.Min.X)*t.Tilewidth), float64((y-tiles.Min.Y)*t.Tileheight))
+			ch.image.DrawImage(tile, op)
+		}
+	}
+}
+
+// Draw draws the visible tile and image layers seen by the camera, with
+// their offset, parallax, tint and opacity. Tile layers are drawn in chunks,
+// baked the first time they are seen and again after being invalidated.
+func (t *Tilemap) Draw(screen *ebiten.Image, cam *camera.Controller) {
+	view := cam.VisibleRect()
+	cx, cy := cam.Kamera().Center()
+	for _, l := range t.Layers {
+		if !l.IsVisible() {
+			continue
+		}
+		ox, oy := t.layerOrigin(l, cx, cy)
+		switch l.Type {
+		case "tilelayer":
+			t.drawTileLayer(screen, cam, l, view, ox, oy)
+		case "imagelayer":
+			drawImageLayer(screen, cam, l, view, ox, oy)
+		}
+	}
+}
+
+// drawTileLayer draws the chunks of a layer whose origin is at ox, oy that
+// overlap view.
+func (t *Tilemap) drawTileLayer(screen *ebiten.Image, cam *camera.Controller, l *Layer, view image.Rectangle, ox, oy float64) {
+	if t.Tilewidth <= 0 || t.Tileheight <= 0 {
+		return
+	}
+
+	// The view in layer coordinates
+	view = view.Sub(image.Pt(int(math.Floor(ox)), int(math.Floor(oy))))
+	view.Max = view.Max.Add(image.Pt(1, 1))
+
+	cw, chh := ChunkSize*t.Tilewidth, ChunkSize*t.Tileheight
+	minX, minY := max(view.Min.X, 0)/cw, max(view.Min.Y, 0)/chh
+	maxX := min((view.Max.X+cw-1)/cw, (l.Width+ChunkSize-1)/ChunkSize)
+	maxY := min((view.Max.Y+chh-1)/chh, (l.Height+ChunkSize-1)/ChunkSize)
+
+	cs := l.colorScale()
+	for y := minY; y < maxY; y++ {
+		for x := minX; x < maxX; x++ {
+			ch := t.chunk(l, image.Pt(x, y))
+			cx, cy := float64(x*cw)+ox, float64(y*chh)+oy
+			if ch.image != nil {
+				op := &ebiten.DrawImageOptions{}
+				op.GeoM.Translate(cx, cy)
+				op.ColorScale = cs
+				cam.Draw(ch.image, op, screen)
+			}
+			t.drawAnimatedTiles(screen, cam, l, ch, image.Pt(x, y), cx, cy, cs)
+		}
+	}
+}
+
+// drawAnimatedTiles draws the current frame of the animated tiles of a chunk
+// drawn at cx, cy.
+func (t *Tilemap) drawAnimatedTiles(screen *ebiten.Image, cam *camera.Controller, l *Layer, ch *chunk, c image.Point, cx, cy float64, cs ebiten.ColorScale) {
+	for _, p := range ch.animated {
+		x, y := c.X*ChunkSize+p.X, c.Y*ChunkSize+p.Y
+		tile, op := t.tileImage(l.Data[y*l.Width+x])
+		if tile == nil {
+			continue
+		}
+		op.GeoM.Translate(cx+float64(p.X*t.Tilewidth), cy+float64(p.Y*t.Tileheight))
+		op.ColorScale = cs
+		cam.Draw(tile, op, screen)
+	}
+}
+
+// drawImageLayer draws the image of a layer at ox, oy, repeated over view
+// along the axes the layer repeats on.
+func drawImageLayer(screen *ebiten.Image, cam *camera.Controller, l *Layer, view image.Rectangle, ox, oy float64) {
+	if l.EbitenImage == nil {
+		return
+	}
+	w, h := float64(l.EbitenImage.Bounds().Dx()), float64(l.EbitenImage.Bounds().Dy())
+	if w <= 0 || h <= 0 {
+		return
+	}
+
+	startX, endX := ox, ox+w
+	if l.Repeatx {
+		startX = ox + math.Floor((float64(view.Min.X)-ox)/w)*w
+		endX = float64(view.Max.X)
+	}
+	startY, endY := oy, oy+h
+	if l.Repeaty {
+		startY = oy + math.Floor((float64(view.Min.Y)-oy)/h)*h
+		endY = float64(view.Max.Y)
+	}
+
+	cs := l.colorScale()
+	for y := startY; y < endY; y += h {
+		for x := startX; x < endX; x += w {
+			op := &ebiten.DrawImageOptions{}
+			op.GeoM.Translate(x, y)
+			op.ColorScale = cs
+			cam.Draw(l.EbitenImage, op, screen)
+		}
+	}
+}
+
+// InvalidateTile rebakes the chunks of the tile at x, y, in tiles, the next
+// time they are drawn.
+func (t *Tilemap) InvalidateTile(x, y int) {
+	for _, l := range t.Layers {
+		l.invalidateTile(x, y)
+	}
+}
+
+func (l *Layer) invalidateTile(x, y int) {
+	if x < 0 || y < 0 {
+		return
+	}
+	if ch, ok := l.chunks[image.Pt(x/ChunkSize, y/ChunkSize)]; ok {
+		ch.dirty = true
+	}
+}
+
+// Invalidate rebakes every chunk, e.g. after a tileset image changes.
+func (t *Tilemap) Invalidate() {
+	for _, l := range t.Layers {
+		for _, ch := range l.chunks {
+			ch.dirty = true
+		}
+	}
+}
@@ -2,7 +2,9 @@ package tilemap
 
 import (
 	"fmt"
+	"image"
 	"log"
+	"math"
 
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
@@ -26,41 +28,26 @@ var LayerNameMap = map[string]LayerNameID{
 	"Endpoint":    EndpointLayer,
 }
 
+// CreateCollisionBodies adds the obstacles and endpoints of the map to the
+// space. The space is kept in sync with tiles changed by SetTile.
 func (t *Tilemap) CreateCollisionBodies(space body.BodiesSpace, endpointTriggerFactory func(id string) body.Touchable) {
+	t.space = space
+	t.endpointTriggerFactory = endpointTriggerFactory
+
 	foundEndpoint := false
 	foundObstacles := false
 
 	eventCount := 0
 	for _, layer := range t.Layers {
-		if !layer.Visible {
+		layer.bodies = nil
+		if !layer.IsVisible() {
 			continue
 		}
 
 		if layer.Name == "Endpoint" {
 			foundEndpoint = true
 			if layer.Type == "tilelayer" {
-				for i, tileID := range layer.Data {
-					if tileID == 0 {
-						continue
-					}
-
-					// Calculate the x and y coordinates of the tile
-					x := (i % layer.Width) * t.Tilewidth
-					y := (i / layer.Width) * t.Tileheight
-
-					rect := bodyphysics.NewRect(x, y, t.Tilewidth, t.Tileheight)
-					obstacle := bodyphysics.NewObstacleRect(rect)
-					obstacle.SetPosition(x, y)
-					// Generate a unique ID for the obstacle based on its position
-					id := fmt.Sprintf("ENDPOINT_%d_%d", x, y)
-					obstacle.SetID(id)
-					obstacle.AddCollisionBodies()
-					obstacle.SetIsObstructive(false)
-					if endpointTriggerFactory != nil {
-						obstacle.SetTouchable(endpointTriggerFactory(id))
-					}
-					space.AddBody(obstacle)
-				}
+				t.addTileBodies(layer, image.Rect(0, 0, layer.Width, layer.Height))
 			} else {
 				for _, obj := range layer.Objects {
 					obstacle := t.NewObstacleRect(obj, "Endpoint", false)
@@ -87,24 +74,7 @@ func (t *Tilemap) CreateCollisionBodies(space body.BodiesSpace, endpointTriggerF
 		if layer.Name == "Obstacles" {
 			foundObstacles = true
 			if layer.Type == "tilelayer" {
-				for i, tileID := range layer.Data {
-					if tileID == 0 {
-						continue
-					}
-
-					// Calculate the x and y coordinates of the tile
-					x := (i % layer.Width) * t.Tilewidth
-					y := (i / layer.Width) * t.Tileheight
-
-					rect := bodyphysics.NewRect(x, y, t.Tilewidth, t.Tileheight)
-					obstacle := bodyphysics.NewObstacleRect(rect)
-					obstacle.SetPosition(x, y)
-					// Generate a unique ID for the obstacle based on its position
-					obstacle.SetID(fmt.Sprintf("OBSTACLE_%d_%d", x, y))
-					obstacle.AddCollisionBodies()
-					obstacle.SetIsObstructive(true)
-					space.AddBody(obstacle)
-				}
+				t.addTileBodies(layer, image.Rect(0, 0, layer.Width, layer.Height))
 			} else {
 				for _, obj := range layer.Objects {
 					obstacle := t.NewObstacleRect(obj, "OBSTACLE", true)
@@ -123,6 +93,172 @@ func (t *Tilemap) CreateCollisionBodies(space body.BodiesSpace, endpointTriggerF
 	}
 }
 
+// addTileBodies adds the bodies of the tiles of a layer within area, in
+// tiles, that have no body yet. Neighbour obstacle tiles are merged into the
+// fewest rectangles with a greedy pass: a run of tiles is grown to the right,
+// then down while the rows below match it.
+func (t *Tilemap) addTileBodies(layer *Layer, area image.Rectangle) {
+	area = area.Intersect(image.Rect(0, 0, layer.Width, layer.Height))
+	if layer.bodies == nil {
+		layer.bodies = make(map[image.Point]*TileBody)
+	}
+	free := func(x, y int) bool {
+		_, ok := layer.bodies[image.Pt(x, y)]
+		return !ok
+	}
+
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			if !free(x, y) {
+				continue
+			}
+			tiles := image.Rect(x, y, x+1, y+1)
+			if key, ok := t.mergeKey(layer, x, y); ok {
+				matches := func(x, y int) bool {
+					k, ok := t.mergeKey(layer, x, y)
+					return ok && k == key && free(x, y)
+				}
+				for tiles.Max.X < area.Max.X && matches(tiles.Max.X, y) {
+					tiles.Max.X++
+				}
+			grow:
+				for tiles.Max.Y < area.Max.Y {
+					for tx := tiles.Min.X; tx < tiles.Max.X; tx++ {
+						if !matches(tx, tiles.Max.Y) {
+							break grow
+						}
+					}
+					tiles.Max.Y++
+				}
+			}
+
+			b := t.newTileBody(layer, tiles)
+			if b == nil {
+				continue
+			}
+			for ty := tiles.Min.Y; ty < tiles.Max.Y; ty++ {
+				for tx := tiles.Min.X; tx < tiles.Max.X; tx++ {
+					layer.bodies[image.Pt(tx, ty)] = b
+				}
+			}
+			t.space.AddBody(b)
+		}
+	}
+}
+
+// removeTileBody removes the body of the tile at x, y (in tiles) from the
+// space, and returns the tiles it covered.
+func (t *Tilemap) removeTileBody(layer *Layer, x, y int) image.Rectangle {
+	b, ok := layer.bodies[image.Pt(x, y)]
+	if !ok {
+		return image.Rectangle{}
+	}
+	t.space.RemoveBody(b)
+	for ty := b.tiles.Min.Y; ty < b.tiles.Max.Y; ty++ {
+		for tx := b.tiles.Min.X; tx < b.tiles.Max.X; tx++ {
+			delete(layer.bodies, image.Pt(tx, ty))
+		}
+	}
+	return b.tiles
+}
+
+// surfaceKey holds the properties tiles must share to be merged.
+type surfaceKey struct {
+	hazard   int
+	friction float64
+	oneWay   bool
+	sound    string
+}
+
+// mergeKey returns the surface properties of the tile at x, y (in tiles), and
+// whether it can be merged with its neighbours. Only obstacle tiles that
+// collide with their whole area are merged.
+func (t *Tilemap) mergeKey(layer *Layer, x, y int) (surfaceKey, bool) {
+	if layer.Name != "Obstacles" {
+		return surfaceKey{}, false
+	}
+	i, ok := layer.tileIndex(x, y)
+	if !ok || layer.Data[i] == 0 {
+		return surfaceKey{}, false
+	}
+	gid := layer.Data[i]
+	if tile := t.findTile(gid); tile != nil && tile.Objectgroup != nil {
+		return surfaceKey{}, false
+	}
+	p := t.TileProperties(gid)
+	return surfaceKey{p.Hazard, p.Friction, p.OneWay, p.Sound}, true
+}
+
+// tileOrigin returns the top left corner of the tile at x, y (in tiles) in
+// the world, moved by the offset of the layer like the tile is drawn.
+func (t *Tilemap) tileOrigin(layer *Layer, x, y int) image.Point {
+	ox, oy := layer.TotalOffset()
+	return image.Pt(x*t.Tilewidth+int(math.Round(ox)), y*t.Tileheight+int(math.Round(oy)))
+}
+
+// newTileBody creates the body of the tiles of the obstacles or the endpoint
+// layer, in tiles, with the properties of the top left one. A single tile
+// gets the collision shapes of its tileset tile, merged tiles collide with
+// their whole area. It returns nil for other layers, empty tiles and tiles
+// without collision shapes.
+func (t *Tilemap) newTileBody(layer *Layer, tiles image.Rectangle) *TileBody {
+	var prefix string
+	switch layer.Name {
+	case "Obstacles":
+		prefix = "OBSTACLE"
+	case "Endpoint":
+		prefix = "ENDPOINT"
+	default:
+		return nil
+	}
+
+	i, ok := layer.tileIndex(tiles.Min.X, tiles.Min.Y)
+	if !ok || layer.Data[i] == 0 {
+		return nil
+	}
+	gid := layer.Data[i]
+	shapes := []image.Rectangle{image.Rect(0, 0, tiles.Dx()*t.Tilewidth, tiles.Dy()*t.Tileheight)}
+	if tiles.Dx() == 1 && tiles.Dy() == 1 {
+		shapes = t.tileShapes(gid)
+	}
+	if len(shapes) == 0 {
+		return nil
+	}
+
+	// Calculate the x and y coordinates of the top left tile
+	origin := t.tileOrigin(layer, tiles.Min.X, tiles.Min.Y)
+	px, py := origin.X, origin.Y
+
+	// The body covers all the shapes of the tiles
+	bounds := shapes[0]
+	for _, s := range shapes[1:] {
+		bounds = bounds.Union(s)
+	}
+	bounds = bounds.Add(image.Pt(px, py))
+
+	rect := bodyphysics.NewRect(bounds.Min.X, bounds.Min.Y, bounds.Dx(), bounds.Dy())
+	obstacle := bodyphysics.NewObstacleRect(rect)
+	obstacle.SetPosition(bounds.Min.X, bounds.Min.Y)
+	// Generate a unique ID for the obstacle based on its top left tile
+	id := fmt.Sprintf("%s_%d_%d", prefix, px, py)
+	obstacle.SetID(id)
+
+	collisions := make([]body.Collidable, 0, len(shapes))
+	for _, s := range shapes {
+		s = s.Add(image.Pt(px, py))
+		c := bodyphysics.NewCollidableBodyFromRect(bodyphysics.NewRect(s.Min.X, s.Min.Y, s.Dx(), s.Dy()))
+		c.SetPosition(s.Min.X, s.Min.Y)
+		collisions = append(collisions, c)
+	}
+	obstacle.AddCollisionBodies(collisions...)
+
+	obstacle.SetIsObstructive(prefix == "OBSTACLE")
+	if prefix == "ENDPOINT" && t.endpointTriggerFactory != nil {
+		obstacle.SetTouchable(t.endpointTriggerFactory(id))
+	}
+	return &TileBody{ObstacleRect: obstacle, tiles: tiles, properties: t.TileProperties(gid)}
+}
+
 func (t *Tilemap) NewObstacleRect(obj *Obstacle, prefix string, isObstructive bool) *bodyphysics.ObstacleRect {
 	y := int(obj.Y)
 
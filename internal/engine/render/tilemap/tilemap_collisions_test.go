@@ -0,0 +1,172 @@
+package tilemap
+
+import (
+	"image"
+	"path/filepath"
+	"testing"
+
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/physics/space"
+)
+
+func phaseMaps(tb testing.TB) []string {
+	tb.Helper()
+	paths, err := filepath.Glob("../../../../assets/tilemap/shepherd-phase-*.tmj")
+	if err != nil || len(paths) == 0 {
+		tb.Fatalf("no phase maps found: %v", err)
+	}
+	return paths
+}
+
+func loadPhase(tb testing.TB, path string) *Tilemap {
+	tb.Helper()
+	tm, err := LoadTilemap(path)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return tm
+}
+
+// perTileSpace adds a body per obstacle and endpoint tile, as done before
+// tiles were merged.
+func perTileSpace(tm *Tilemap) body.BodiesSpace {
+	s := space.NewSpace()
+	for _, l := range tm.Layers {
+		if !l.IsVisible() || l.Type != "tilelayer" {
+			continue
+		}
+		for y := 0; y < l.Height; y++ {
+			for x := 0; x < l.Width; x++ {
+				if b := tm.newTileBody(l, image.Rect(x, y, x+1, y+1)); b != nil {
+					s.AddBody(b)
+				}
+			}
+		}
+	}
+	return s
+}
+
+// pixelCollision is what a pixel of the map collides with.
+type pixelCollision struct {
+	bodies      int
+	obstructive bool
+	surface     surfaceKey
+}
+
+// collisionGrid rasterizes the collision shapes of the tile bodies of a
+// space.
+func collisionGrid(tm *Tilemap, s body.BodiesSpace) []pixelCollision {
+	w, h := tm.Width*tm.Tilewidth, tm.Height*tm.Tileheight
+	grid := make([]pixelCollision, w*h)
+	for _, b := range s.Bodies() {
+		tb, ok := b.(*TileBody)
+		if !ok {
+			continue
+		}
+		p := tb.Properties()
+		for _, r := range b.CollisionPosition() {
+			r = r.Intersect(image.Rect(0, 0, w, h))
+			for y := r.Min.Y; y < r.Max.Y; y++ {
+				for x := r.Min.X; x < r.Max.X; x++ {
+					c := &grid[y*w+x]
+					c.bodies++
+					c.obstructive = b.IsObstructive()
+					c.surface = surfaceKey{p.Hazard, p.Friction, p.OneWay, p.Sound}
+				}
+			}
+		}
+	}
+	return grid
+}
+
+func TestMergedBodiesMatchTiles(t *testing.T) {
+	for _, path := range phaseMaps(t) {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			tm := loadPhase(t, path)
+			merged := space.NewSpace()
+			tm.CreateCollisionBodies(merged, nil)
+			perTile := perTileSpace(tm)
+
+			if n, m := len(merged.Bodies()), len(perTile.Bodies()); n >= m {
+				t.Errorf("%d bodies after merging, not fewer than the %d tiles", n, m)
+			} else {
+				t.Logf("%d tile bodies merged into %d", m, n)
+			}
+
+			want, got := collisionGrid(tm, perTile), collisionGrid(tm, merged)
+			w := tm.Width * tm.Tilewidth
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("pixel %d,%d collides with %+v, want %+v", i%w, i/w, got[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSetTileKeepsMergedBodiesInSync(t *testing.T) {
+	tm := loadPhase(t, phaseMaps(t)[0])
+	merged := space.NewSpace()
+	tm.CreateCollisionBodies(merged, nil)
+
+	// Dig a hole in every merged body and fill it back
+	var holes []image.Point
+	for _, b := range merged.Bodies() {
+		if tb, ok := b.(*TileBody); ok && tb.Tiles().Dx()*tb.Tiles().Dy() > 1 {
+			tiles := tb.Tiles()
+			holes = append(holes, tiles.Min.Add(image.Pt(tiles.Dx()/2, tiles.Dy()/2)))
+		}
+	}
+	if len(holes) == 0 {
+		t.Fatal("no merged bodies")
+	}
+	for _, p := range holes {
+		gid, _ := tm.GetTile("Obstacles", p.X, p.Y)
+		if err := tm.SetTile("Obstacles", p.X, p.Y, 0); err != nil {
+			t.Fatal(err)
+		}
+		if err := tm.SetTile("Obstacles", p.X, p.Y, gid); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, got := collisionGrid(tm, perTileSpace(tm)), collisionGrid(tm, merged)
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("pixel %d collides with %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func BenchmarkCollisionBodies(b *testing.B) {
+	tm := loadPhase(b, phaseMaps(b)[0])
+	spaces := map[string]func() body.BodiesSpace{
+		"merged": func() body.BodiesSpace {
+			s := space.NewSpace()
+			tm.CreateCollisionBodies(s, nil)
+			return s
+		},
+		"per-tile": func() body.BodiesSpace {
+			return perTileSpace(tm)
+		},
+	}
+
+	for _, name := range []string{"merged", "per-tile"} {
+		build := spaces[name]
+		b.Run("build/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				build()
+			}
+		})
+
+		s := build()
+		w, h := tm.Width*tm.Tilewidth, tm.Height*tm.Tileheight
+		b.Run("query/"+name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				// A player sized rect sweeping the map
+				x, y := (i*7)%w, (i*13)%h
+				s.Query(image.Rect(x, y, x+12, y+16))
+			}
+		})
+	}
+}
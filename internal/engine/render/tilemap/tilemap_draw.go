@@ -13,18 +13,21 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
+// ParseToImage bakes the visible tile layers into one image the size of the
+// map, with the current frame of the animated tiles. Big maps can exceed the
+// texture size limit; scenes draw the map with Draw, which bakes it in
+// chunks.
 func (t *Tilemap) ParseToImage(screen *ebiten.Image) (*ebiten.Image, error) {
 	if _, err := t.isTilemapValid(); err != nil {
 		return nil, err
 	}
 
-	// Use the first layer to determine map dimensions. This assumes all layers are the same size.
-	mapWidth := t.Layers[0].Width * t.Tilewidth
-	mapHeight := t.Layers[0].Height * t.Tileheight
+	mapWidth := t.Width * t.Tilewidth
+	mapHeight := t.Height * t.Tileheight
 	result := ebiten.NewImage(mapWidth, mapHeight)
 
 	for _, layer := range t.Layers {
-		if !layer.Visible {
+		if !layer.IsVisible() {
 			continue
 		}
 
@@ -33,9 +36,6 @@ func (t *Tilemap) ParseToImage(screen *ebiten.Image) (*ebiten.Image, error) {
 		}
 	}
 
-	// Reset to sync camera
-	t.Reset(screen)
-
 	return result, nil
 }
 
@@ -91,52 +91,44 @@ func (t *Tilemap) ParseBase(layer *Layer, result *ebiten.Image) {
 		return
 	}
 
+	ox, oy := layer.TotalOffset()
+	cs := layer.colorScale()
 	for i, tileID := range layer.Data {
 		if tileID == 0 {
 			continue
 		}
 
-		ts := t.findTileset(tileID)
-		if ts == nil || ts.EbitenImage == nil {
+		tile, op := t.tileImage(tileID)
+		if tile == nil {
 			continue
 		}
-
-		srcRect := tilesetSourceRect(ts, tileID)
-
-		tile := ts.EbitenImage.SubImage(srcRect).(*ebiten.Image)
-		op := drawTileOpts(i, layer.Width, ts.Tilewidth, ts.Tileheight)
+		grid := drawTileOpts(i, layer.Width, t.Tilewidth, t.Tileheight)
+		op.GeoM.Concat(grid.GeoM)
+		op.GeoM.Translate(ox, oy)
+		op.ColorScale = cs
 		result.DrawImage(tile, op)
 	}
 }
 
 func (t *Tilemap) ParseItems(layer *Layer, result *ebiten.Image) {
-	if layer == nil || result == nil || !layer.Visible {
+	if layer == nil || result == nil || !layer.IsVisible() {
 		return
 	}
 
 	for _, obj := range layer.Objects {
-		gid := obj.Gid
-		if gid == 0 {
+		if obj.Gid == 0 {
 			continue
 		}
 
-		ts := t.findTileset(gid)
-		if ts == nil || ts.EbitenImage == nil {
+		tileImg, op := t.tileImage(obj.Gid)
+		if tileImg == nil {
 			continue
 		}
-
-		srcRect := tilesetSourceRect(ts, gid)
-		tileImg := ts.EbitenImage.SubImage(srcRect).(*ebiten.Image)
-		op := &ebiten.DrawImageOptions{}
 		op.GeoM.Translate(obj.X, obj.Y-obj.Height)
 		result.DrawImage(tileImg, op)
 	}
 }
 
-func (t *Tilemap) Reset(screen *ebiten.Image) {
-	t.imageOptions.GeoM.Reset()
-}
-
 func LoadTilemap(path string) (*Tilemap, error) {
 	jsonFile, err := os.Open(path)
 	if err != nil {
@@ -153,11 +145,20 @@ func LoadTilemap(path string) (*Tilemap, error) {
 	if err := json.Unmarshal(byteValue, &tilemap); err != nil {
 		return nil, err
 	}
-	tilemap.imageOptions = &ebiten.DrawImageOptions{}
+	tilemap.flattenLayers()
 
-	// After loading the tilemap structure, load the associated tileset images.
+	// After loading the tilemap structure, load the external tilesets and
+	// the associated tileset images.
+	dir := filepath.Dir(path)
 	for _, ts := range tilemap.Tilesets {
-		imagePath := filepath.Join(filepath.Dir(path), ts.Image)
+		if ts.Source != "" {
+			if err := loadExternalTileset(ts, dir); err != nil {
+				return nil, err
+			}
+		}
+		ts.indexAnimations()
+
+		imagePath := filepath.Join(dir, ts.Image)
 		img, err := loadImage(imagePath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load tileset image %s: %w", imagePath, err)
@@ -165,6 +166,19 @@ func LoadTilemap(path string) (*Tilemap, error) {
 		ts.EbitenImage = img
 	}
 
+	// And the images of the image layers
+	for _, l := range tilemap.Layers {
+		if l.Type != "imagelayer" || l.Image == "" {
+			continue
+		}
+		imagePath := filepath.Join(dir, l.Image)
+		img, err := loadImage(imagePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load layer image %s: %w", imagePath, err)
+		}
+		l.EbitenImage = img
+	}
+
 	return &tilemap, nil
 }
 
@@ -202,7 +216,7 @@ func (t *Tilemap) FindLayerByName(name string) (*Layer, bool) {
 	}
 
 	for _, layer := range t.Layers {
-		if !layer.Visible {
+		if !layer.IsVisible() {
 			continue
 		}
 
@@ -0,0 +1,113 @@
+package tilemap
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/leandroatallah/firefly/internal/engine/event"
+)
+
+const TileChangedEventType = "tile_changed"
+
+// TileChangedEvent is published by SetTile. X and Y are in tiles; Gid is zero
+// when the tile was removed.
+type TileChangedEvent struct {
+	Layer    string
+	X, Y     int
+	Previous int
+	Gid      int
+	Rect     image.Rectangle // Area of the tile in the world
+}
+
+func (e *TileChangedEvent) Type() string {
+	return TileChangedEventType
+}
+
+// Position returns the area of the tile, so the change can be captioned.
+func (e *TileChangedEvent) Position() image.Rectangle {
+	return e.Rect
+}
+
+// SetEventManager sets where tile changes are published.
+func (t *Tilemap) SetEventManager(events *event.Manager) {
+	t.events = events
+}
+
+// tileLayer returns the tile layer with the given name, hidden or not.
+func (t *Tilemap) tileLayer(name string) (*Layer, bool) {
+	for _, layer := range t.Layers {
+		if layer.Type == "tilelayer" && layer.Name == name {
+			return layer, true
+		}
+	}
+	return nil, false
+}
+
+func (l *Layer) tileIndex(x, y int) (int, bool) {
+	if x < 0 || y < 0 || x >= l.Width || y >= l.Height {
+		return 0, false
+	}
+	i := y*l.Width + x
+	return i, i < len(l.Data)
+}
+
+// GetTile returns the gid of the tile at x, y (in tiles) of a tile layer,
+// with its flip flags, zero for an empty tile. It returns false for unknown layers and positions
+// outside of the map.
+func (t *Tilemap) GetTile(layerName string, x, y int) (int, bool) {
+	layer, ok := t.tileLayer(layerName)
+	if !ok {
+		return 0, false
+	}
+	i, ok := layer.tileIndex(x, y)
+	if !ok {
+		return 0, false
+	}
+	return layer.Data[i], true
+}
+
+// SetTile places the tile gid at x, y (in tiles) of a tile layer, or removes
+// it when gid is zero. The gid can have flip flags. The chunk of the tile is
+// rebaked, the obstacle or endpoint body it was part of is rebuilt in the
+// space, and a TileChangedEvent is published.
+func (t *Tilemap) SetTile(layerName string, x, y, gid int) error {
+	layer, ok := t.tileLayer(layerName)
+	if !ok {
+		return fmt.Errorf("tile layer not found: %s", layerName)
+	}
+	i, ok := layer.tileIndex(x, y)
+	if !ok {
+		return fmt.Errorf("tile %d,%d is outside of layer %s", x, y, layerName)
+	}
+	if id, _ := SplitGid(gid); gid < 0 || (gid > 0 && t.findTileset(id) == nil) {
+		return fmt.Errorf("no tileset for gid %d", gid)
+	}
+
+	previous := layer.Data[i]
+	if previous == gid {
+		return nil
+	}
+	layer.Data[i] = gid
+	layer.invalidateTile(x, y)
+
+	// Bodies are only created for visible layers. The body the tile was
+	// part of is rebuilt with the tile changed.
+	if t.space != nil && layer.IsVisible() {
+		area := t.removeTileBody(layer, x, y).Union(image.Rect(x, y, x+1, y+1))
+		t.addTileBodies(layer, area)
+	}
+
+	if t.events != nil {
+		origin := t.tileOrigin(layer, x, y)
+		px, py := origin.X, origin.Y
+		t.events.Publish(&TileChangedEvent{
+			Layer:    layerName,
+			X:        x,
+			Y:        y,
+			Previous: previous,
+			Gid:      gid,
+			Rect:     image.Rect(px, py, px+t.Tilewidth, py+t.Tileheight),
+		})
+	}
+	return nil
+}
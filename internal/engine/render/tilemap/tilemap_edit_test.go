@@ -0,0 +1,99 @@
+package tilemap
+
+import (
+	"image"
+	"testing"
+
+	"github.com/leandroatallah/firefly/internal/engine/event"
+	"github.com/leandroatallah/firefly/internal/engine/physics/space"
+)
+
+func newEditableTilemap() *Tilemap {
+	return &Tilemap{
+		Width:      3,
+		Height:     2,
+		Tilewidth:  16,
+		Tileheight: 16,
+		Tilesets:   []*Tileset{{Firstgid: 1, Columns: 4, Tilecount: 8, Tilewidth: 16, Tileheight: 16}},
+		Layers: []*Layer{{
+			Name:    "Obstacles",
+			Type:    "tilelayer",
+			Visible: true,
+			Width:   3,
+			Height:  2,
+			Data:    []int{0, 0, 0, 1, 1, 1},
+		}},
+	}
+}
+
+func TestSetTileSyncsSpace(t *testing.T) {
+	tm := newEditableTilemap()
+	s := space.NewSpace()
+	tm.CreateCollisionBodies(s, nil)
+	events := event.NewManager()
+	tm.SetEventManager(events)
+
+	var changes []*TileChangedEvent
+	events.Subscribe(TileChangedEventType, func(e event.Event) {
+		changes = append(changes, e.(*TileChangedEvent))
+	})
+
+	// The row of blocks is merged into a body
+	if n := len(s.Bodies()); n != 1 {
+		t.Fatalf("got %d bodies, want 1", n)
+	}
+
+	// Break a block and build one above it
+	if err := tm.SetTile("Obstacles", 1, 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := tm.SetTile("Obstacles", 1, 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	if gid, _ := tm.GetTile("Obstacles", 1, 0); gid != 2 {
+		t.Errorf("GetTile = %d, want 2", gid)
+	}
+
+	ids := make(map[string]bool)
+	for _, b := range s.Bodies() {
+		ids[b.ID()] = true
+	}
+	if ids["OBSTACLE_16_16"] || !ids["OBSTACLE_16_0"] || len(ids) != 3 {
+		t.Errorf("unexpected bodies after SetTile: %v", ids)
+	}
+
+	if len(changes) != 2 || changes[0].Previous != 1 || changes[0].Gid != 0 || changes[1].Y != 0 {
+		t.Errorf("unexpected events: %+v", changes)
+	}
+
+	if err := tm.SetTile("Obstacles", 3, 0, 1); err == nil {
+		t.Error("SetTile outside of the layer should fail")
+	}
+	if err := tm.SetTile("Missing", 0, 0, 1); err == nil {
+		t.Error("SetTile on a missing layer should fail")
+	}
+}
+
+func TestLayerOffsetMovesBodies(t *testing.T) {
+	tm := newEditableTilemap()
+	tm.Layers[0].Offsetx, tm.Layers[0].Offsety = 4, -8
+	s := space.NewSpace()
+	tm.CreateCollisionBodies(s, nil)
+	events := event.NewManager()
+	tm.SetEventManager(events)
+
+	var changed *TileChangedEvent
+	events.Subscribe(TileChangedEventType, func(e event.Event) {
+		changed = e.(*TileChangedEvent)
+	})
+
+	if bodies := s.Bodies(); len(bodies) != 1 || bodies[0].Position().Min != image.Pt(4, 8) {
+		t.Errorf("bodies = %v, want the row moved by the layer offset", bodies)
+	}
+	if err := tm.SetTile("Obstacles", 0, 0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if want := image.Rect(4, -8, 20, 8); changed == nil || changed.Rect != want {
+		t.Errorf("changed = %+v, want the tile at %v", changed, want)
+	}
+}
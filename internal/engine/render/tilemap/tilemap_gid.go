@@ -0,0 +1,62 @@
+package tilemap
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// TileFlags are the flip flags Tiled stores in the high bits of a gid.
+type TileFlags uint32
+
+const (
+	FlipHorizontal TileFlags = 1 << 31
+	FlipVertical   TileFlags = 1 << 30
+	// FlipDiagonal swaps the x and y axes. Combined with the other flags it
+	// rotates the tile by 90 degree steps.
+	FlipDiagonal TileFlags = 1 << 29
+	// RotateHex120 only applies to hexagonal maps.
+	RotateHex120 TileFlags = 1 << 28
+
+	gidFlags = FlipHorizontal | FlipVertical | FlipDiagonal | RotateHex120
+)
+
+// SplitGid separates the gid of a tile from its flip flags.
+func SplitGid(raw int) (int, TileFlags) {
+	v := uint32(raw)
+	return int(v &^ uint32(gidFlags)), TileFlags(v) & gidFlags
+}
+
+// flipGeoM returns the transform that flips a w by h tile in place, applying
+// the flags in the order Tiled does: diagonally first, then horizontally and
+// vertically.
+func flipGeoM(flags TileFlags, w, h float64) ebiten.GeoM {
+	var g ebiten.GeoM
+	if flags&FlipDiagonal != 0 {
+		g.SetElement(0, 0, 0)
+		g.SetElement(0, 1, 1)
+		g.SetElement(1, 0, 1)
+		g.SetElement(1, 1, 0)
+		w, h = h, w
+	}
+	if flags&FlipHorizontal != 0 {
+		g.Scale(-1, 1)
+		g.Translate(w, 0)
+	}
+	if flags&FlipVertical != 0 {
+		g.Scale(1, -1)
+		g.Translate(0, h)
+	}
+	return g
+}
+
+// tileImage returns the image of a gid, flip flags included, and the options
+// to draw it flipped in place. Animated tiles return their current frame. It
+// returns nil for empty tiles and gids without a tileset.
+func (t *Tilemap) tileImage(raw int) (*ebiten.Image, *ebiten.DrawImageOptions) {
+	gid, flags := SplitGid(raw)
+	gid = t.currentGid(gid)
+	ts := t.findTileset(gid)
+	if ts == nil || ts.EbitenImage == nil {
+		return nil, nil
+	}
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM = flipGeoM(flags, float64(ts.Tilewidth), float64(ts.Tileheight))
+	return ts.EbitenImage.SubImage(tilesetSourceRect(ts, gid)).(*ebiten.Image), op
+}
@@ -0,0 +1,200 @@
+package tilemap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func (l *Layer) UnmarshalJSON(data []byte) error {
+	type layerJSON Layer
+	raw := struct {
+		*layerJSON
+		Data json.RawMessage `json:"data"`
+	}{layerJSON: (*layerJSON)(l)}
+
+	// Tiled leaves out the properties that have their default value
+	l.Opacity, l.Visible = 1, true
+	l.Parallaxx, l.Parallaxy = 1, 1
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	d, err := decodeLayerData(raw.Data, l.Encoding, l.Compression)
+	if err != nil {
+		return fmt.Errorf("failed to decode layer %s: %w", l.Name, err)
+	}
+	l.Data = d
+	return nil
+}
+
+// decodeLayerData decodes tile data stored as a JSON array, or as base64
+// little-endian gids, optionally compressed with gzip or zlib.
+func decodeLayerData(raw json.RawMessage, encoding, compression string) ([]int, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	if encoding != "base64" {
+		var d []int
+		err := json.Unmarshal(raw, &d)
+		return d, err
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, err
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.Reader = bytes.NewReader(b)
+	switch compression {
+	case "":
+	case "gzip":
+		if r, err = gzip.NewReader(r); err != nil {
+			return nil, err
+		}
+	case "zlib":
+		if r, err = zlib.NewReader(r); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported compression: %s", compression)
+	}
+	if b, err = io.ReadAll(r); err != nil {
+		return nil, err
+	}
+	if len(b)%4 != 0 {
+		return nil, fmt.Errorf("tile data is %d bytes, not a multiple of 4", len(b))
+	}
+
+	d := make([]int, len(b)/4)
+	for i := range d {
+		d[i] = int(binary.LittleEndian.Uint32(b[i*4:]))
+	}
+	return d, nil
+}
+
+// flattenLayers replaces the group layers by the layers in them, in drawing
+// order. The layers keep their group as Parent.
+func (t *Tilemap) flattenLayers() {
+	var flat []*Layer
+	var walk func(layers []*Layer, parent *Layer)
+	walk = func(layers []*Layer, parent *Layer) {
+		for _, l := range layers {
+			l.Parent = parent
+			if l.Type == "group" {
+				walk(l.Layers, l)
+				continue
+			}
+			flat = append(flat, l)
+		}
+	}
+	walk(t.Layers, nil)
+	t.Layers = flat
+}
+
+// IsVisible reports whether the layer and every group it is in are visible.
+func (l *Layer) IsVisible() bool {
+	for g := l; g != nil; g = g.Parent {
+		if !g.Visible {
+			return false
+		}
+	}
+	return true
+}
+
+// TotalOpacity returns the opacity of the layer multiplied by its groups'.
+func (l *Layer) TotalOpacity() float64 {
+	o := 1.0
+	for g := l; g != nil; g = g.Parent {
+		o *= g.Opacity
+	}
+	return o
+}
+
+// TotalOffset returns the offset of the layer added to its groups'.
+func (l *Layer) TotalOffset() (float64, float64) {
+	var x, y float64
+	for g := l; g != nil; g = g.Parent {
+		x += g.Offsetx
+		y += g.Offsety
+	}
+	return x, y
+}
+
+// TotalParallax returns the parallax factors of the layer multiplied by its
+// groups'.
+func (l *Layer) TotalParallax() (float64, float64) {
+	x, y := 1.0, 1.0
+	for g := l; g != nil; g = g.Parent {
+		x *= g.Parallaxx
+		y *= g.Parallaxy
+	}
+	return x, y
+}
+
+// TotalTint returns the tint color of the layer multiplied by its groups',
+// and false when none of them is tinted.
+func (l *Layer) TotalTint() (color.NRGBA, bool) {
+	tint := color.NRGBA{0xff, 0xff, 0xff, 0xff}
+	tinted := false
+	for g := l; g != nil; g = g.Parent {
+		c, ok := ParseColor(g.Tintcolor)
+		if !ok {
+			continue
+		}
+		tinted = true
+		tint.R = uint8(uint16(tint.R) * uint16(c.R) / 0xff)
+		tint.G = uint8(uint16(tint.G) * uint16(c.G) / 0xff)
+		tint.B = uint8(uint16(tint.B) * uint16(c.B) / 0xff)
+		tint.A = uint8(uint16(tint.A) * uint16(c.A) / 0xff)
+	}
+	return tint, tinted
+}
+
+// colorScale returns the tint and the opacity of the layer as a color scale.
+func (l *Layer) colorScale() ebiten.ColorScale {
+	var cs ebiten.ColorScale
+	if tint, ok := l.TotalTint(); ok {
+		cs.ScaleWithColor(tint)
+	}
+	cs.ScaleAlpha(float32(l.TotalOpacity()))
+	return cs
+}
+
+// layerOrigin returns where the origin of a layer is drawn in the world when
+// the camera is centered at cx, cy: its offset, plus how far its parallax
+// moves it from the parallax origin of the map.
+func (t *Tilemap) layerOrigin(l *Layer, cx, cy float64) (float64, float64) {
+	ox, oy := l.TotalOffset()
+	px, py := l.TotalParallax()
+	return ox + (cx-t.Parallaxoriginx)*(1-px), oy + (cy-t.Parallaxoriginy)*(1-py)
+}
+
+// ParseColor parses a Tiled color, "#RRGGBB" or "#AARRGGBB".
+func ParseColor(s string) (color.NRGBA, bool) {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "#"))
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	switch len(b) {
+	case 3:
+		return color.NRGBA{b[0], b[1], b[2], 0xff}, true
+	case 4:
+		return color.NRGBA{b[1], b[2], b[3], b[0]}, true
+	}
+	return color.NRGBA{}, false
+}
@@ -0,0 +1,80 @@
+package tilemap
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/leandroatallah/firefly/internal/engine/render/lighting"
+)
+
+// defaultLightRadius is the radius of point objects of the "Lights" layer
+// without a radius property.
+const defaultLightRadius = 48
+
+// AmbientLight returns the "ambient_light" color property of the map, the
+// light of the phase away from any light.
+func (t *Tilemap) AmbientLight() (color.NRGBA, bool) {
+	v, ok := t.Property("ambient_light")
+	if !ok {
+		return color.NRGBA{}, false
+	}
+	return ParseColor(v)
+}
+
+// Lights returns the objects of the "Lights" object layer as lights, centered
+// on the objects. Their properties are:
+//   - radius, half the largest side of the object by default
+//   - color, white by default
+//   - intensity, 1 by default
+//   - direction and angle, in degrees, making a cone light when angle is set
+//   - flicker, in [0, 1]
+func (t *Tilemap) Lights() []*lighting.Light {
+	layer, found := t.FindLayerByName("Lights")
+	if !found {
+		return nil
+	}
+
+	var lights []*lighting.Light
+	for _, obj := range layer.Objects {
+		x, y := obj.X, obj.Y
+		if obj.Gid > 0 {
+			y -= obj.Height
+		}
+		x, y = x+obj.Width/2, y+obj.Height/2
+
+		radius := math.Max(obj.Width, obj.Height) / 2
+		if radius == 0 {
+			radius = defaultLightRadius
+		}
+		if v, err := strconv.ParseFloat(propertyOf(obj, "radius"), 64); err == nil {
+			radius = v
+		}
+
+		l := lighting.NewPointLight(x, y, radius)
+		if c, ok := ParseColor(propertyOf(obj, "color")); ok {
+			l.Color = c
+		}
+		if v, err := strconv.ParseFloat(propertyOf(obj, "intensity"), 64); err == nil {
+			l.Intensity = v
+		}
+		if v, err := strconv.ParseFloat(propertyOf(obj, "direction"), 64); err == nil {
+			l.Direction = v * math.Pi / 180
+		}
+		if v, err := strconv.ParseFloat(propertyOf(obj, "angle"), 64); err == nil {
+			l.Angle = v * math.Pi / 180
+		}
+		if v, err := strconv.ParseFloat(propertyOf(obj, "flicker"), 64); err == nil {
+			l.Flicker = v
+		}
+		lights = append(lights, l)
+	}
+	return lights
+}
+
+// propertyOf returns the value of the named property of the object, empty
+// if it has none.
+func propertyOf(obj *Obstacle, name string) string {
+	v, _ := obj.Property(name)
+	return v
+}
@@ -0,0 +1,39 @@
+package tilemap
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestLights(t *testing.T) {
+	tm, err := LoadTilemap("testdata/night.tmj")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c, ok := tm.AmbientLight(); !ok || c != (color.NRGBA{0x20, 0x20, 0x40, 0xff}) {
+		t.Errorf("ambient light = %v, %v", c, ok)
+	}
+
+	lights := tm.Lights()
+	if len(lights) != 2 {
+		t.Fatalf("%d lights, want 2", len(lights))
+	}
+
+	fire := lights[0]
+	if fire.X != 32 || fire.Y != 32 || fire.Radius != 32 || fire.IsCone() {
+		t.Errorf("campfire at %v, %v, radius %v", fire.X, fire.Y, fire.Radius)
+	}
+	if fire.Color != (color.NRGBA{0xff, 0x80, 0x40, 0xff}) || fire.Flicker != 0.3 {
+		t.Errorf("campfire color %v, flicker %v", fire.Color, fire.Flicker)
+	}
+
+	lamp := lights[1]
+	if lamp.X != 48 || lamp.Y != 16 || lamp.Radius != defaultLightRadius || lamp.Intensity != 0.5 {
+		t.Errorf("lamp = %+v", lamp)
+	}
+	if !lamp.IsCone() || math.Abs(lamp.Direction-math.Pi/2) > 1e-9 || math.Abs(lamp.Angle-math.Pi/3) > 1e-9 {
+		t.Errorf("lamp cone direction %v, angle %v", lamp.Direction, lamp.Angle)
+	}
+}
@@ -0,0 +1,182 @@
+package tilemap
+
+import (
+	"image"
+	"math"
+	"sort"
+	"strconv"
+
+	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
+)
+
+// TileProperties are the properties of a tileset tile the engine acts on.
+type TileProperties struct {
+	Hazard   int     // Damage dealt to bodies touching the tile
+	Friction float64 // Ground friction multiplier, 1 by default
+	OneWay   bool    // Only blocks bodies coming from above
+	Sound    string  // Sound of stepping on the tile
+	// Properties holds all the properties of the tile, custom ones included.
+	Properties []Property
+}
+
+// TileProperties returns the properties of the tile gid. Flip flags are
+// ignored. Tiles without properties return the defaults.
+func (t *Tilemap) TileProperties(gid int) TileProperties {
+	props := TileProperties{Friction: 1}
+	tile := t.findTile(gid)
+	if tile == nil {
+		return props
+	}
+
+	props.Properties = tile.Properties
+	if v, ok := findProperty(tile.Properties, "hazard"); ok {
+		props.Hazard, _ = strconv.Atoi(v)
+	}
+	if v, ok := findProperty(tile.Properties, "friction"); ok {
+		if f, err := strconv.ParseFloat(v, 64); err == nil && f >= 0 {
+			props.Friction = f
+		}
+	}
+	if v, ok := findProperty(tile.Properties, "one_way"); ok {
+		props.OneWay, _ = strconv.ParseBool(v)
+	}
+	props.Sound, _ = findProperty(tile.Properties, "sound")
+	return props
+}
+
+// findTile returns the tileset data of the tile gid, which can have flip
+// flags, or nil if its tileset has none.
+func (t *Tilemap) findTile(raw int) *Tile {
+	gid, _ := SplitGid(raw)
+	ts := t.findTileset(gid)
+	if ts == nil {
+		return nil
+	}
+	tile, _ := ts.Tile(gid - ts.Firstgid)
+	return tile
+}
+
+// tileShapes returns the collision rects of the tile gid, relative to the
+// tile and flipped with it. Tiles without an object group collide with their
+// whole area. Rectangles and ellipses collide with their bounds, and convex
+// polygons, like slopes, with 1 pixel wide columns. Points and polylines are
+// ignored.
+func (t *Tilemap) tileShapes(raw int) []image.Rectangle {
+	gid, flags := SplitGid(raw)
+	tw, th := t.Tilewidth, t.Tileheight
+	if ts := t.findTileset(gid); ts != nil && ts.Tilewidth > 0 && ts.Tileheight > 0 {
+		tw, th = ts.Tilewidth, ts.Tileheight
+	}
+	bounds := image.Rect(0, 0, tw, th)
+
+	tile := t.findTile(gid)
+	if tile == nil || tile.Objectgroup == nil {
+		return []image.Rectangle{bounds}
+	}
+
+	var rects []image.Rectangle
+	for _, obj := range tile.Objectgroup.Objects {
+		switch {
+		case obj.Point || len(obj.Polyline) > 0:
+			continue
+		case len(obj.Polygon) > 0:
+			rects = append(rects, polygonColumns(obj)...)
+		default:
+			rects = append(rects, image.Rect(
+				round(obj.X), round(obj.Y),
+				round(obj.X+obj.Width), round(obj.Y+obj.Height),
+			))
+		}
+	}
+
+	g := flipGeoM(flags, float64(tw), float64(th))
+	shapes := rects[:0]
+	for _, r := range rects {
+		x0, y0 := g.Apply(float64(r.Min.X), float64(r.Min.Y))
+		x1, y1 := g.Apply(float64(r.Max.X), float64(r.Max.Y))
+		r = image.Rect(round(x0), round(y0), round(x1), round(y1)).Intersect(bounds)
+		if !r.Empty() {
+			shapes = append(shapes, r)
+		}
+	}
+	return shapes
+}
+
+// polygonColumns approximates a convex polygon object with a rect for each
+// pixel column it covers, merging neighbour columns of the same height.
+func polygonColumns(obj *Obstacle) []image.Rectangle {
+	minX, maxX := math.Inf(1), math.Inf(-1)
+	for _, p := range obj.Polygon {
+		minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+	}
+
+	var rects []image.Rectangle
+	for x := int(math.Floor(minX)); x < int(math.Ceil(maxX)); x++ {
+		// Where the vertical line through the middle of the column crosses
+		// the edges of the polygon
+		cx := float64(x) + 0.5
+		var ys []float64
+		for i, a := range obj.Polygon {
+			b := obj.Polygon[(i+1)%len(obj.Polygon)]
+			if (a.X <= cx) == (b.X <= cx) {
+				continue
+			}
+			ys = append(ys, a.Y+(cx-a.X)*(b.Y-a.Y)/(b.X-a.X))
+		}
+		if len(ys) < 2 {
+			continue
+		}
+		sort.Float64s(ys)
+
+		ox, oy := round(obj.X), obj.Y
+		top := int(math.Floor(oy + ys[0]))
+		bottom := int(math.Ceil(oy + ys[len(ys)-1]))
+		col := image.Rect(ox+x, top, ox+x+1, bottom)
+		if col.Empty() {
+			continue
+		}
+		if n := len(rects); n > 0 && rects[n-1].Max.X == col.Min.X &&
+			rects[n-1].Min.Y == col.Min.Y && rects[n-1].Max.Y == col.Max.Y {
+			rects[n-1].Max.X = col.Max.X
+			continue
+		}
+		rects = append(rects, col)
+	}
+	return rects
+}
+
+func round(v float64) int {
+	return int(math.Round(v))
+}
+
+// TileBody is the body of an obstacle or endpoint tile, or of neighbour
+// obstacle tiles merged together. It is a body.Surface with the properties of
+// its tiles.
+type TileBody struct {
+	*bodyphysics.ObstacleRect
+
+	tiles      image.Rectangle // Covered tiles
+	properties TileProperties
+}
+
+// Tiles returns the tiles covered by the body.
+func (b *TileBody) Tiles() image.Rectangle {
+	return b.tiles
+}
+
+// Properties returns the properties of the tiles of the body.
+func (b *TileBody) Properties() TileProperties {
+	return b.properties
+}
+
+func (b *TileBody) Friction() float64 {
+	return b.properties.Friction
+}
+
+func (b *TileBody) Hazard() int {
+	return b.properties.Hazard
+}
+
+func (b *TileBody) OneWay() bool {
+	return b.properties.OneWay
+}
@@ -0,0 +1,127 @@
+package tilemap
+
+import (
+	"image"
+	"testing"
+
+	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
+	"github.com/leandroatallah/firefly/internal/engine/physics/space"
+)
+
+func loadSurfaces(t *testing.T) *Tilemap {
+	t.Helper()
+	tm, err := LoadTilemap("testdata/surfaces.tmj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tm
+}
+
+func TestTileProperties(t *testing.T) {
+	tm := loadSurfaces(t)
+
+	if p := tm.TileProperties(1); p.Friction != 1 || p.Hazard != 0 || p.OneWay || p.Sound != "" {
+		t.Errorf("tile without properties = %+v", p)
+	}
+	if p := tm.TileProperties(3); !p.OneWay {
+		t.Error("tile 3 should be one way")
+	}
+	if p := tm.TileProperties(4); p.Hazard != 2 {
+		t.Errorf("hazard = %d, want 2", p.Hazard)
+	}
+	p := tm.TileProperties(5 | int(FlipVertical))
+	if p.Friction != 0.25 || p.Sound != "ice" || len(p.Properties) != 2 {
+		t.Errorf("flipped ice tile = %+v", p)
+	}
+}
+
+func TestTileShapes(t *testing.T) {
+	tm := loadSurfaces(t)
+
+	if s := tm.tileShapes(1); len(s) != 1 || s[0] != image.Rect(0, 8, 16, 16) {
+		t.Errorf("half tile shapes = %v", s)
+	}
+	if s := tm.tileShapes(4); len(s) != 1 || s[0] != image.Rect(0, 0, 16, 16) {
+		t.Errorf("tile without object group shapes = %v", s)
+	}
+
+	// The slope rises to the right, a pixel per column
+	slope := tm.tileShapes(2)
+	if len(slope) != 16 || slope[0] != image.Rect(0, 15, 1, 16) || slope[15] != image.Rect(15, 0, 16, 16) {
+		t.Errorf("slope shapes = %v", slope)
+	}
+	flipped := tm.tileShapes(2 | int(FlipHorizontal))
+	if len(flipped) != 16 || flipped[0] != image.Rect(15, 15, 16, 16) || flipped[15] != image.Rect(0, 0, 1, 16) {
+		t.Errorf("flipped slope shapes = %v", flipped)
+	}
+}
+
+func TestTileBodies(t *testing.T) {
+	tm := loadSurfaces(t)
+	s := space.NewSpace()
+	tm.CreateCollisionBodies(s, nil)
+
+	find := func(id string) *TileBody {
+		t.Helper()
+		for _, b := range s.Bodies() {
+			if b.ID() == id {
+				return b.(*TileBody)
+			}
+		}
+		t.Fatalf("body %s not found", id)
+		return nil
+	}
+
+	half := find("OBSTACLE_0_0")
+	if half.Position() != image.Rect(0, 8, 16, 16) {
+		t.Errorf("half tile body at %v", half.Position())
+	}
+	if ice := find("OBSTACLE_80_0"); ice.Friction() != 0.25 {
+		t.Errorf("ice friction = %v", ice.Friction())
+	}
+
+	// Replacing the half tile with a full one changes its shape
+	if err := tm.SetTile("Obstacles", 0, 0, 4); err != nil {
+		t.Fatal(err)
+	}
+	if full := find("OBSTACLE_0_0"); full.Position() != image.Rect(0, 0, 16, 16) || full.Hazard() != 2 {
+		t.Errorf("replaced body at %v, hazard %d", full.Position(), full.Hazard())
+	}
+}
+
+func TestOneWayTileBlocksFromAbove(t *testing.T) {
+	tm := loadSurfaces(t)
+	s := space.NewSpace()
+	tm.CreateCollisionBodies(s, nil)
+
+	var ledge *TileBody
+	for _, b := range s.Bodies() {
+		if b.ID() == "OBSTACLE_48_0" {
+			ledge = b.(*TileBody)
+		}
+	}
+
+	newMover := func(y int) *bodyphysics.ObstacleRect {
+		m := bodyphysics.NewObstacleRect(bodyphysics.NewRect(48, y, 8, 8))
+		m.SetID("mover")
+		m.SetPosition(48, y)
+		m.AddCollisionBodies()
+		return m
+	}
+
+	// Feet 1 pixel into the ledge, landing on it
+	if !bodyphysics.Blocks(newMover(-7), ledge) {
+		t.Error("ledge should block a body landing on it")
+	}
+
+	// Deeper in, e.g. jumping through it from below
+	if bodyphysics.Blocks(newMover(-4), ledge) {
+		t.Error("ledge should not block a body inside it")
+	}
+
+	going := newMover(-7)
+	going.SetVelocity(0, -1)
+	if bodyphysics.Blocks(going, ledge) {
+		t.Error("ledge should not block a body going up")
+	}
+}
@@ -0,0 +1,126 @@
+package tilemap
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/leandroatallah/firefly/internal/engine/utils/timing"
+)
+
+// Tile holds the data Tiled stores per tile of a tileset.
+type Tile struct {
+	Id         int        `json:"id"`
+	Type       string     `json:"type,omitempty"`
+	Animation  []Frame    `json:"animation,omitempty"`
+	Properties []Property `json:"properties,omitempty"`
+	// Objectgroup holds the collision shapes of the tile, relative to it.
+	Objectgroup *Layer `json:"objectgroup,omitempty"`
+}
+
+// Frame is a frame of a tile animation. Duration is in milliseconds.
+type Frame struct {
+	Tileid   int `json:"tileid"`
+	Duration int `json:"duration"`
+}
+
+type animation struct {
+	frames []Frame
+	total  int // Milliseconds
+}
+
+// loadExternalTileset reads the .tsj file of a tileset relative to dir, and
+// keeps the first gid the map gives it. Image paths in it are made relative
+// to the map.
+func loadExternalTileset(ts *Tileset, dir string) error {
+	if strings.EqualFold(filepath.Ext(ts.Source), ".tsx") {
+		return fmt.Errorf("tileset %s: XML tilesets are not supported, export it as .tsj", ts.Source)
+	}
+
+	path := filepath.Join(dir, ts.Source)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	firstgid, source := ts.Firstgid, ts.Source
+	if err := json.Unmarshal(data, ts); err != nil {
+		return fmt.Errorf("failed to load tileset %s: %w", path, err)
+	}
+	ts.Firstgid, ts.Source = firstgid, source
+	if ts.Image != "" {
+		ts.Image = filepath.Join(filepath.Dir(source), ts.Image)
+	}
+	return nil
+}
+
+// indexAnimations collects the animated tiles of the tileset.
+func (ts *Tileset) indexAnimations() {
+	ts.animations = nil
+	for _, tile := range ts.Tiles {
+		a := &animation{frames: tile.Animation}
+		for _, f := range tile.Animation {
+			a.total += f.Duration
+		}
+		if a.total <= 0 {
+			continue
+		}
+		if ts.animations == nil {
+			ts.animations = make(map[int]*animation)
+		}
+		ts.animations[tile.Id] = a
+	}
+}
+
+// frame returns the local tile ID shown elapsed into the animation.
+func (a *animation) frame(elapsed time.Duration) int {
+	ms := int(elapsed.Milliseconds() % int64(a.total))
+	for _, f := range a.frames {
+		if ms < f.Duration {
+			return f.Tileid
+		}
+		ms -= f.Duration
+	}
+	return a.frames[len(a.frames)-1].Tileid
+}
+
+// Tile returns the data of a tile by its local ID.
+func (ts *Tileset) Tile(id int) (*Tile, bool) {
+	for _, tile := range ts.Tiles {
+		if tile.Id == id {
+			return tile, true
+		}
+	}
+	return nil, false
+}
+
+// Update advances the tile animations by one tick.
+func (t *Tilemap) Update() {
+	t.tick++
+}
+
+// isAnimated reports whether the tile gid, without flip flags, is animated.
+func (t *Tilemap) isAnimated(gid int) bool {
+	ts := t.findTileset(gid)
+	if ts == nil {
+		return false
+	}
+	_, ok := ts.animations[gid-ts.Firstgid]
+	return ok
+}
+
+// currentGid returns the gid of the frame an animated tile shows at the
+// current tick, or gid itself for static tiles.
+func (t *Tilemap) currentGid(gid int) int {
+	ts := t.findTileset(gid)
+	if ts == nil {
+		return gid
+	}
+	a, ok := ts.animations[gid-ts.Firstgid]
+	if !ok {
+		return gid
+	}
+	return ts.Firstgid + a.frame(timing.ToDuration(t.tick))
+}
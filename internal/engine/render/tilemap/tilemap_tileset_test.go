@@ -0,0 +1,69 @@
+package tilemap
+
+import (
+	"image"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/leandroatallah/firefly/internal/engine/utils/timing"
+)
+
+func TestLoadExternalTileset(t *testing.T) {
+	tm, err := LoadTilemap("testdata/animated.tmj")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	water := tm.Tilesets[1]
+	if water.Firstgid != 3 || water.Name != "water" || water.EbitenImage == nil {
+		t.Fatalf("unexpected external tileset: %+v", water)
+	}
+	if tile, ok := water.Tile(0); !ok || len(tile.Animation) != 2 {
+		t.Errorf("tile 0 = %+v", tile)
+	}
+}
+
+func TestAnimatedTiles(t *testing.T) {
+	tm, err := LoadTilemap("testdata/animated.tmj")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Frames of 100ms and 250ms, looping every 350ms
+	tests := []struct {
+		elapsed time.Duration
+		gid     int
+	}{
+		{0, 3},
+		{50 * time.Millisecond, 3},
+		{100 * time.Millisecond, 4},
+		{300 * time.Millisecond, 4},
+		{350 * time.Millisecond, 3},
+	}
+	for _, tt := range tests {
+		tm.tick = timing.FromDuration(tt.elapsed)
+		if gid := tm.currentGid(3); gid != tt.gid {
+			t.Errorf("after %v: gid %d, want %d", tt.elapsed, gid, tt.gid)
+		}
+	}
+	if tm.currentGid(1) != 1 {
+		t.Error("static tiles should not change")
+	}
+
+	// Animated tiles, flipped or not, are left out of the baked chunk
+	ch := tm.chunk(tm.Layers[0], image.Pt(0, 0))
+	if want := []image.Point{{2, 0}, {3, 0}}; !slices.Equal(ch.animated, want) {
+		t.Errorf("animated tiles = %v, want %v", ch.animated, want)
+	}
+	if ch.image == nil {
+		t.Error("static tiles should be baked")
+	}
+}
+
+func TestLoadXMLTilesetFails(t *testing.T) {
+	ts := &Tileset{Firstgid: 1, Source: "tiles.tsx"}
+	if err := loadExternalTileset(ts, "testdata"); err == nil {
+		t.Error("expected an error for .tsx tilesets")
+	}
+}
@@ -0,0 +1,178 @@
+package tilemap
+
+import (
+	"image/color"
+	"math"
+	"slices"
+	"testing"
+)
+
+func loadFixture(t *testing.T) *Tilemap {
+	t.Helper()
+	tm, err := LoadTilemap("testdata/features.tmj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tm
+}
+
+func layerByName(t *testing.T, tm *Tilemap, name string) *Layer {
+	t.Helper()
+	for _, l := range tm.Layers {
+		if l.Name == name {
+			return l
+		}
+	}
+	t.Fatalf("layer %s not found", name)
+	return nil
+}
+
+func TestLoadFlattensGroups(t *testing.T) {
+	tm := loadFixture(t)
+
+	var names []string
+	for _, l := range tm.Layers {
+		names = append(names, l.Name)
+	}
+	want := []string{"Sky", "Decor", "Secret", "Obstacles", "Ground", "Items"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("layers = %v, want %v", names, want)
+	}
+
+	decor := layerByName(t, tm, "Decor")
+	if decor.Parent == nil || decor.Parent.Name != "Foreground" {
+		t.Errorf("Decor parent = %v, want Foreground", decor.Parent)
+	}
+	if v, _ := decor.Parent.Property("layer_kind"); v != "decor" {
+		t.Errorf("group property = %q", v)
+	}
+	if v, _ := decor.Property("sway"); v != "1.5" {
+		t.Errorf("layer property = %q", v)
+	}
+
+	secret := layerByName(t, tm, "Secret")
+	if !secret.Visible || secret.IsVisible() {
+		t.Error("Secret should be hidden by its group")
+	}
+	if _, found := tm.FindLayerByName("Secret"); found {
+		t.Error("FindLayerByName should skip layers in hidden groups")
+	}
+}
+
+func TestLoadLayerAttributes(t *testing.T) {
+	tm := loadFixture(t)
+
+	sky := layerByName(t, tm, "Sky")
+	if sky.Opacity != 0.6 || !sky.Repeatx || sky.Repeaty || sky.EbitenImage == nil {
+		t.Errorf("unexpected image layer: %+v", sky)
+	}
+	if sky.Parallaxx != 0.25 || sky.Parallaxy != 0.5 {
+		t.Errorf("Sky parallax = %v, %v", sky.Parallaxx, sky.Parallaxy)
+	}
+
+	obstacles := layerByName(t, tm, "Obstacles")
+	if obstacles.Parallaxx != 1 || obstacles.Parallaxy != 1 {
+		t.Error("parallax should default to 1")
+	}
+
+	decor := layerByName(t, tm, "Decor")
+	if o := decor.TotalOpacity(); o != 0.25 {
+		t.Errorf("TotalOpacity = %v, want 0.25", o)
+	}
+	if x, y := decor.TotalOffset(); x != 12 || y != -4 {
+		t.Errorf("TotalOffset = %v, %v", x, y)
+	}
+	if x, y := decor.TotalParallax(); x != 0.25 || y != 1 {
+		t.Errorf("TotalParallax = %v, %v", x, y)
+	}
+	tint, ok := decor.TotalTint()
+	if want := (color.NRGBA{0x80, 0, 0, 0x80}); !ok || tint != want {
+		t.Errorf("TotalTint = %v, want %v", tint, want)
+	}
+
+	if v, _ := tm.Property("dark"); v != "true" {
+		t.Errorf("map property dark = %q", v)
+	}
+	if v, _ := tm.Property("gravity"); v != "3" {
+		t.Errorf("map property gravity = %q", v)
+	}
+}
+
+func TestLoadEncodedData(t *testing.T) {
+	tm := loadFixture(t)
+
+	if d := layerByName(t, tm, "Obstacles").Data; !slices.Equal(d, []int{0, 0, 0, 0, 1, 1, 2, 2}) {
+		t.Errorf("zlib data = %v", d)
+	}
+	if d := layerByName(t, tm, "Ground").Data; !slices.Equal(d, []int{2, 0, 0, 2, 1, 1, 1, 1}) {
+		t.Errorf("gzip data = %v", d)
+	}
+}
+
+func TestSplitGid(t *testing.T) {
+	tm := loadFixture(t)
+	decor := layerByName(t, tm, "Decor")
+
+	tests := []struct {
+		gid   int
+		flags TileFlags
+	}{
+		{1, 0},
+		{1, FlipHorizontal},
+		{2, FlipVertical},
+		{1, FlipHorizontal | FlipVertical},
+		{1, FlipHorizontal | FlipDiagonal},
+	}
+	for i, tt := range tests {
+		gid, flags := SplitGid(decor.Data[i])
+		if gid != tt.gid || flags != tt.flags {
+			t.Errorf("tile %d: got %d, %#x, want %d, %#x", i, gid, flags, tt.gid, tt.flags)
+		}
+	}
+
+	items := layerByName(t, tm, "Items")
+	if gid, flags := SplitGid(items.Objects[0].Gid); gid != 2 || flags != FlipHorizontal {
+		t.Errorf("tile object: got %d, %#x", gid, flags)
+	}
+	if len(items.Objects[1].Polygon) != 3 || items.Objects[1].Polygon[1] != (Point{16, -16}) {
+		t.Errorf("polygon = %v", items.Objects[1].Polygon)
+	}
+}
+
+func TestFlipGeoM(t *testing.T) {
+	const w, h = 16, 16
+	// Where the top right corner of the tile ends up
+	tests := []struct {
+		flags  TileFlags
+		x, y   float64
+		action string
+	}{
+		{0, w, 0, "none"},
+		{FlipHorizontal, 0, 0, "horizontal flip"},
+		{FlipVertical, w, h, "vertical flip"},
+		{FlipHorizontal | FlipVertical, 0, h, "180 rotation"},
+		{FlipDiagonal | FlipHorizontal, w, h, "90 clockwise rotation"},
+		{FlipDiagonal | FlipVertical, 0, 0, "90 counterclockwise rotation"},
+	}
+	for _, tt := range tests {
+		g := flipGeoM(tt.flags, w, h)
+		x, y := g.Apply(w, 0)
+		if math.Abs(x-tt.x) > 1e-9 || math.Abs(y-tt.y) > 1e-9 {
+			t.Errorf("%s: corner at %v, %v, want %v, %v", tt.action, x, y, tt.x, tt.y)
+		}
+	}
+}
+
+func TestLayerOrigin(t *testing.T) {
+	tm := loadFixture(t)
+
+	// Parallax is relative to the parallax origin of the map, x 8
+	x, y := tm.layerOrigin(layerByName(t, tm, "Decor"), 108, 50)
+	if x != 12+100*0.75 || y != -4 {
+		t.Errorf("Decor origin = %v, %v", x, y)
+	}
+	x, y = tm.layerOrigin(layerByName(t, tm, "Obstacles"), 108, 50)
+	if x != 0 || y != 0 {
+		t.Errorf("Obstacles origin = %v, %v, want 0, 0", x, y)
+	}
+}
@@ -0,0 +1,309 @@
+package viewport
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/render/camera"
+)
+
+// Split is how the screen is divided between viewports.
+type Split int
+
+const (
+	// SplitVertical places the viewports side by side.
+	SplitVertical Split = iota
+	// SplitHorizontal stacks the viewports.
+	SplitHorizontal
+	// SplitAuto splits along the axis the targets are furthest apart on, and
+	// orders the viewports like their targets, e.g. the player on the left
+	// gets the left viewport.
+	SplitAuto
+)
+
+// unmergeFactor keeps merged viewports merged until the targets are a bit
+// further apart than the merge distance, so they don't flicker at it.
+const unmergeFactor = 1.25
+
+// Viewport is a region of the screen showing what a camera sees.
+type Viewport struct {
+	Camera *camera.Controller
+	// Rect is the region of the screen, in screen coordinates.
+	Rect image.Rectangle
+
+	image *ebiten.Image // Offscreen image the viewport is drawn to
+}
+
+// SplitScreen divides the screen between cameras, e.g. one per player in
+// local co-op. When the targets of the cameras are close, the viewports can
+// merge into one showing all of them.
+type SplitScreen struct {
+	Split Split
+	// MergeDistance is the distance, in pixels, between the targets under
+	// which the viewports merge. 0 never merges.
+	MergeDistance float64
+	// DividerColor is the color of the lines between viewports. Nil draws no
+	// lines.
+	DividerColor color.Color
+
+	width, height int
+	viewports     []*Viewport
+	order         []*Viewport // Viewports in the order they are laid out
+	merged        *Viewport
+	isMerged      bool
+}
+
+// NewSplitScreen returns a split screen of width by height pixels with a
+// viewport for each camera.
+func NewSplitScreen(width, height int, cams ...*camera.Controller) *SplitScreen {
+	s := &SplitScreen{
+		Split:        SplitAuto,
+		DividerColor: color.Black,
+		width:        width,
+		height:       height,
+		merged: &Viewport{
+			Camera: camera.NewController(0, 0),
+			Rect:   image.Rect(0, 0, width, height),
+		},
+	}
+	s.merged.Camera.SetFollowing(true)
+	for _, c := range cams {
+		s.Add(c)
+	}
+	return s
+}
+
+// Add adds a viewport for the camera.
+func (s *SplitScreen) Add(c *camera.Controller) *Viewport {
+	v := &Viewport{Camera: c}
+	s.viewports = append(s.viewports, v)
+	s.layout()
+	return v
+}
+
+// Viewports returns the viewports of the cameras, in the order they were
+// added.
+func (s *SplitScreen) Viewports() []*Viewport {
+	return s.viewports
+}
+
+// Merged returns the viewport covering the whole screen while the viewports
+// are merged.
+func (s *SplitScreen) Merged() *Viewport {
+	return s.merged
+}
+
+// IsMerged reports whether the viewports are merged.
+func (s *SplitScreen) IsMerged() bool {
+	return s.isMerged
+}
+
+// Active returns the viewports drawn on screen.
+func (s *SplitScreen) Active() []*Viewport {
+	if s.isMerged {
+		return []*Viewport{s.merged}
+	}
+	return s.order
+}
+
+// ViewportOf returns the viewport showing what the camera sees, the merged
+// one while merged, or nil if the camera has no viewport.
+func (s *SplitScreen) ViewportOf(c *camera.Controller) *Viewport {
+	for _, v := range s.viewports {
+		if v.Camera != c {
+			continue
+		}
+		if s.isMerged {
+			return s.merged
+		}
+		return v
+	}
+	return nil
+}
+
+// Update merges or splits the viewports and lays them out. It updates the
+// camera of the merged viewport, the one it owns. The cameras of the other
+// viewports are updated by whoever created them, e.g. the scene.
+func (s *SplitScreen) Update() {
+	s.updateMerge()
+	s.layout()
+	if s.isMerged {
+		s.merged.Camera.Update()
+	}
+}
+
+// updateMerge merges the viewports when their targets get close, and splits
+// them when they get apart.
+func (s *SplitScreen) updateMerge() {
+	if s.MergeDistance <= 0 || len(s.viewports) < 2 {
+		s.isMerged = false
+		return
+	}
+
+	limit := s.MergeDistance
+	if s.isMerged {
+		limit *= unmergeFactor
+	}
+	centers := s.targetCenters()
+	near := len(centers) == len(s.viewports)
+	for i := 0; near && i < len(centers); i++ {
+		for j := i + 1; j < len(centers); j++ {
+			if math.Hypot(centers[j].x-centers[i].x, centers[j].y-centers[i].y) > limit {
+				near = false
+				break
+			}
+		}
+	}
+
+	if near && !s.isMerged {
+		s.merge()
+	}
+	s.isMerged = near
+}
+
+// merge makes the merged camera follow the targets of all the cameras, with
+// the rules of the first one.
+func (s *SplitScreen) merge() {
+	first := s.viewports[0].Camera
+	c := s.merged.Camera
+	c.SetRig(first.Rig())
+	c.SetZones(first.Zones())
+	c.SetBounds(first.Bounds())
+	c.Kamera().ZoomFactor = first.Kamera().ZoomFactor
+
+	var targets []body.Body
+	for _, v := range s.viewports {
+		targets = append(targets, v.Camera.Targets()...)
+	}
+	c.SetFollowTargets(targets...)
+}
+
+type point struct{ x, y float64 }
+
+// targetCenters returns the center of the targets of each camera, skipping
+// cameras without targets.
+func (s *SplitScreen) targetCenters() []point {
+	var centers []point
+	for _, v := range s.viewports {
+		if len(v.Camera.Targets()) > 0 {
+			centers = append(centers, center(v.Camera))
+		}
+	}
+	return centers
+}
+
+// axis returns the split to lay the viewports out with, resolving
+// SplitAuto.
+func (s *SplitScreen) axis() Split {
+	if s.Split != SplitAuto {
+		return s.Split
+	}
+	centers := s.targetCenters()
+	if len(centers) < 2 {
+		return SplitVertical
+	}
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range centers {
+		minX, maxX = math.Min(minX, p.x), math.Max(maxX, p.x)
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+	// Relative to the screen, as it is wider than tall
+	if (maxY-minY)/float64(s.height) > (maxX-minX)/float64(s.width) {
+		return SplitHorizontal
+	}
+	return SplitVertical
+}
+
+// layout divides the screen between the viewports and sizes their cameras
+// to fit them.
+func (s *SplitScreen) layout() {
+	s.merged.Rect = image.Rect(0, 0, s.width, s.height)
+	s.merged.Camera.SetSize(float64(s.width), float64(s.height))
+
+	axis := s.axis()
+	s.order = append(s.order[:0], s.viewports...)
+	if s.Split == SplitAuto && len(s.targetCenters()) == len(s.viewports) {
+		sort.SliceStable(s.order, func(i, j int) bool {
+			a, b := center(s.order[i].Camera), center(s.order[j].Camera)
+			if axis == SplitHorizontal {
+				return a.y < b.y
+			}
+			return a.x < b.x
+		})
+	}
+
+	n := len(s.order)
+	for i, v := range s.order {
+		if axis == SplitHorizontal {
+			v.Rect = image.Rect(0, s.height*i/n, s.width, s.height*(i+1)/n)
+		} else {
+			v.Rect = image.Rect(s.width*i/n, 0, s.width*(i+1)/n, s.height)
+		}
+		v.Camera.SetSize(float64(v.Rect.Dx()), float64(v.Rect.Dy()))
+	}
+}
+
+// center returns the center of the targets of the camera.
+func center(c *camera.Controller) point {
+	var p point
+	targets := c.Targets()
+	for _, t := range targets {
+		r := t.Position()
+		p.x += float64(r.Min.X+r.Max.X) / 2
+		p.y += float64(r.Min.Y+r.Max.Y) / 2
+	}
+	if n := float64(len(targets)); n > 0 {
+		p.x, p.y = p.x/n, p.y/n
+	}
+	return p
+}
+
+// Draw calls draw for each viewport on screen with an image the size of the
+// viewport, where the world is drawn through the viewport camera and the
+// HUD of the viewport at its top left corner, and places it on screen. A
+// viewport covering the whole screen is drawn on it directly.
+func (s *SplitScreen) Draw(screen *ebiten.Image, draw func(v *Viewport, dst *ebiten.Image)) {
+	active := s.Active()
+	for _, v := range active {
+		if v.Rect == screen.Bounds() {
+			draw(v, screen)
+			continue
+		}
+
+		w, h := v.Rect.Dx(), v.Rect.Dy()
+		if w <= 0 || h <= 0 {
+			continue
+		}
+		if v.image == nil || v.image.Bounds().Size() != v.Rect.Size() {
+			if v.image != nil {
+				v.image.Deallocate()
+			}
+			v.image = ebiten.NewImage(w, h)
+		}
+		v.image.Clear()
+		draw(v, v.image)
+
+		opts := &ebiten.DrawImageOptions{}
+		opts.GeoM.Translate(float64(v.Rect.Min.X), float64(v.Rect.Min.Y))
+		screen.DrawImage(v.image, opts)
+	}
+
+	if s.DividerColor == nil || len(active) < 2 {
+		return
+	}
+	for _, v := range active[1:] {
+		r := v.Rect
+		if r.Min.X > 0 {
+			vector.DrawFilledRect(screen, float32(r.Min.X), float32(r.Min.Y), 1, float32(r.Dy()), s.DividerColor, false)
+		}
+		if r.Min.Y > 0 {
+			vector.DrawFilledRect(screen, float32(r.Min.X), float32(r.Min.Y), float32(r.Dx()), 1, s.DividerColor, false)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package viewport
+
+import (
+	"image"
+	"testing"
+
+	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
+	"github.com/leandroatallah/firefly/internal/engine/render/camera"
+)
+
+// newPlayers returns two 10x10 bodies centered at the points and a split
+// screen with a camera following each.
+func newPlayers(t *testing.T, a, b image.Point) (*SplitScreen, *bodyphysics.MovableBody, *bodyphysics.MovableBody) {
+	t.Helper()
+	config.Set(&config.AppConfig{ScreenWidth: 320, ScreenHeight: 224})
+
+	var cams []*camera.Controller
+	var bodies []*bodyphysics.MovableBody
+	for _, p := range []image.Point{a, b} {
+		body := bodyphysics.NewMovableBody(bodyphysics.NewBody(bodyphysics.NewRect(0, 0, 10, 10)))
+		body.SetPosition(p.X-5, p.Y-5)
+		c := camera.NewController(0, 0)
+		c.SetFollowing(true)
+		c.SetFollowTarget(body)
+		cams = append(cams, c)
+		bodies = append(bodies, body)
+	}
+	return NewSplitScreen(320, 224, cams...), bodies[0], bodies[1]
+}
+
+func TestSplitLayout(t *testing.T) {
+	s, _, _ := newPlayers(t, image.Pt(0, 0), image.Pt(100, 0))
+
+	s.Split = SplitVertical
+	s.Update()
+	v := s.Viewports()
+	if v[0].Rect != image.Rect(0, 0, 160, 224) || v[1].Rect != image.Rect(160, 0, 320, 224) {
+		t.Errorf("vertical split = %v, %v", v[0].Rect, v[1].Rect)
+	}
+	if w, h := v[1].Camera.Size(); w != 160 || h != 224 {
+		t.Errorf("camera size = %v, %v, want the viewport size", w, h)
+	}
+
+	s.Split = SplitHorizontal
+	s.Update()
+	if v[0].Rect != image.Rect(0, 0, 320, 112) || v[1].Rect != image.Rect(0, 112, 320, 224) {
+		t.Errorf("horizontal split = %v, %v", v[0].Rect, v[1].Rect)
+	}
+}
+
+func TestAutoSplitFollowsTargets(t *testing.T) {
+	// The first player is on the right
+	s, a, _ := newPlayers(t, image.Pt(300, 0), image.Pt(0, 10))
+	s.Update()
+	v := s.Viewports()
+	if v[0].Rect != image.Rect(160, 0, 320, 224) {
+		t.Errorf("first player viewport = %v, want the right half", v[0].Rect)
+	}
+
+	// Then below the second one
+	a.SetPosition(10-5, 400-5)
+	s.Update()
+	if v[0].Rect != image.Rect(0, 112, 320, 224) {
+		t.Errorf("first player viewport = %v, want the bottom half", v[0].Rect)
+	}
+}
+
+func TestMergeWhenClose(t *testing.T) {
+	s, a, _ := newPlayers(t, image.Pt(0, 0), image.Pt(300, 0))
+	s.MergeDistance = 100
+	cam := s.Viewports()[0].Camera
+
+	s.Update()
+	if s.IsMerged() || len(s.Active()) != 2 {
+		t.Fatal("far apart players should be split")
+	}
+
+	a.SetPosition(220-5, -5)
+	s.Update()
+	if !s.IsMerged() || s.ViewportOf(cam) != s.Merged() {
+		t.Fatal("close players should be merged")
+	}
+	if n := len(s.Merged().Camera.Targets()); n != 2 {
+		t.Errorf("merged camera follows %d targets, want 2", n)
+	}
+
+	// Within the unmerge distance
+	a.SetPosition(190-5, -5)
+	s.Update()
+	if !s.IsMerged() {
+		t.Error("players slightly further than the merge distance should stay merged")
+	}
+
+	a.SetPosition(100-5, -5)
+	s.Update()
+	if s.IsMerged() || s.ViewportOf(cam) != s.Viewports()[0] {
+		t.Error("players apart should split again")
+	}
+}
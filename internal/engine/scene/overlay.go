@@ -0,0 +1,42 @@
+package scene
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/app"
+)
+
+// OverlayScene is embedded by scenes pushed over others, like the pause
+// menu or a dialogue. Unlike BaseScene, starting it leaves the space and
+// the actors of the scenes below alone.
+type OverlayScene struct {
+	app.AppContextHolder
+
+	// UpdateBelow keeps the scenes below updating, e.g. the world during a dialogue
+	UpdateBelow bool
+	// DrawBelow draws the scenes below first, for overlays that don't cover the screen
+	DrawBelow bool
+	// CaptureInput makes the scenes below read every action as released
+	CaptureInput bool
+}
+
+func (s *OverlayScene) UpdatesBelow() bool {
+	return s.UpdateBelow
+}
+
+func (s *OverlayScene) DrawsBelow() bool {
+	return s.DrawBelow
+}
+
+func (s *OverlayScene) CapturesInput() bool {
+	return s.CaptureInput
+}
+
+func (s *OverlayScene) Draw(screen *ebiten.Image) {}
+
+func (s *OverlayScene) Update() error {
+	return nil
+}
+
+func (s *OverlayScene) OnStart() {}
+
+func (s *OverlayScene) OnFinish() {}
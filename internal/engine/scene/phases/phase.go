@@ -6,4 +6,6 @@ type Phase struct {
 	TilemapPath  string
 	NextPhaseID  int
 	SequencePath string
+	// AudioManifest lists the sounds resident while the phase is played.
+	AudioManifest string
 }
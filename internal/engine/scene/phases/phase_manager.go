@@ -1,6 +1,9 @@
 package phases
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 type Manager struct {
 	phases       map[int]Phase
@@ -25,6 +28,18 @@ func (m *Manager) GetPhase(id int) (Phase, error) {
 	return p, nil
 }
 
+// Phases returns every phase sorted by ID.
+func (m *Manager) Phases() []Phase {
+	res := make([]Phase, 0, len(m.phases))
+	for _, p := range m.phases {
+		res = append(res, p)
+	}
+	slices.SortFunc(res, func(a, b Phase) int {
+		return a.ID - b.ID
+	})
+	return res
+}
+
 func (m *Manager) GetCurrentPhase() (Phase, error) {
 	return m.GetPhase(m.CurrentPhase)
 }
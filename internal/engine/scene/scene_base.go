@@ -3,8 +3,11 @@
 package scene
 
 import (
+	"log"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/leandroatallah/firefly/internal/engine/app"
+	"github.com/leandroatallah/firefly/internal/engine/audio"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 	"github.com/leandroatallah/firefly/internal/engine/physics/space"
 )
@@ -15,6 +18,9 @@ type BaseScene struct {
 	count          int
 	space          *space.Space
 	IsKeysDisabled bool
+
+	audioManifestPath string
+	audioManifest     *audio.Manifest
 }
 
 func NewScene() *BaseScene {
@@ -32,9 +38,34 @@ func (s *BaseScene) OnStart() {
 	if s.AppContext().ActorManager != nil {
 		s.AppContext().ActorManager.Clear()
 	}
+	s.loadAudioManifest()
+}
+
+func (s *BaseScene) OnFinish() {
+	if am := s.AppContext().AudioManager; am != nil {
+		am.UnloadManifest(s.audioManifest)
+	}
+	s.audioManifest = nil
 }
 
-func (s *BaseScene) OnFinish() {}
+// SetAudioManifest sets the audio manifest loaded when the scene starts and
+// unloaded when it finishes.
+func (s *BaseScene) SetAudioManifest(path string) {
+	s.audioManifestPath = path
+}
+
+func (s *BaseScene) loadAudioManifest() {
+	am := s.AppContext().AudioManager
+	if am == nil || s.audioManifestPath == "" {
+		return
+	}
+	m, err := am.LoadManifest(s.audioManifestPath)
+	if err != nil {
+		log.Printf("failed to load audio manifest %s: %v", s.audioManifestPath, err)
+		return
+	}
+	s.audioManifest = m
+}
 
 func (s *BaseScene) Exit() {}
 
@@ -2,21 +2,27 @@ package scene
 
 import (
 	"log"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/leandroatallah/firefly/internal/engine/app"
 	"github.com/leandroatallah/firefly/internal/engine/audio"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/navigation"
+	"github.com/leandroatallah/firefly/internal/engine/input"
 )
 
+// maxHistory is how many scenes NavigateBack can go back through.
+const maxHistory = 8
+
+// SceneManager keeps a stack of scenes. The top scene always updates and
+// draws; overlays decide whether the scenes below them do too.
 type SceneManager struct {
 	app.AppContextHolder
 
-	current       navigation.Scene
-	previousScene navigation.Scene
-	factory       SceneFactory
-	nextScene     navigation.Scene
-	transitioner  navigation.Transition
+	stack        []navigation.Scene
+	history      []navigation.Scene // Bottom scenes navigated away from
+	factory      SceneFactory
+	transitioner navigation.Transition
 }
 
 func NewSceneManager() *SceneManager {
@@ -24,89 +30,183 @@ func NewSceneManager() *SceneManager {
 	return m
 }
 
+// overlay returns the scene as an overlay, nil for full scenes, which hide
+// and pause the scenes below.
+func overlay(s navigation.Scene) navigation.Overlay {
+	o, _ := s.(navigation.Overlay)
+	return o
+}
+
 func (m *SceneManager) Update() error {
 	if m.transitioner != nil {
 		m.transitioner.Update()
 	}
 
-	if m.current == nil {
+	if len(m.stack) == 0 {
 		return nil
 	}
-	if err := m.current.Update(); err != nil {
-		return err
+
+	first := len(m.stack) - 1
+	for first > 0 {
+		if o := overlay(m.stack[first]); o == nil || !o.UpdatesBelow() {
+			break
+		}
+		first--
 	}
 
+	// Scenes can push and pop while updating, so iterate over a copy
+	scenes := slices.Clone(m.stack[first:])
+	defer input.SetCaptured(false)
+	for i, s := range scenes {
+		input.SetCaptured(m.capturedAbove(scenes[i+1:]))
+		if err := s.Update(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// capturedAbove reports whether any of the scenes above captures the input.
+func (m *SceneManager) capturedAbove(above []navigation.Scene) bool {
+	for _, s := range above {
+		if o := overlay(s); o == nil || o.CapturesInput() {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *SceneManager) Draw(screen *ebiten.Image) {
-	if m.current == nil {
+	if len(m.stack) == 0 {
 		return
 	}
-	m.current.Draw(screen)
+
+	first := len(m.stack) - 1
+	for first > 0 {
+		if o := overlay(m.stack[first]); o == nil || !o.DrawsBelow() {
+			break
+		}
+		first--
+	}
+	for _, s := range m.stack[first:] {
+		s.Draw(screen)
+	}
+
 	if m.transitioner != nil {
 		m.transitioner.Draw(screen)
 	}
 }
 
-func (m *SceneManager) SwitchTo(scene navigation.Scene) {
-	if m.current != nil {
-		m.current.OnFinish()
+// Top returns the scene on top of the stack.
+func (m *SceneManager) Top() navigation.Scene {
+	if len(m.stack) == 0 {
+		return nil
 	}
+	return m.stack[len(m.stack)-1]
+}
 
-	m.current = scene
+// SwitchTo finishes every scene of the stack and starts scene alone.
+func (m *SceneManager) SwitchTo(scene navigation.Scene) {
+	m.clear()
+	if scene != nil {
+		m.PushScene(scene)
+	}
+}
 
-	if m.current != nil {
-		m.current.OnStart()
+func (m *SceneManager) clear() {
+	for len(m.stack) > 0 {
+		m.Pop()
 	}
 }
 
-func (m *SceneManager) SetFactory(factory SceneFactory) {
-	m.factory = factory
+// PushScene starts a scene on top of the stack. The scenes below stay
+// started.
+func (m *SceneManager) PushScene(scene navigation.Scene) {
+	m.stack = append(m.stack, scene)
+	scene.OnStart()
 }
 
-func (m *SceneManager) NavigateTo(
+// Push creates a scene and starts it on top of the stack.
+func (m *SceneManager) Push(sceneType navigation.SceneType, freshInstance bool) {
+	m.PushScene(m.create(sceneType, freshInstance))
+}
+
+// Pop finishes the top scene.
+func (m *SceneManager) Pop() {
+	top := m.Top()
+	if top == nil {
+		return
+	}
+	m.stack = m.stack[:len(m.stack)-1]
+	top.OnFinish()
+}
+
+// Replace swaps the top scene for a new one, keeping the scenes below.
+func (m *SceneManager) Replace(
 	sceneType navigation.SceneType, sceneTransition navigation.Transition, freshInstance bool,
 ) {
-	if m.current != nil {
-		m.previousScene = m.current
-	}
+	scene := m.create(sceneType, freshInstance)
+	m.transition(sceneTransition, func() {
+		m.Pop()
+		m.PushScene(scene)
+	})
+}
 
+func (m *SceneManager) SetFactory(factory SceneFactory) {
+	m.factory = factory
+}
+
+func (m *SceneManager) create(sceneType navigation.SceneType, freshInstance bool) navigation.Scene {
 	scene, err := m.factory.Create(sceneType, freshInstance)
 	if err != nil {
 		log.Fatalf("Error creating scene: %v", err)
 	}
+	return scene
+}
 
-	if sceneTransition != nil {
-		m.transitioner = sceneTransition
-		m.nextScene = scene
-		m.transitioner.StartTransition(func() {
-			m.SwitchTo(m.nextScene)
-			m.nextScene = nil
-		})
-	} else {
-		m.SwitchTo(scene)
+// transition runs fn halfway through a transition, or right away without one.
+func (m *SceneManager) transition(sceneTransition navigation.Transition, fn func()) {
+	if sceneTransition == nil {
+		fn()
+		return
 	}
+	m.transitioner = sceneTransition
+	m.transitioner.StartTransition(fn)
+}
+
+// NavigateTo replaces the whole stack with a new scene. The scene at the
+// bottom of the stack is remembered for NavigateBack.
+func (m *SceneManager) NavigateTo(
+	sceneType navigation.SceneType, sceneTransition navigation.Transition, freshInstance bool,
+) {
+	scene := m.create(sceneType, freshInstance)
+	m.transition(sceneTransition, func() {
+		if len(m.stack) > 0 {
+			m.history = append(m.history, m.stack[0])
+			if len(m.history) > maxHistory {
+				m.history = m.history[1:]
+			}
+		}
+		m.SwitchTo(scene)
+	})
 }
 
 func (m *SceneManager) NavigateBack(sceneTransition navigation.Transition) {
-	if m.previousScene == nil {
-		log.Println("No previous scene to navigate back to.")
+	if len(m.stack) > 1 {
+		m.transition(sceneTransition, m.Pop)
 		return
 	}
 
-	sceneToLoad := m.previousScene
-	m.previousScene = nil // Clear previous scene after navigating back
+	if len(m.history) == 0 {
+		log.Println("No previous scene to navigate back to.")
+		return
+	}
 
-	if sceneTransition != nil {
-		m.transitioner = sceneTransition
-		m.nextScene = sceneToLoad
-		m.transitioner.StartTransition(func() {
-			m.SwitchTo(m.nextScene)
-			m.nextScene = nil
-		})
-	} else {
+	sceneToLoad := m.history[len(m.history)-1]
+	m.history = m.history[:len(m.history)-1]
+	m.transition(sceneTransition, func() {
 		m.SwitchTo(sceneToLoad)
-	}
+	})
 }
 
 func (m *SceneManager) AudioManager() *audio.AudioManager {
@@ -0,0 +1,72 @@
+package scene
+
+import (
+	"slices"
+	"testing"
+)
+
+// recordingScene is a full scene that logs its calls.
+type recordingScene struct {
+	BaseScene
+	name string
+	log  *[]string
+}
+
+func (s *recordingScene) Update() error {
+	*s.log = append(*s.log, "update "+s.name)
+	return nil
+}
+
+func (s *recordingScene) OnStart() {
+	*s.log = append(*s.log, "start "+s.name)
+}
+
+func (s *recordingScene) OnFinish() {
+	*s.log = append(*s.log, "finish "+s.name)
+}
+
+type recordingOverlay struct {
+	OverlayScene
+	recordingScene
+}
+
+func (s *recordingOverlay) Update() error { return s.recordingScene.Update() }
+func (s *recordingOverlay) OnStart()      { s.recordingScene.OnStart() }
+func (s *recordingOverlay) OnFinish()     { s.recordingScene.OnFinish() }
+
+func TestSceneManagerStack(t *testing.T) {
+	var log []string
+	game := &recordingScene{name: "game", log: &log}
+	dialogue := &recordingOverlay{
+		OverlayScene:   OverlayScene{UpdateBelow: true},
+		recordingScene: recordingScene{name: "dialogue", log: &log},
+	}
+	pause := &recordingOverlay{recordingScene: recordingScene{name: "pause", log: &log}}
+
+	m := NewSceneManager()
+	m.SwitchTo(game)
+	m.PushScene(dialogue)
+	m.Update()
+	m.PushScene(pause)
+	m.Update()
+	m.Pop()
+	m.Update()
+	m.SwitchTo(nil)
+
+	want := []string{
+		"start game",
+		"start dialogue",
+		"update game", "update dialogue",
+		"start pause",
+		"update pause",
+		"finish pause",
+		"update game", "update dialogue",
+		"finish dialogue", "finish game",
+	}
+	if !slices.Equal(log, want) {
+		t.Errorf("got %q, want %q", log, want)
+	}
+	if m.Top() != nil {
+		t.Errorf("stack not empty after SwitchTo(nil)")
+	}
+}
@@ -8,9 +8,8 @@ import (
 	"github.com/leandroatallah/firefly/internal/engine/audio"
 	"github.com/leandroatallah/firefly/internal/engine/data/config"
 	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
-	"github.com/leandroatallah/firefly/internal/engine/entity/actors/enemies"
-	"github.com/leandroatallah/firefly/internal/engine/entity/actors/npcs"
 	"github.com/leandroatallah/firefly/internal/engine/entity/items"
+	"github.com/leandroatallah/firefly/internal/engine/entity/prefabs"
 	"github.com/leandroatallah/firefly/internal/engine/render/camera"
 	"github.com/leandroatallah/firefly/internal/engine/render/tilemap"
 )
@@ -37,6 +36,9 @@ func (s *TilemapScene) SetCameraConfig(config CameraConfig) {
 
 func (s *TilemapScene) Update() error {
 	s.cam.Update()
+	if s.tilemap != nil {
+		s.tilemap.Update()
+	}
 	return s.BaseScene.Update()
 }
 
@@ -45,35 +47,38 @@ func (s *TilemapScene) Camera() *camera.Controller {
 }
 
 func (s *TilemapScene) OnStart() {
-	s.BaseScene.OnStart()
-
 	// Load phases from context
 	phase, err := s.AppContext().PhaseManager.GetCurrentPhase()
 	if err != nil {
 		log.Fatalf("failed to get current phase: %v", err)
 	}
 
+	s.SetAudioManifest(phase.AudioManifest)
+	s.BaseScene.OnStart()
+
 	// Init tilemap
 	tm, err := tilemap.LoadTilemap(phase.TilemapPath)
 	if err != nil {
 		log.Fatal(err)
 	}
 	s.tilemap = tm
+	tm.SetEventManager(s.AppContext().EventManager)
+	s.cam.SetZones(tm.CameraZones())
 
 	// Init space
 	s.PhysicsSpace().SetTilemapDimensionsProvider(s)
 }
 
 func (s *TilemapScene) GetTilemapWidth() int {
-	if s.tilemap != nil && len(s.tilemap.Layers) > 0 {
-		return s.tilemap.Layers[0].Width * s.tilemap.Tileheight
+	if s.tilemap != nil && s.tilemap.Width > 0 {
+		return s.tilemap.Width * s.tilemap.Tilewidth
 	}
 	return config.Get().ScreenWidth
 }
 
 func (s *TilemapScene) GetTilemapHeight() int {
-	if s.tilemap != nil && len(s.tilemap.Layers) > 0 {
-		return s.tilemap.Layers[0].Height * s.tilemap.Tileheight
+	if s.tilemap != nil && s.tilemap.Height > 0 {
+		return s.tilemap.Height * s.tilemap.Tileheight
 	}
 	return config.Get().ScreenHeight
 }
@@ -118,40 +123,19 @@ func (s *TilemapScene) SetPlayerStartPosition(p actors.ActorEntity) {
 	}
 }
 
-func InitEnemies[T actors.ActorEntity](s *TilemapScene, factory *enemies.EnemyFactory[T]) error {
-	enemiesPos := s.Tilemap().GetEnemiesPositionID()
-
-	for _, e := range enemiesPos {
-		enemy, err := factory.Create(enemies.EnemyType(e.EnemyType), e.X, e.Y, e.ID)
-		pos := enemy.Position()
-		enemy.SetPosition(pos.Min.X, pos.Min.Y-pos.Dy()/2) // Adjust Y position based on enemy height
-		if err != nil {
-			return err
-		}
-
-		s.PhysicsSpace().AddBody(enemy)
-		if s.AppContext().ActorManager != nil {
-			s.AppContext().ActorManager.Register(enemy)
-		}
-	}
-
-	return nil
-}
-
-func InitNPCs[T actors.ActorEntity](s *TilemapScene, factory *npcs.NpcFactory[T]) error {
-	npcsPos := s.Tilemap().GetNpcsPositionID()
-
-	for _, n := range npcsPos {
-		npc, err := factory.Create(npcs.NpcType(n.NpcType), n.X, n.Y, n.ID)
-		pos := npc.Position()
-		npc.SetPosition(pos.Min.X, pos.Min.Y-pos.Dy()/2) // Adjust Y position based on npc height
+// InitPrefabs spawns every tilemap object with a "prefab" property.
+func (s *TilemapScene) InitPrefabs(spawner *prefabs.Spawner) error {
+	for _, p := range s.tilemap.GetPrefabPositions() {
+		b, err := spawner.Spawn(p.Prefab, p.X, p.Y, p.ID, p.Properties)
 		if err != nil {
 			return err
 		}
+		pos := b.Position()
+		b.SetPosition(pos.Min.X, pos.Min.Y-pos.Dy()/2) // Adjust Y position based on body height
 
-		s.PhysicsSpace().AddBody(npc)
-		if s.AppContext().ActorManager != nil {
-			s.AppContext().ActorManager.Register(npc)
+		s.PhysicsSpace().AddBody(b)
+		if actor, ok := b.(actors.ActorEntity); ok && s.AppContext().ActorManager != nil {
+			s.AppContext().ActorManager.Register(actor)
 		}
 	}
 
@@ -2,6 +2,7 @@ package sequences
 
 import (
 	"fmt"
+	"log"
 	"math"
 
 	"github.com/leandroatallah/firefly/internal/engine/app"
@@ -33,9 +34,11 @@ func (c *EventCommand) Update() bool {
 	return true
 }
 
-// DialogueCommand displays one or more lines of text and waits for player input.
+// DialogueCommand displays one or more lines of text, or a dialogue graph
+// when GraphID is set, and waits for player input.
 type DialogueCommand struct {
 	Lines           []string
+	GraphID         string
 	Position        string
 	Speed           int
 	dialogueManager *speech.Manager
@@ -43,7 +46,13 @@ type DialogueCommand struct {
 
 func (c *DialogueCommand) Init(appContext *app.AppContext) {
 	c.dialogueManager = appContext.DialogueManager
-	c.dialogueManager.ShowMessages(c.Lines, c.Position, c.Speed)
+	if c.GraphID == "" {
+		c.dialogueManager.ShowMessages(c.Lines, c.Position, c.Speed)
+		return
+	}
+	if err := c.dialogueManager.StartGraph(c.GraphID, c.Position, c.Speed); err != nil {
+		log.Println(err)
+	}
 }
 
 func (c *DialogueCommand) Update() bool {
@@ -124,3 +133,59 @@ func (c *MoveActorCommand) Update() bool {
 
 	return false
 }
+
+// EffectCommand applies or removes a status effect on a target actor.
+type EffectCommand struct {
+	TargetID string
+	Effect   string
+	Remove   bool
+}
+
+func (c *EffectCommand) Init(appContext *app.AppContext) {
+	actor, found := appContext.ActorManager.Find(c.TargetID)
+	if !found {
+		fmt.Printf("EffectCommand: Actor with ID '%s' not found.\n", c.TargetID)
+		return
+	}
+
+	stack := actor.GetCharacter().Effects()
+	if c.Remove {
+		stack.Remove(c.Effect)
+		return
+	}
+	if err := stack.ApplyByName(c.Effect, "sequence"); err != nil {
+		fmt.Printf("EffectCommand: %v\n", err)
+	}
+}
+
+func (c *EffectCommand) Update() bool {
+	return true
+}
+
+// PostEffectCommand turns a post-processing pass on or off, or pulses it for
+// Frames frames when Frames is set.
+type PostEffectCommand struct {
+	Effect  string
+	Enabled bool
+	Frames  int
+}
+
+func (c *PostEffectCommand) Init(appContext *app.AppContext) {
+	fx := appContext.PostFX
+	if fx == nil {
+		return
+	}
+	var found bool
+	if c.Frames > 0 {
+		found = fx.Pulse(c.Effect, c.Frames)
+	} else {
+		found = fx.SetEnabled(c.Effect, c.Enabled)
+	}
+	if !found {
+		fmt.Printf("PostEffectCommand: Post effect '%s' not found.\n", c.Effect)
+	}
+}
+
+func (c *PostEffectCommand) Update() bool {
+	return true
+}
@@ -32,6 +32,7 @@ type CommandData struct {
 
 	// Fields for "dialogue"
 	Lines       []string `json:"lines,omitempty"`
+	Graph       string   `json:"graph,omitempty"` // Dialogue graph ID, used instead of lines
 	Position    string   `json:"position,omitempty"`
 	SpeechSpeed int      `json:"speech_speed,omitempty"`
 
@@ -43,6 +44,10 @@ type CommandData struct {
 	EndX     float64 `json:"end_x,omitempty"`
 	Speed    float64 `json:"speed,omitempty"`
 
+	// Fields for "apply_effect" and "remove_effect" (also uses TargetID), and
+	// the post effects commands (also uses Frames)
+	Effect string `json:"effect,omitempty"`
+
 	// Fields for "event"
 	EventType string                 `json:"event_type,omitempty"`
 	Payload   map[string]interface{} `json:"payload,omitempty"`
@@ -62,7 +67,7 @@ func (cd *CommandData) ToCommand() Command {
 		if speed == 0 && cd.Speed > 0 {
 			speed = int(cd.Speed)
 		}
-		return &DialogueCommand{Lines: cd.Lines, Position: cd.Position, Speed: speed}
+		return &DialogueCommand{Lines: cd.Lines, GraphID: cd.Graph, Position: cd.Position, Speed: speed}
 	case "delay":
 		return &DelayCommand{Frames: cd.Frames}
 	case "move_actor":
@@ -71,6 +76,16 @@ func (cd *CommandData) ToCommand() Command {
 			EndX:     cd.EndX,
 			Speed:    cd.Speed,
 		}
+	case "apply_effect":
+		return &EffectCommand{TargetID: cd.TargetID, Effect: cd.Effect}
+	case "remove_effect":
+		return &EffectCommand{TargetID: cd.TargetID, Effect: cd.Effect, Remove: true}
+	case "enable_post_effect":
+		return &PostEffectCommand{Effect: cd.Effect, Enabled: true}
+	case "disable_post_effect":
+		return &PostEffectCommand{Effect: cd.Effect}
+	case "pulse_post_effect":
+		return &PostEffectCommand{Effect: cd.Effect, Frames: cd.Frames}
 	case "event":
 		return &EventCommand{
 			EventType: cd.EventType,
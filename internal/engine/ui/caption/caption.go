@@ -0,0 +1,242 @@
+package caption
+
+import (
+	"encoding/json"
+	"image"
+	"image/color"
+	"os"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leandroatallah/firefly/internal/engine/assets/font"
+	"github.com/leandroatallah/firefly/internal/engine/audio"
+	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	"github.com/leandroatallah/firefly/internal/engine/data/i18n"
+	"github.com/leandroatallah/firefly/internal/engine/event"
+	"github.com/leandroatallah/firefly/internal/engine/render/camera"
+	"github.com/leandroatallah/firefly/internal/engine/utils/timing"
+)
+
+const (
+	// DefaultDuration is used by captions of events and looping music.
+	DefaultDuration = 2 * time.Second
+	// Captions of short sounds stay at least this long to be readable.
+	minDuration = time.Second
+
+	fontSize   = 8
+	padding    = 2
+	margin     = 4
+	fadeFrames = 15
+)
+
+// Rule captions a sound or an event. Text is a string table key, like
+// "caption.wolf_growls" for "[wolf growls]".
+type Rule struct {
+	Sound string `json:"sound,omitempty"`
+	Event string `json:"event,omitempty"`
+	Text  string `json:"text"`
+	// DurationMs overrides the duration of the caption. Zero uses the length
+	// of the sound, or DefaultDuration.
+	DurationMs int `json:"duration_ms,omitempty"`
+}
+
+func (r Rule) duration(sound time.Duration) time.Duration {
+	switch {
+	case r.DurationMs > 0:
+		return time.Duration(r.DurationMs) * time.Millisecond
+	case sound > 0:
+		return max(sound, minDuration)
+	default:
+		return DefaultDuration
+	}
+}
+
+// Positioned sounds and events show their caption next to them. Bodies are
+// positioned.
+type Positioned interface {
+	Position() image.Rectangle
+}
+
+type point struct {
+	x, y float64
+}
+
+func (p point) Position() image.Rectangle {
+	return image.Rect(int(p.x), int(p.y), int(p.x), int(p.y))
+}
+
+type caption struct {
+	text   string
+	frames int
+	source Positioned // Nil for captions at the bottom of the screen
+}
+
+// Manager shows captions for sounds and events while captions are enabled in
+// the config. Captions of positioned sources are drawn over them, or at the
+// screen edge closest to them when they are out of view.
+type Manager struct {
+	font     *font.FontText
+	sounds   map[string]Rule
+	events   map[string]Rule
+	captions []*caption
+	camera   *camera.Controller
+}
+
+func NewManager(fontText *font.FontText) *Manager {
+	return &Manager{
+		font:   fontText,
+		sounds: make(map[string]Rule),
+		events: make(map[string]Rule),
+	}
+}
+
+func (m *Manager) AddRule(r Rule) {
+	if r.Sound != "" {
+		m.sounds[r.Sound] = r
+	}
+	if r.Event != "" {
+		m.events[r.Event] = r
+	}
+}
+
+// LoadRules reads a JSON array of caption rules.
+func (m *Manager) LoadRules(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return err
+	}
+	for _, r := range rules {
+		m.AddRule(r)
+	}
+	return nil
+}
+
+// Subscribe shows the captions of the event rules when their events are
+// published. Events that are Positioned are captioned at their position.
+func (m *Manager) Subscribe(events *event.Manager) {
+	for eventType, r := range m.events {
+		events.Subscribe(eventType, func(e event.Event) {
+			source, _ := e.(Positioned)
+			m.Show(r.Text, r.duration(0), source)
+		})
+	}
+}
+
+// ListenTo shows the captions of the sound rules when the sounds play.
+func (m *Manager) ListenTo(am *audio.AudioManager) {
+	am.OnPlay(func(p audio.Playback) {
+		r, ok := m.sounds[p.Name]
+		if !ok {
+			return
+		}
+		var source Positioned
+		switch {
+		case p.Source != nil:
+			source = p.Source
+		case p.Positional:
+			source = point{p.X, p.Y}
+		}
+		m.Show(r.Text, r.duration(p.Duration), source)
+	})
+}
+
+// SetCamera sets the camera positioned captions are drawn with. Without a
+// camera, positions are in screen coordinates.
+func (m *Manager) SetCamera(c *camera.Controller) {
+	m.camera = c
+}
+
+// Show displays a caption for a duration. Showing a caption that is already
+// on screen for the same source restarts it instead.
+func (m *Manager) Show(text string, duration time.Duration, source Positioned) {
+	if !config.Get().Captions {
+		return
+	}
+	frames := timing.FromDuration(duration)
+	for _, c := range m.captions {
+		if c.text == text && c.source == source {
+			c.frames = frames
+			return
+		}
+	}
+	m.captions = append(m.captions, &caption{text: text, frames: frames, source: source})
+}
+
+func (m *Manager) Update() {
+	active := m.captions[:0]
+	for _, c := range m.captions {
+		c.frames--
+		if c.frames > 0 {
+			active = append(active, c)
+		}
+	}
+	clear(m.captions[len(active):])
+	m.captions = active
+}
+
+func (m *Manager) Clear() {
+	m.captions = nil
+}
+
+func (m *Manager) Draw(screen *ebiten.Image) {
+	if !config.Get().Captions || m.font == nil {
+		return
+	}
+
+	bounds := screen.Bounds()
+	bottom := float64(bounds.Max.Y - margin)
+	for i := len(m.captions) - 1; i >= 0; i-- {
+		c := m.captions[i]
+		msg := i18n.T(c.text)
+
+		var sx, sy float64
+		if c.source != nil {
+			sx, sy = m.toScreen(c.source)
+			// Point at sources out of view
+			if sx < float64(bounds.Min.X) {
+				msg = "< " + msg
+			} else if sx > float64(bounds.Max.X) {
+				msg += " >"
+			}
+		}
+		w, h := m.font.Measure(msg, fontSize, fontSize)
+		w, h = w+padding*2, h+padding*2
+
+		var x, y float64
+		if c.source != nil {
+			// Above the source, clamped to the screen
+			x = min(max(sx-w/2, float64(bounds.Min.X+margin)), float64(bounds.Max.X-margin)-w)
+			y = min(max(sy-h-margin, float64(bounds.Min.Y+margin)), float64(bounds.Max.Y-margin)-h)
+		} else {
+			// Stacked at the bottom center, newest first
+			x = float64(bounds.Min.X+bounds.Max.X)/2 - w/2
+			bottom -= h
+			y = bottom
+			bottom -= margin
+		}
+
+		alpha := min(float32(c.frames)/fadeFrames, 1)
+		vector.DrawFilledRect(screen, float32(x), float32(y), float32(w), float32(h), color.RGBA{A: uint8(0xb0 * alpha)}, false)
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(x+padding, y+padding)
+		op.ColorScale.ScaleWithColor(color.White)
+		op.ColorScale.ScaleAlpha(alpha)
+		m.font.Draw(screen, msg, fontSize, op)
+	}
+}
+
+// toScreen returns the top center of a source on screen.
+func (m *Manager) toScreen(source Positioned) (float64, float64) {
+	p := source.Position()
+	x, y := float64(p.Min.X+p.Max.X)/2, float64(p.Min.Y)
+	if m.camera != nil {
+		x, y = m.camera.Kamera().ApplyCameraTransformToPoint(x, y)
+	}
+	return x, y
+}
@@ -0,0 +1,61 @@
+package caption
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	"github.com/leandroatallah/firefly/internal/engine/event"
+)
+
+type testEvent struct{ point }
+
+func (testEvent) Type() string { return "test_event" }
+
+func TestRuleDuration(t *testing.T) {
+	tests := []struct {
+		rule  Rule
+		sound time.Duration
+		want  time.Duration
+	}{
+		{Rule{DurationMs: 500}, 3 * time.Second, 500 * time.Millisecond},
+		{Rule{}, 3 * time.Second, 3 * time.Second},
+		{Rule{}, 100 * time.Millisecond, minDuration},
+		{Rule{}, 0, DefaultDuration},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.duration(tt.sound); got != tt.want {
+			t.Errorf("duration(%v) of %+v = %v, want %v", tt.sound, tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestEventCaptions(t *testing.T) {
+	config.Set(&config.AppConfig{Captions: true})
+	defer config.Set(&config.AppConfig{})
+
+	m := NewManager(nil)
+	m.AddRule(Rule{Event: "test_event", Text: "caption.test", DurationMs: 100})
+	events := event.NewManager()
+	m.Subscribe(events)
+
+	e := testEvent{point{10, 20}}
+	events.Publish(e)
+	events.Publish(e)
+	if len(m.captions) != 1 || m.captions[0].source != e {
+		t.Fatalf("captions = %+v, want one caption at the event", m.captions)
+	}
+
+	for range 6 {
+		m.Update()
+	}
+	if len(m.captions) != 0 {
+		t.Error("the caption should expire after its duration")
+	}
+
+	config.Get().Captions = false
+	events.Publish(e)
+	if len(m.captions) != 0 {
+		t.Error("captions are disabled")
+	}
+}
@@ -1,18 +1,41 @@
 package speech
 
 import (
+	"fmt"
+	"image"
+
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/leandroatallah/firefly/internal/engine/data/flags"
+	"github.com/leandroatallah/firefly/internal/engine/data/i18n"
+	"github.com/leandroatallah/firefly/internal/engine/event"
+	"github.com/leandroatallah/firefly/internal/engine/input"
 )
 
+// Presenter is implemented by speeches that show who is speaking and the
+// choices of the current node.
+type Presenter interface {
+	// SetSpeaker sets the speaker of the current line, nil for narration.
+	SetSpeaker(s *Speaker)
+	// SetChoices sets the choices shown once the line is spelled, none when empty.
+	SetChoices(choices []string, selected int)
+}
+
 // Manager handles the display of dialogue and speech bubbles.
 type Manager struct {
 	speech          Speech
 	isSpeaking      bool
-	currentText     string
-	lines           []string
-	currentLine     int
 	waitingForInput bool
+
+	graph    *Graph
+	node     *Node
+	choices  []Choice // Choices of the current node whose conditions hold
+	selected int
+	position string
+	area     image.Rectangle // Part of the screen the speech is drawn in
+
+	flags   *flags.Flags
+	events  *event.Manager
+	onStart func()
 }
 
 // NewManager creates a new dialogue manager.
@@ -20,24 +43,132 @@ func NewManager(speech Speech) *Manager {
 	return &Manager{speech: speech}
 }
 
+// SetFlags sets the game flags checked by choices and set by effects.
+func (m *Manager) SetFlags(f *flags.Flags) {
+	m.flags = f
+}
+
+// SetEventManager sets where the events of dialogue effects are published.
+func (m *Manager) SetEventManager(events *event.Manager) {
+	m.events = events
+}
+
+// SetArea sets the part of the screen speeches are drawn in, e.g. the
+// viewport of the speaking player in split screen. An empty area is the
+// whole screen.
+func (m *Manager) SetArea(area image.Rectangle) {
+	m.area = area
+}
+
+// SetOnStart sets a function called when a dialogue starts while none is
+// showing, e.g. to push the dialogue scene.
+func (m *Manager) SetOnStart(fn func()) {
+	m.onStart = fn
+}
+
 // ShowMessages displays a list of messages.
 func (m *Manager) ShowMessages(lines []string, position string, speed int) {
 	if len(lines) == 0 {
 		return
 	}
-	m.lines = lines
-	m.currentLine = 0
+	m.start(linearGraph(lines, position), position, speed)
+}
+
+// StartGraph plays the registered dialogue graph with the given ID.
+func (m *Manager) StartGraph(id string, position string, speed int) error {
+	g, ok := GetGraph(id)
+	if !ok {
+		return fmt.Errorf("dialogue graph not found: %s", id)
+	}
+	m.start(g, position, speed)
+	return nil
+}
+
+func (m *Manager) start(g *Graph, position string, speed int) {
+	wasSpeaking := m.isSpeaking
+	m.graph = g
+	m.position = position
 	m.isSpeaking = true
-	m.waitingForInput = false
-	m.speech.ResetText()
-	m.speech.SetPosition(position)
 	if speed > 0 {
 		m.speech.SetSpeed(speed)
 	} else {
 		// Default speed if not specified
 		m.speech.SetSpeed(4)
 	}
-	m.speech.Show()
+	m.enter(g.Start)
+	if m.isSpeaking {
+		m.speech.Show()
+		if !wasSpeaking && m.onStart != nil {
+			m.onStart()
+		}
+	}
+}
+
+// enter moves to a node and runs its effects.
+func (m *Manager) enter(id string) {
+	n, ok := m.graph.Node(id)
+	if !ok {
+		m.end()
+		return
+	}
+	m.node = n
+	m.waitingForInput = false
+	m.runEffects(n.Effects)
+
+	m.choices = m.choices[:0]
+	for _, c := range n.Choices {
+		if m.flags == nil || m.flags.Check(c.Conditions...) {
+			m.choices = append(m.choices, c)
+		}
+	}
+	m.selected = 0
+
+	position := n.Position
+	if position == "" {
+		position = m.position
+	}
+	m.speech.ResetText()
+	m.speech.SetPosition(position)
+
+	if p, ok := m.speech.(Presenter); ok {
+		var speaker *Speaker
+		if s, ok := GetSpeaker(n.Speaker); ok {
+			speaker = s
+		}
+		p.SetSpeaker(speaker)
+		p.SetChoices(nil, 0)
+	}
+}
+
+// Stop ends the current dialogue, if any, e.g. when the scene showing it is
+// left.
+func (m *Manager) Stop() {
+	if m.isSpeaking {
+		m.end()
+	}
+}
+
+func (m *Manager) end() {
+	m.speech.Hide()
+	m.isSpeaking = false
+	m.graph = nil
+	m.node = nil
+}
+
+func (m *Manager) runEffects(effects []Effect) {
+	for _, e := range effects {
+		if m.flags != nil {
+			if e.SetFlag != "" {
+				m.flags.Set(e.SetFlag, true)
+			}
+			if e.ClearFlag != "" {
+				m.flags.Set(e.ClearFlag, false)
+			}
+		}
+		if e.Event != "" && m.events != nil {
+			m.events.Publish(event.GenericEvent{EventType: e.Event, Payload: e.Payload})
+		}
+	}
 }
 
 // IsSpeaking returns true if the dialogue manager is currently displaying a message.
@@ -45,7 +176,7 @@ func (m *Manager) IsSpeaking() bool {
 	return m.isSpeaking
 }
 
-// Update updates the dialogue state. It handles input for proceeding.
+// Update updates the dialogue state. It handles input for proceeding and picking choices.
 func (m *Manager) Update() error {
 	if !m.isSpeaking {
 		return nil
@@ -57,30 +188,74 @@ func (m *Manager) Update() error {
 
 	if m.speech.IsSpellingComplete() && !m.waitingForInput {
 		m.waitingForInput = true
+		m.showChoices()
 	}
 
-	if m.waitingForInput {
-		if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-			m.currentLine++
-			if m.currentLine >= len(m.lines) {
-				m.speech.Hide()
-				m.isSpeaking = false
-			} else {
-				m.speech.ResetText()
-				m.waitingForInput = false
-			}
+	if !m.waitingForInput {
+		return nil
+	}
+
+	if len(m.choices) > 0 {
+		switch {
+		case input.IsActionJustPressed(input.ActionUp):
+			m.selected = (m.selected - 1 + len(m.choices)) % len(m.choices)
+			m.showChoices()
+		case input.IsActionJustPressed(input.ActionDown):
+			m.selected = (m.selected + 1) % len(m.choices)
+			m.showChoices()
+		case input.IsActionJustPressed(input.ActionConfirm):
+			m.Choose(m.selected)
 		}
+		return nil
+	}
+
+	if input.IsActionJustPressed(input.ActionConfirm) {
+		m.advance(m.node.Next)
 	}
 	return nil
 }
 
-// Draw draws the speech bubble if it's active.
-func (m *Manager) Draw(screen *ebiten.Image) {
-	if !m.isSpeaking {
+// Choose picks one of the choices offered by the current node.
+func (m *Manager) Choose(i int) {
+	if i < 0 || i >= len(m.choices) {
+		return
+	}
+	c := m.choices[i]
+	m.runEffects(c.Effects)
+	m.advance(c.Next)
+}
+
+// Choices returns the localized text of the choices offered by the current node.
+func (m *Manager) Choices() []string {
+	res := make([]string, len(m.choices))
+	for i, c := range m.choices {
+		res[i] = i18n.T(c.Text)
+	}
+	return res
+}
+
+func (m *Manager) showChoices() {
+	if p, ok := m.speech.(Presenter); ok && len(m.choices) > 0 {
+		p.SetChoices(m.Choices(), m.selected)
+	}
+}
+
+func (m *Manager) advance(next string) {
+	if next == "" {
+		m.end()
 		return
 	}
+	m.enter(next)
+}
 
-	if m.currentLine < len(m.lines) {
-		m.speech.Draw(screen, m.lines[m.currentLine])
+// Draw draws the speech bubble if it's active. Node texts are string table
+// keys, or plain text.
+func (m *Manager) Draw(screen *ebiten.Image) {
+	if !m.isSpeaking || m.node == nil {
+		return
+	}
+	if !m.area.Empty() {
+		screen = screen.SubImage(m.area.Intersect(screen.Bounds())).(*ebiten.Image)
 	}
+	m.speech.Draw(screen, i18n.T(m.node.Text))
 }
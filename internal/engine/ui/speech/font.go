@@ -10,6 +10,8 @@ type SpeechFont struct {
 	source      *font.FontText
 	size        float64
 	LineSpacing float64
+
+	advances map[rune]float64
 }
 
 func NewSpeechFont(source *font.FontText, size, lineSpacing float64) *SpeechFont {
@@ -23,3 +25,7 @@ func NewSpeechFont(source *font.FontText, size, lineSpacing float64) *SpeechFont
 func (f *SpeechFont) Draw(screen *ebiten.Image, msg string, op *text.DrawOptions) {
 	f.source.Draw(screen, msg, f.size, op)
 }
+
+func (f *SpeechFont) Size() float64 {
+	return f.size
+}
@@ -0,0 +1,179 @@
+package speech
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Speaker is a character that talks in dialogues, shown with a portrait and a name plate.
+type Speaker struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Portrait string `json:"portrait,omitempty"`
+
+	portrait *ebiten.Image
+}
+
+func (s *Speaker) PortraitImage() *ebiten.Image {
+	return s.portrait
+}
+
+// Effect runs when a dialogue node is reached or a choice is picked.
+type Effect struct {
+	SetFlag   string         `json:"set_flag,omitempty"`
+	ClearFlag string         `json:"clear_flag,omitempty"`
+	Event     string         `json:"event,omitempty"`
+	Payload   map[string]any `json:"payload,omitempty"`
+}
+
+// Choice is an answer the player can pick. It is only offered when all its
+// conditions hold.
+type Choice struct {
+	Text       string   `json:"text"`
+	Next       string   `json:"next,omitempty"`
+	Conditions []string `json:"conditions,omitempty"`
+	Effects    []Effect `json:"effects,omitempty"`
+}
+
+// Node is a line of a dialogue. It moves on to Next, or to the picked choice.
+// A node without either ends the dialogue.
+type Node struct {
+	ID       string   `json:"id"`
+	Speaker  string   `json:"speaker,omitempty"`
+	Text     string   `json:"text"`
+	Next     string   `json:"next,omitempty"`
+	Choices  []Choice `json:"choices,omitempty"`
+	Effects  []Effect `json:"effects,omitempty"`
+	Position string   `json:"position,omitempty"`
+}
+
+// Graph is a dialogue tree. The first node is the start unless Start is set.
+type Graph struct {
+	ID    string  `json:"id"`
+	Start string  `json:"start,omitempty"`
+	Nodes []*Node `json:"nodes"`
+
+	nodes map[string]*Node
+}
+
+func (g *Graph) index() error {
+	g.nodes = make(map[string]*Node, len(g.Nodes))
+	for _, n := range g.Nodes {
+		if _, ok := g.nodes[n.ID]; ok {
+			return fmt.Errorf("dialogue %s: duplicated node %s", g.ID, n.ID)
+		}
+		g.nodes[n.ID] = n
+	}
+	if g.Start == "" && len(g.Nodes) > 0 {
+		g.Start = g.Nodes[0].ID
+	}
+
+	for _, n := range g.Nodes {
+		targets := []string{n.Next}
+		for _, c := range n.Choices {
+			targets = append(targets, c.Next)
+		}
+		for _, t := range targets {
+			if _, ok := g.nodes[t]; t != "" && !ok {
+				return fmt.Errorf("dialogue %s: node %s goes to unknown node %s", g.ID, n.ID, t)
+			}
+		}
+	}
+	return nil
+}
+
+func (g *Graph) Node(id string) (*Node, bool) {
+	if g.nodes == nil {
+		if err := g.index(); err != nil {
+			return nil, false
+		}
+	}
+	n, ok := g.nodes[id]
+	return n, ok
+}
+
+// linearGraph chains plain lines in a graph without speakers.
+func linearGraph(lines []string, position string) *Graph {
+	g := &Graph{}
+	for i, line := range lines {
+		n := &Node{ID: fmt.Sprint(i), Text: line, Position: position}
+		if i < len(lines)-1 {
+			n.Next = fmt.Sprint(i + 1)
+		}
+		g.Nodes = append(g.Nodes, n)
+	}
+	_ = g.index()
+	return g
+}
+
+var (
+	speakers = make(map[string]*Speaker)
+	graphs   = make(map[string]*Graph)
+)
+
+func RegisterSpeaker(s *Speaker) {
+	speakers[s.ID] = s
+}
+
+func GetSpeaker(id string) (*Speaker, bool) {
+	s, ok := speakers[id]
+	return s, ok
+}
+
+func RegisterGraph(g *Graph) error {
+	if err := g.index(); err != nil {
+		return err
+	}
+	graphs[g.ID] = g
+	return nil
+}
+
+func GetGraph(id string) (*Graph, bool) {
+	g, ok := graphs[id]
+	return g, ok
+}
+
+// LoadSpeakers reads a JSON array of speakers and loads their portraits.
+func LoadSpeakers(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var list []*Speaker
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	for _, s := range list {
+		if s.Portrait != "" {
+			img, _, err := ebitenutil.NewImageFromFile(s.Portrait)
+			if err != nil {
+				return fmt.Errorf("failed to load portrait of %s: %w", s.ID, err)
+			}
+			s.portrait = img
+		}
+		RegisterSpeaker(s)
+	}
+	return nil
+}
+
+// LoadGraphs reads a JSON array of dialogue graphs.
+func LoadGraphs(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var list []*Graph
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	for _, g := range list {
+		if err := RegisterGraph(g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package speech
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/data/flags"
+	"github.com/leandroatallah/firefly/internal/engine/event"
+)
+
+type fakeSpeech struct {
+	SpeechBase
+	speaker *Speaker
+}
+
+func (s *fakeSpeech) Visible() bool                       { return s.Visile() }
+func (s *fakeSpeech) Text(msg string) string              { return s.SpeechBase.Text(msg, s.speed) }
+func (s *fakeSpeech) Draw(screen *ebiten.Image, _ string) {}
+func (s *fakeSpeech) SetSpeaker(speaker *Speaker)         { s.speaker = speaker }
+func (s *fakeSpeech) SetChoices(_ []string, _ int)        {}
+
+func TestGraphUnknownNode(t *testing.T) {
+	g := &Graph{ID: "broken", Nodes: []*Node{{ID: "a", Text: "Hi", Next: "b"}}}
+	if err := RegisterGraph(g); err == nil {
+		t.Error("a node going to an unknown node should fail")
+	}
+}
+
+func TestManagerGraph(t *testing.T) {
+	RegisterSpeaker(&Speaker{ID: "dog", Name: "Dog"})
+	err := RegisterGraph(&Graph{
+		ID: "test_question",
+		Nodes: []*Node{
+			{ID: "ask", Speaker: "dog", Text: "Ready?", Choices: []Choice{
+				{Text: "Yes", Next: "yes", Effects: []Effect{{SetFlag: "ready", Event: "ready_event"}}},
+				{Text: "Again", Conditions: []string{"ready"}, Next: "yes"},
+				{Text: "No"},
+			}},
+			{ID: "yes", Text: "Go!"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := flags.New()
+	events := event.NewManager()
+	published := 0
+	events.Subscribe("ready_event", func(event.Event) { published++ })
+
+	s := &fakeSpeech{SpeechBase: *NewSpeechBase(nil)}
+	m := NewManager(s)
+	m.SetFlags(f)
+	m.SetEventManager(events)
+
+	if err := m.StartGraph("test_question", "bottom", 0); err != nil {
+		t.Fatal(err)
+	}
+	if s.speaker == nil || s.speaker.Name != "Dog" {
+		t.Error("the speaker of the first node should be shown")
+	}
+	if got := m.Choices(); len(got) != 2 || got[1] != "No" {
+		t.Fatalf("choices = %v, want the conditional choice hidden", got)
+	}
+
+	m.Choose(0)
+	if !f.Get("ready") || published != 1 {
+		t.Errorf("choice effects: flag %v, %d events published", f.Get("ready"), published)
+	}
+	if !m.IsSpeaking() || m.node.ID != "yes" || s.speaker != nil {
+		t.Error("the dialogue should move to the narrated node of the choice")
+	}
+
+	m.advance(m.node.Next)
+	if m.IsSpeaking() {
+		t.Error("a node without next ends the dialogue")
+	}
+
+	// The flag now unlocks the second choice
+	_ = m.StartGraph("test_question", "bottom", 0)
+	if got := m.Choices(); len(got) != 3 {
+		t.Errorf("choices = %v, want the conditional choice shown", got)
+	}
+}
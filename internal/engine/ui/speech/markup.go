@@ -0,0 +1,223 @@
+package speech
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// Glyph is a character of a rich text, or an inline icon.
+//
+// Dialogue strings support inline tags:
+//
+//	{color:red}...{/color}  named or #rrggbb color
+//	{wave}...{/wave}        characters bob up and down
+//	{shake}...{/shake}      characters jitter
+//	{speed:2}...{/speed}    frames per character
+//	{pause:30}              wait before the next character
+//	{icon:heart}            inline image registered with RegisterIcon
+//
+// "{{" writes a literal brace.
+type Glyph struct {
+	Rune  rune
+	Icon  string
+	Color color.Color // Nil uses the speech color
+	Wave  bool
+	Shake bool
+	Speed int // Frames to reveal the glyph, zero uses the speech speed
+	Pause int // Extra frames before the glyph is revealed
+}
+
+func (g Glyph) isSpace() bool {
+	return g.Icon == "" && (g.Rune == ' ' || g.Rune == '\n')
+}
+
+// RichText is a dialogue string parsed into glyphs.
+type RichText struct {
+	Source string
+	Glyphs []Glyph
+}
+
+// Line is a range of glyphs drawn on the same line.
+type Line struct {
+	Start, End int
+}
+
+var namedColors = map[string]color.Color{
+	"black":  color.Black,
+	"white":  color.White,
+	"red":    color.RGBA{0xCC, 0x24, 0x40, 0xff},
+	"green":  color.RGBA{0x38, 0x8c, 0x3c, 0xff},
+	"blue":   color.RGBA{0x2c, 0x5c, 0xc4, 0xff},
+	"yellow": color.RGBA{0xd8, 0xa8, 0x20, 0xff},
+	"gray":   color.RGBA{0x80, 0x80, 0x80, 0xff},
+}
+
+func parseColor(s string) (color.Color, bool) {
+	if c, ok := namedColors[s]; ok {
+		return c, true
+	}
+	hex, ok := strings.CutPrefix(s, "#")
+	if !ok || len(hex) != 6 {
+		return nil, false
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, false
+	}
+	return color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xff}, true
+}
+
+// ParseMarkup parses the inline tags of a dialogue string. Unknown or
+// malformed tags are kept as text.
+func ParseMarkup(s string) *RichText {
+	t := &RichText{Source: s}
+
+	var colors []color.Color
+	var speeds []int
+	wave, shake, pause := 0, 0, 0
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if r == '{' {
+			if i+1 < len(runes) && runes[i+1] == '{' {
+				i++
+			} else if end := indexRune(runes[i:], '}'); end > 0 {
+				tag := string(runes[i+1 : i+end])
+				name, arg, _ := strings.Cut(tag, ":")
+				handled := true
+				switch name {
+				case "color":
+					if c, ok := parseColor(arg); ok {
+						colors = append(colors, c)
+					} else {
+						handled = false
+					}
+				case "/color":
+					colors = pop(colors)
+				case "wave":
+					wave++
+				case "/wave":
+					wave = max(wave-1, 0)
+				case "shake":
+					shake++
+				case "/shake":
+					shake = max(shake-1, 0)
+				case "speed":
+					if n, err := strconv.Atoi(arg); err == nil && n > 0 {
+						speeds = append(speeds, n)
+					} else {
+						handled = false
+					}
+				case "/speed":
+					speeds = pop(speeds)
+				case "pause":
+					if n, err := strconv.Atoi(arg); err == nil && n > 0 {
+						pause += n
+					} else {
+						handled = false
+					}
+				case "icon":
+					if arg == "" {
+						handled = false
+						break
+					}
+					t.Glyphs = append(t.Glyphs, Glyph{Icon: arg, Speed: last(speeds), Pause: pause})
+					pause = 0
+				default:
+					handled = false
+				}
+				if handled {
+					i += end
+					continue
+				}
+			}
+		}
+
+		t.Glyphs = append(t.Glyphs, Glyph{
+			Rune:  r,
+			Color: last(colors),
+			Wave:  wave > 0,
+			Shake: shake > 0,
+			Speed: last(speeds),
+			Pause: pause,
+		})
+		pause = 0
+	}
+	return t
+}
+
+func indexRune(runes []rune, r rune) int {
+	for i, c := range runes {
+		if c == r {
+			return i
+		}
+	}
+	return -1
+}
+
+func pop[T any](s []T) []T {
+	if len(s) == 0 {
+		return s
+	}
+	return s[:len(s)-1]
+}
+
+func last[T any](s []T) T {
+	var zero T
+	if len(s) == 0 {
+		return zero
+	}
+	return s[len(s)-1]
+}
+
+// Plain returns the text of the first n glyphs, without icons.
+func (t *RichText) Plain(n int) string {
+	var b strings.Builder
+	for _, g := range t.Glyphs[:min(n, len(t.Glyphs))] {
+		if g.Icon == "" {
+			b.WriteRune(g.Rune)
+		}
+	}
+	return b.String()
+}
+
+// Wrap breaks the glyphs into lines no wider than width, between words when
+// possible. "\n" still forces a break. advance returns the width of a glyph.
+func (t *RichText) Wrap(width float64, advance func(Glyph) float64) []Line {
+	var lines []Line
+	start, lastSpace := 0, -1
+	x := 0.0
+
+	for i := 0; i < len(t.Glyphs); i++ {
+		g := t.Glyphs[i]
+		if g.Icon == "" && g.Rune == '\n' {
+			lines = append(lines, Line{start, i})
+			start, lastSpace, x = i+1, -1, 0
+			continue
+		}
+
+		adv := advance(g)
+		if x+adv > width && i > start && !g.isSpace() {
+			if lastSpace > start {
+				lines = append(lines, Line{start, lastSpace})
+				start = lastSpace + 1
+			} else {
+				lines = append(lines, Line{start, i})
+				start = i
+			}
+			lastSpace = -1
+			x = 0
+			for j := start; j < i; j++ {
+				x += advance(t.Glyphs[j])
+			}
+		}
+
+		if g.isSpace() {
+			lastSpace = i
+		}
+		x += adv
+	}
+	return append(lines, Line{start, len(t.Glyphs)})
+}
@@ -0,0 +1,50 @@
+package speech
+
+import "testing"
+
+func TestParseMarkup(t *testing.T) {
+	rt := ParseMarkup("{color:red}Hi{/color} {wave}não{/wave}{pause:10}!{speed:2}{icon:heart}{/speed} {bogus} {{")
+
+	if got, want := rt.Plain(len(rt.Glyphs)), "Hi não! {bogus} {"; got != want {
+		t.Errorf("Plain = %q, want %q", got, want)
+	}
+	if rt.Glyphs[0].Color == nil || rt.Glyphs[2].Color != nil {
+		t.Error("color should only apply inside its tag")
+	}
+	if !rt.Glyphs[4].Wave || rt.Glyphs[6].Wave {
+		t.Error("wave should only apply inside its tag")
+	}
+	if rt.Glyphs[6].Rune != '!' || rt.Glyphs[6].Pause != 10 {
+		t.Errorf("pause should delay the next glyph, got %+v", rt.Glyphs[6])
+	}
+	if icon := rt.Glyphs[7]; icon.Icon != "heart" || icon.Speed != 2 {
+		t.Errorf("icon glyph = %+v", icon)
+	}
+	if rt.Glyphs[8].Speed != 0 {
+		t.Error("speed should reset after its closing tag")
+	}
+}
+
+func TestWrap(t *testing.T) {
+	rt := ParseMarkup("one two three\nfour abcdefghij")
+	advance := func(Glyph) float64 { return 1 }
+
+	var got []string
+	for _, l := range rt.Wrap(8, advance) {
+		got = append(got, linePlain(rt, l))
+	}
+	want := []string{"one two", "three", "four", "abcdefgh", "ij"}
+	if len(got) != len(want) {
+		t.Fatalf("lines = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func linePlain(rt *RichText, l Line) string {
+	sub := &RichText{Glyphs: rt.Glyphs[l.Start:l.End]}
+	return sub.Plain(len(sub.Glyphs))
+}
@@ -0,0 +1,137 @@
+package speech
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"os"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+)
+
+const (
+	waveAmplitude = 1.5
+	iconGap       = 1
+)
+
+var icons = make(map[string]*ebiten.Image)
+
+func RegisterIcon(name string, img *ebiten.Image) {
+	icons[name] = img
+}
+
+func GetIcon(name string) (*ebiten.Image, bool) {
+	img, ok := icons[name]
+	return img, ok
+}
+
+// IconData describes an inline icon. Width and height crop the first frame of
+// a sprite sheet; zero uses the whole image.
+type IconData struct {
+	Name   string `json:"name"`
+	Image  string `json:"image"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+}
+
+// LoadIcons reads a JSON array of inline icons and loads their images.
+func LoadIcons(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var list []IconData
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	for _, d := range list {
+		img, _, err := ebitenutil.NewImageFromFile(d.Image)
+		if err != nil {
+			return fmt.Errorf("failed to load icon %s: %w", d.Name, err)
+		}
+		if d.Width > 0 && d.Height > 0 {
+			img = img.SubImage(image.Rect(0, 0, d.Width, d.Height)).(*ebiten.Image)
+		}
+		RegisterIcon(d.Name, img)
+	}
+	return nil
+}
+
+// iconScale fits an icon to the height of the text.
+func (f *SpeechFont) iconScale(img *ebiten.Image) float64 {
+	return f.size / float64(img.Bounds().Dy())
+}
+
+// Advance returns the width of a glyph.
+func (f *SpeechFont) Advance(g Glyph) float64 {
+	if g.Icon != "" {
+		img, ok := GetIcon(g.Icon)
+		if !ok {
+			return 0
+		}
+		return float64(img.Bounds().Dx())*f.iconScale(img) + iconGap
+	}
+	if w, ok := f.advances[g.Rune]; ok {
+		return w
+	}
+	w := f.size
+	if f.source != nil {
+		w, _ = f.source.Measure(string(g.Rune), f.size, f.LineSpacing)
+	}
+	if f.advances == nil {
+		f.advances = make(map[rune]float64)
+	}
+	f.advances[g.Rune] = w
+	return w
+}
+
+// Wrap breaks a rich text into lines that fit width.
+func (f *SpeechFont) Wrap(t *RichText, width float64) []Line {
+	return t.Wrap(width, f.Advance)
+}
+
+// DrawRich draws the first n glyphs of the wrapped lines at x, y. count drives
+// the wave and shake effects; glyphs without a color use base.
+func (f *SpeechFont) DrawRich(screen *ebiten.Image, t *RichText, lines []Line, n int, x, y float64, base color.Color, count int) {
+	for row, line := range lines {
+		gx := x
+		gy := y + float64(row)*f.LineSpacing
+		for i := line.Start; i < line.End && i < n; i++ {
+			g := t.Glyphs[i]
+			dx, dy := 0.0, 0.0
+			if g.Wave {
+				dy = math.Sin(float64(count)/6+float64(i)/2) * waveAmplitude
+			}
+			if g.Shake {
+				// Cheap per glyph jitter that changes every few frames
+				h := uint32(i*7919+count/3*104729) * 2654435761
+				dx = float64(h>>8%3) - 1
+				dy += float64(h>>16%3) - 1
+			}
+
+			if g.Icon != "" {
+				if img, ok := GetIcon(g.Icon); ok {
+					s := f.iconScale(img)
+					op := &ebiten.DrawImageOptions{}
+					op.GeoM.Scale(s, s)
+					op.GeoM.Translate(gx+dx, gy+dy)
+					screen.DrawImage(img, op)
+				}
+			} else if g.Rune != ' ' && g.Rune != '\n' {
+				c := base
+				if g.Color != nil {
+					c = g.Color
+				}
+				op := &text.DrawOptions{}
+				op.GeoM.Translate(gx+dx, gy+dy)
+				op.ColorScale.ScaleWithColor(c)
+				f.Draw(screen, string(g.Rune), op)
+			}
+			gx += f.Advance(g)
+		}
+	}
+}
@@ -25,6 +25,8 @@ type SpeechBase struct {
 	count         int
 	FontSource    *SpeechFont
 	text          string
+	rich          *RichText
+	wait          int
 	spellingCount int
 	spellingDelay int
 	position      string
@@ -41,19 +43,32 @@ func NewSpeechBase(fontSource *SpeechFont) *SpeechBase {
 
 func (s *SpeechBase) Update() error {
 	s.count++
-	if s.count > s.spellingDelay {
-		effectiveCount := s.count - s.spellingDelay
-		speed := s.speed
-		if speed <= 0 {
-			speed = 4
-		}
-		if effectiveCount > 0 && effectiveCount%speed == 0 {
+	if s.count > s.spellingDelay && s.rich != nil {
+		s.wait--
+		for s.wait <= 0 && s.spellingCount < len(s.rich.Glyphs) {
 			s.spellingCount++
+			s.wait += s.glyphWait(s.spellingCount)
 		}
 	}
 	return nil
 }
 
+// glyphWait returns the frames to wait before the i-th glyph is revealed.
+func (s *SpeechBase) glyphWait(i int) int {
+	if i >= len(s.rich.Glyphs) {
+		return 0
+	}
+	g := s.rich.Glyphs[i]
+	speed := g.Speed
+	if speed <= 0 {
+		speed = s.speed
+	}
+	if speed <= 0 {
+		speed = 4
+	}
+	return speed + g.Pause
+}
+
 func (s *SpeechBase) Count() int {
 	return s.count
 }
@@ -78,34 +93,47 @@ func (s *SpeechBase) SetSpellingDelay(d int) {
 	s.spellingDelay = d
 }
 
+// Text returns the spelled part of msg, without markup.
 func (s *SpeechBase) Text(msg string, speed int) string {
-	s.text = msg // Store the full message
+	rich, n := s.RichText(msg, speed)
+	return rich.Plain(n)
+}
+
+// RichText parses msg and returns it with the number of glyphs spelled so far.
+func (s *SpeechBase) RichText(msg string, speed int) (*RichText, int) {
+	if s.rich == nil || msg != s.text {
+		s.text = msg // Store the full message
+		s.rich = ParseMarkup(msg)
+		s.wait = s.glyphWait(s.spellingCount)
+	}
 
 	if speed > 0 && speed != s.speed {
 		s.speed = speed
 	}
 
 	if s.count < s.spellingDelay {
-		return ""
+		return s.rich, 0
 	}
-
-	limit := min(s.spellingCount, len(s.text))
-	return s.text[:limit]
+	return s.rich, min(s.spellingCount, len(s.rich.Glyphs))
 }
 
 func (s *SpeechBase) IsSpellingComplete() bool {
-	// Check if the number of characters spelled is greater than or equal to the total message length.
-	// Also check that the message is not empty.
-	return s.spellingCount >= len(s.text) && len(s.text) > 0
+	// Check if every glyph has been spelled. Also check that the message is not empty.
+	return s.rich != nil && s.spellingCount >= len(s.rich.Glyphs) && len(s.rich.Glyphs) > 0
 }
 
 func (s *SpeechBase) CompleteSpelling() {
-	s.spellingCount = len(s.text)
+	if s.rich != nil {
+		s.spellingCount = len(s.rich.Glyphs)
+	}
 }
 
+// ResetText starts spelling a new message. The message is parsed the next
+// time it is drawn.
 func (s *SpeechBase) ResetText() {
 	s.spellingCount = 0
 	s.count = 0
+	s.rich = nil
 }
 
 func (s *SpeechBase) Image(screen *ebiten.Image) *ebiten.Image {
@@ -0,0 +1,82 @@
+package widget
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type Direction int
+
+const (
+	Vertical Direction = iota
+	Horizontal
+)
+
+// Box stacks its children in a row or a column, separated by the theme
+// spacing. Children are stretched across the box.
+type Box struct {
+	Base
+	Direction Direction
+	children  []Widget
+}
+
+func NewVBox(children ...Widget) *Box {
+	return &Box{Direction: Vertical, children: children}
+}
+
+func NewHBox(children ...Widget) *Box {
+	return &Box{Direction: Horizontal, children: children}
+}
+
+func (b *Box) Add(children ...Widget) {
+	b.children = append(b.children, children...)
+}
+
+func (b *Box) Children() []Widget {
+	return b.children
+}
+
+func (b *Box) MinSize(theme *Theme) image.Point {
+	var size image.Point
+	for i, child := range b.children {
+		m := child.MinSize(theme)
+		gap := 0
+		if i > 0 {
+			gap = theme.Spacing
+		}
+		if b.Direction == Vertical {
+			size.X = max(size.X, m.X)
+			size.Y += m.Y + gap
+		} else {
+			size.X += m.X + gap
+			size.Y = max(size.Y, m.Y)
+		}
+	}
+	return size
+}
+
+func (b *Box) Layout(theme *Theme) {
+	pos := b.bounds.Min
+	for _, child := range b.children {
+		m := child.MinSize(theme)
+		var r image.Rectangle
+		if b.Direction == Vertical {
+			r = image.Rect(b.bounds.Min.X, pos.Y, b.bounds.Max.X, pos.Y+m.Y)
+			pos.Y += m.Y + theme.Spacing
+		} else {
+			r = image.Rect(pos.X, b.bounds.Min.Y, pos.X+m.X, b.bounds.Max.Y)
+			pos.X += m.X + theme.Spacing
+		}
+		child.SetBounds(r)
+		if c, ok := child.(Container); ok {
+			c.Layout(theme)
+		}
+	}
+}
+
+func (b *Box) Draw(screen *ebiten.Image, theme *Theme) {
+	for _, child := range b.children {
+		child.Draw(screen, theme)
+	}
+}
@@ -0,0 +1,33 @@
+package widget
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type Button struct {
+	FocusBase
+	Text    string
+	OnClick func()
+}
+
+func NewButton(text string, onClick func()) *Button {
+	return &Button{Text: text, OnClick: onClick}
+}
+
+func (b *Button) MinSize(theme *Theme) image.Point {
+	return theme.measure(b.Text)
+}
+
+func (b *Button) HandleInput(in *Input) bool {
+	if (in.Confirm || b.clicked(in)) && b.OnClick != nil {
+		b.OnClick()
+	}
+	return false
+}
+
+func (b *Button) Draw(screen *ebiten.Image, theme *Theme) {
+	theme.drawMarker(screen, b)
+	theme.drawText(screen, b.Text, b.bounds.Min.X, b.bounds.Min.Y, theme.textColor(b))
+}
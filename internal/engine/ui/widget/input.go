@@ -0,0 +1,38 @@
+package widget
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/leandroatallah/firefly/internal/engine/input"
+)
+
+// Input is the UI input of a frame. Directions and buttons are true on the
+// frame they are pressed, from the keyboard or a gamepad.
+type Input struct {
+	Up, Down, Left, Right bool
+	Confirm, Cancel       bool
+
+	Mouse      image.Point
+	MouseMoved bool
+	Click      bool
+}
+
+// ReadInput reads the input of the current frame. last is the mouse position
+// of the previous frame.
+func ReadInput(last image.Point) *Input {
+	x, y := ebiten.CursorPosition()
+	mouse := image.Pt(x, y)
+	return &Input{
+		Up:         input.IsActionJustPressed(input.ActionUp),
+		Down:       input.IsActionJustPressed(input.ActionDown),
+		Left:       input.IsActionJustPressed(input.ActionLeft),
+		Right:      input.IsActionJustPressed(input.ActionRight),
+		Confirm:    input.IsActionJustPressed(input.ActionConfirm),
+		Cancel:     input.IsActionJustPressed(input.ActionCancel),
+		Mouse:      mouse,
+		MouseMoved: mouse != last,
+		Click:      inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft),
+	}
+}
@@ -0,0 +1,29 @@
+package widget
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+type Label struct {
+	Base
+	Text     string
+	Centered bool
+}
+
+func NewLabel(text string) *Label {
+	return &Label{Text: text}
+}
+
+func (l *Label) MinSize(theme *Theme) image.Point {
+	return theme.measure(l.Text)
+}
+
+func (l *Label) Draw(screen *ebiten.Image, theme *Theme) {
+	x := l.bounds.Min.X
+	if l.Centered {
+		x += (l.bounds.Dx() - theme.measure(l.Text).X) / 2
+	}
+	theme.drawText(screen, l.Text, x, l.bounds.Min.Y, theme.Text)
+}
@@ -0,0 +1,120 @@
+package widget
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// List shows a scrolling column of items. Up and down move the selection
+// inside the list until an end is reached, then the focus leaves it.
+type List struct {
+	FocusBase
+	Items    []string
+	Selected int
+	Rows     int // Visible rows, all when zero
+	OnSelect func(index int)
+
+	offset int
+	row    int // Row height, set on layout
+}
+
+func NewList(items []string, rows int, onSelect func(int)) *List {
+	return &List{Items: items, Rows: rows, OnSelect: onSelect}
+}
+
+func (l *List) visibleRows() int {
+	if l.Rows <= 0 || l.Rows > len(l.Items) {
+		return len(l.Items)
+	}
+	return l.Rows
+}
+
+func (l *List) MinSize(theme *Theme) image.Point {
+	l.row = int(theme.FontSize) + theme.Spacing
+	w := 0
+	for _, item := range l.Items {
+		w = max(w, theme.measure(item).X)
+	}
+	return image.Pt(w, l.visibleRows()*l.row-theme.Spacing)
+}
+
+// Select moves the selection and scrolls it into view.
+func (l *List) Select(i int) {
+	if len(l.Items) == 0 {
+		return
+	}
+	l.Selected = min(max(i, 0), len(l.Items)-1)
+	rows := l.visibleRows()
+	if l.Selected < l.offset {
+		l.offset = l.Selected
+	}
+	if l.Selected >= l.offset+rows {
+		l.offset = l.Selected - rows + 1
+	}
+}
+
+// itemAt returns the item under a point, or -1.
+func (l *List) itemAt(p image.Point) int {
+	if !p.In(l.bounds) || l.row == 0 {
+		return -1
+	}
+	i := l.offset + (p.Y-l.bounds.Min.Y)/l.row
+	if i >= len(l.Items) || i >= l.offset+l.visibleRows() {
+		return -1
+	}
+	return i
+}
+
+func (l *List) HandleInput(in *Input) bool {
+	switch {
+	case in.Up && l.Selected > 0:
+		l.Select(l.Selected - 1)
+		return true
+	case in.Down && l.Selected < len(l.Items)-1:
+		l.Select(l.Selected + 1)
+		return true
+	case in.Confirm:
+		l.activate()
+	case in.Click:
+		if i := l.itemAt(in.Mouse); i >= 0 {
+			l.Select(i)
+			l.activate()
+		}
+	case in.MouseMoved:
+		if i := l.itemAt(in.Mouse); i >= 0 {
+			l.Select(i)
+		}
+	}
+	return false
+}
+
+func (l *List) activate() {
+	if l.OnSelect != nil && len(l.Items) > 0 {
+		l.OnSelect(l.Selected)
+	}
+}
+
+func (l *List) Draw(screen *ebiten.Image, theme *Theme) {
+	h := int(theme.FontSize) + theme.Spacing
+	end := min(l.offset+l.visibleRows(), len(l.Items))
+	for i := l.offset; i < end; i++ {
+		y := l.bounds.Min.Y + (i-l.offset)*h
+		c := theme.Text
+		if !l.Enabled() {
+			c = theme.Disabled
+		} else if i == l.Selected && l.Focused() {
+			c = theme.Focus
+			theme.drawText(screen, ">", l.bounds.Min.X-int(theme.FontSize)-2, y, c)
+		}
+		theme.drawText(screen, l.Items[i], l.bounds.Min.X, y, c)
+	}
+
+	// Scroll hints
+	if l.offset > 0 {
+		theme.drawText(screen, "^", l.bounds.Max.X+2, l.bounds.Min.Y, theme.Text)
+	}
+	if end < len(l.Items) {
+		theme.drawText(screen, "v", l.bounds.Max.X+2, l.bounds.Max.Y-int(theme.FontSize), theme.Text)
+	}
+}
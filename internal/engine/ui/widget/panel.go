@@ -0,0 +1,42 @@
+package widget
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Panel draws the theme's 9-slice behind its content.
+type Panel struct {
+	Base
+	Content Widget
+}
+
+func NewPanel(content Widget) *Panel {
+	return &Panel{Content: content}
+}
+
+func (p *Panel) Children() []Widget {
+	return []Widget{p.Content}
+}
+
+func (p *Panel) MinSize(theme *Theme) image.Point {
+	pad := theme.PanelPadding * 2
+	return p.Content.MinSize(theme).Add(image.Pt(pad, pad))
+}
+
+func (p *Panel) Layout(theme *Theme) {
+	p.Content.SetBounds(p.bounds.Inset(theme.PanelPadding))
+	if c, ok := p.Content.(Container); ok {
+		c.Layout(theme)
+	}
+}
+
+func (p *Panel) Draw(screen *ebiten.Image, theme *Theme) {
+	if theme.Panel != nil {
+		theme.Panel.Draw(screen, p.bounds.Dx(), p.bounds.Dy(), func(opts *ebiten.DrawImageOptions) {
+			opts.GeoM.Translate(float64(p.bounds.Min.X), float64(p.bounds.Min.Y))
+		})
+	}
+	p.Content.Draw(screen, theme)
+}
@@ -0,0 +1,111 @@
+package widget
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Screen lays out a widget tree centered on the screen and moves the focus
+// between its focusable widgets: up and down with the keyboard or a gamepad,
+// or by hovering with the mouse.
+type Screen struct {
+	Theme    *Theme
+	Root     Widget
+	OnCancel func()
+
+	focus     Focusable
+	lastMouse image.Point
+}
+
+func NewScreen(theme *Theme, root Widget) *Screen {
+	return &Screen{Theme: theme, Root: root}
+}
+
+// Layout centers the root widget at its minimum size in area and focuses
+// the first focusable widget.
+func (s *Screen) Layout(area image.Rectangle) {
+	size := s.Root.MinSize(s.Theme)
+	min := area.Min.Add(area.Size().Sub(size).Div(2))
+	s.Root.SetBounds(image.Rectangle{Min: min, Max: min.Add(size)})
+	if c, ok := s.Root.(Container); ok {
+		c.Layout(s.Theme)
+	}
+
+	if s.focus == nil {
+		if f := focusables(s.Root); len(f) > 0 {
+			s.SetFocus(f[0])
+		}
+	}
+}
+
+func (s *Screen) Focus() Focusable {
+	return s.focus
+}
+
+func (s *Screen) SetFocus(f Focusable) {
+	if s.focus != nil {
+		s.focus.SetFocused(false)
+	}
+	s.focus = f
+	if f != nil {
+		f.SetFocused(true)
+	}
+}
+
+// Update reads the input of the frame and handles it.
+func (s *Screen) Update() {
+	in := ReadInput(s.lastMouse)
+	s.lastMouse = in.Mouse
+	s.HandleInput(in)
+}
+
+func (s *Screen) HandleInput(in *Input) {
+	items := focusables(s.Root)
+
+	// The mouse focuses the widget under it
+	if in.MouseMoved || in.Click {
+		for _, f := range items {
+			if in.Mouse.In(f.Bounds()) {
+				if f != s.focus {
+					s.SetFocus(f)
+				}
+				break
+			}
+		}
+	}
+
+	if s.focus != nil && s.focus.HandleInput(in) {
+		return
+	}
+
+	switch {
+	case in.Up:
+		s.moveFocus(items, -1)
+	case in.Down:
+		s.moveFocus(items, 1)
+	case in.Cancel:
+		if s.OnCancel != nil {
+			s.OnCancel()
+		}
+	}
+}
+
+func (s *Screen) moveFocus(items []Focusable, dir int) {
+	if len(items) == 0 {
+		return
+	}
+	i := 0
+	for j, f := range items {
+		if f == s.focus {
+			i = j + dir
+			break
+		}
+	}
+	i = (i + len(items)) % len(items)
+	s.SetFocus(items[i])
+}
+
+func (s *Screen) Draw(screen *ebiten.Image) {
+	s.Root.Draw(screen, s.Theme)
+}
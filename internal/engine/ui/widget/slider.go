@@ -0,0 +1,87 @@
+package widget
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const sliderTrackWidth = 64
+
+// Slider picks a value in a range, stepping with left and right or jumping to
+// the clicked point of its track.
+type Slider struct {
+	FocusBase
+	Text     string
+	Value    float64
+	Min, Max float64
+	Step     float64
+	OnChange func(value float64)
+}
+
+func NewSlider(text string, value, min, max, step float64, onChange func(float64)) *Slider {
+	return &Slider{Text: text, Value: value, Min: min, Max: max, Step: step, OnChange: onChange}
+}
+
+func (s *Slider) MinSize(theme *Theme) image.Point {
+	label := theme.measure(s.Text)
+	return image.Pt(label.X+theme.Spacing*2+sliderTrackWidth, label.Y)
+}
+
+func (s *Slider) track() image.Rectangle {
+	b := s.bounds
+	return image.Rect(b.Max.X-sliderTrackWidth, b.Min.Y, b.Max.X, b.Max.Y)
+}
+
+func (s *Slider) SetValue(v float64) {
+	v = min(max(v, s.Min), s.Max)
+	if s.Step > 0 {
+		v = s.Min + math.Round((v-s.Min)/s.Step)*s.Step
+		v = min(v, s.Max)
+	}
+	if v == s.Value {
+		return
+	}
+	s.Value = v
+	if s.OnChange != nil {
+		s.OnChange(v)
+	}
+}
+
+func (s *Slider) HandleInput(in *Input) bool {
+	switch {
+	case in.Left:
+		s.SetValue(s.Value - s.Step)
+		return true
+	case in.Right:
+		s.SetValue(s.Value + s.Step)
+		return true
+	case in.Click && in.Mouse.In(s.track()):
+		t := s.track()
+		ratio := float64(in.Mouse.X-t.Min.X) / float64(t.Dx()-1)
+		s.SetValue(s.Min + ratio*(s.Max-s.Min))
+	}
+	return false
+}
+
+func (s *Slider) ratio() float64 {
+	if s.Max == s.Min {
+		return 0
+	}
+	return (s.Value - s.Min) / (s.Max - s.Min)
+}
+
+func (s *Slider) Draw(screen *ebiten.Image, theme *Theme) {
+	c := theme.textColor(s)
+	theme.drawMarker(screen, s)
+	theme.drawText(screen, s.Text, s.bounds.Min.X, s.bounds.Min.Y, c)
+
+	t := s.track()
+	y := float32(t.Min.Y+t.Max.Y) / 2
+	vector.StrokeLine(screen, float32(t.Min.X), y, float32(t.Max.X), y, 2, theme.Track, false)
+	fill := float32(t.Min.X) + float32(s.ratio())*float32(t.Dx())
+	vector.StrokeLine(screen, float32(t.Min.X), y, fill, y, 2, c, false)
+	vector.DrawFilledRect(screen, fill-2, float32(t.Min.Y), 4, float32(t.Dy()), c, false)
+}
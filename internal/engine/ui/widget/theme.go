@@ -0,0 +1,104 @@
+package widget
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"unicode/utf8"
+
+	ebitenuiimage "github.com/ebitenui/ebitenui/image"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/leandroatallah/firefly/internal/engine/assets/font"
+)
+
+const panelImage = "assets/images/9-slice-speech.png"
+
+// Theme holds the look shared by the widgets of a screen.
+type Theme struct {
+	Font     *font.FontText
+	FontSize float64
+
+	Text     color.Color
+	Focus    color.Color
+	Disabled color.Color
+	Track    color.Color // Slider background
+
+	Panel        *ebitenuiimage.NineSlice
+	PanelPadding int
+	Spacing      int
+
+	// Localize translates the texts of the widgets, e.g. string table keys.
+	// Nil draws them as they are.
+	Localize func(msg string) string
+}
+
+// NewTheme creates the default theme, with panels drawn from the speech bubble 9-slice.
+func NewTheme(fontText *font.FontText) (*Theme, error) {
+	img, _, err := ebitenutil.NewImageFromFile(panelImage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Theme{
+		Font:         fontText,
+		FontSize:     8,
+		Text:         color.Black,
+		Focus:        color.RGBA{0xCC, 0x24, 0x40, 0xff},
+		Disabled:     color.Gray{0x88},
+		Track:        color.Gray{0xbb},
+		Panel:        ebitenuiimage.NewNineSlice(img, [3]int{4, 4, 4}, [3]int{4, 4, 4}),
+		PanelPadding: 10,
+		Spacing:      6,
+	}, nil
+}
+
+// measure returns the size of a single line of text. Without a font it assumes
+// a monospace font as wide as it is tall, like the main font.
+func (t *Theme) measure(msg string) image.Point {
+	msg = t.text(msg)
+	if t.Font == nil {
+		return image.Pt(utf8.RuneCountInString(msg)*int(t.FontSize), int(t.FontSize))
+	}
+	w, h := t.Font.Measure(msg, t.FontSize, t.FontSize)
+	return image.Pt(int(math.Ceil(w)), int(math.Ceil(h)))
+}
+
+func (t *Theme) drawText(screen *ebiten.Image, msg string, x, y int, c color.Color) {
+	if t.Font == nil {
+		return
+	}
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(float64(x), float64(y))
+	op.ColorScale.ScaleWithColor(c)
+	t.Font.Draw(screen, t.text(msg), t.FontSize, op)
+}
+
+func (t *Theme) text(msg string) string {
+	if t.Localize == nil {
+		return msg
+	}
+	return t.Localize(msg)
+}
+
+// textColor returns the color of a focusable widget's text.
+func (t *Theme) textColor(f Focusable) color.Color {
+	switch {
+	case !f.Enabled():
+		return t.Disabled
+	case f.Focused():
+		return t.Focus
+	default:
+		return t.Text
+	}
+}
+
+// drawMarker draws the focus marker left of a widget.
+func (t *Theme) drawMarker(screen *ebiten.Image, f Focusable) {
+	if !f.Focused() || t.Font == nil {
+		return
+	}
+	b := f.Bounds()
+	t.drawText(screen, ">", b.Min.X-int(t.FontSize)-2, b.Min.Y, t.Focus)
+}
@@ -0,0 +1,54 @@
+package widget
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Toggle switches a setting on and off with confirm, left, right or a click.
+type Toggle struct {
+	FocusBase
+	Text     string
+	Value    bool
+	OnChange func(value bool)
+}
+
+func NewToggle(text string, value bool, onChange func(bool)) *Toggle {
+	return &Toggle{Text: text, Value: value, OnChange: onChange}
+}
+
+func (t *Toggle) state() string {
+	if t.Value {
+		return "ON"
+	}
+	return "OFF"
+}
+
+func (t *Toggle) MinSize(theme *Theme) image.Point {
+	label := theme.measure(t.Text)
+	// Room for the longest state
+	state := theme.measure("OFF")
+	return image.Pt(label.X+theme.Spacing*2+state.X, max(label.Y, state.Y))
+}
+
+func (t *Toggle) HandleInput(in *Input) bool {
+	if in.Confirm || in.Left || in.Right || t.clicked(in) {
+		t.Value = !t.Value
+		if t.OnChange != nil {
+			t.OnChange(t.Value)
+		}
+		return in.Left || in.Right
+	}
+	return false
+}
+
+func (t *Toggle) Draw(screen *ebiten.Image, theme *Theme) {
+	c := theme.textColor(t)
+	theme.drawMarker(screen, t)
+	theme.drawText(screen, t.Text, t.bounds.Min.X, t.bounds.Min.Y, c)
+
+	state := t.state()
+	x := t.bounds.Max.X - theme.measure(state).X
+	theme.drawText(screen, state, x, t.bounds.Min.Y, c)
+}
@@ -0,0 +1,87 @@
+package widget
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Widget is an element of a UI screen. Its bounds are in screen coordinates
+// and are set by the parent container when the screen is laid out.
+type Widget interface {
+	Bounds() image.Rectangle
+	SetBounds(r image.Rectangle)
+	// MinSize is the smallest size the widget can be laid out at.
+	MinSize(theme *Theme) image.Point
+	Draw(screen *ebiten.Image, theme *Theme)
+}
+
+// Focusable widgets can be selected with the keyboard, a gamepad or the mouse.
+type Focusable interface {
+	Widget
+	Focused() bool
+	SetFocused(focused bool)
+	Enabled() bool
+	// HandleInput reacts to the input while the widget is focused. It returns
+	// true when it consumed a direction, e.g. a slider consuming left and right,
+	// so the screen doesn't move the focus.
+	HandleInput(in *Input) bool
+}
+
+// Container is a widget that lays out other widgets.
+type Container interface {
+	Widget
+	Children() []Widget
+	Layout(theme *Theme)
+}
+
+// Base keeps the bounds of a widget.
+type Base struct {
+	bounds image.Rectangle
+}
+
+func (b *Base) Bounds() image.Rectangle {
+	return b.bounds
+}
+
+func (b *Base) SetBounds(r image.Rectangle) {
+	b.bounds = r
+}
+
+// FocusBase keeps the focus and enabled state of a focusable widget.
+type FocusBase struct {
+	Base
+	focused  bool
+	Disabled bool
+}
+
+func (b *FocusBase) Focused() bool {
+	return b.focused
+}
+
+func (b *FocusBase) SetFocused(focused bool) {
+	b.focused = focused
+}
+
+func (b *FocusBase) Enabled() bool {
+	return !b.Disabled
+}
+
+// clicked reports whether the mouse clicked inside the widget this frame.
+func (b *Base) clicked(in *Input) bool {
+	return in.Click && in.Mouse.In(b.bounds)
+}
+
+// focusables returns the enabled focusable widgets of a tree, in layout order.
+func focusables(w Widget) []Focusable {
+	var res []Focusable
+	if f, ok := w.(Focusable); ok && f.Enabled() {
+		res = append(res, f)
+	}
+	if c, ok := w.(Container); ok {
+		for _, child := range c.Children() {
+			res = append(res, focusables(child)...)
+		}
+	}
+	return res
+}
@@ -0,0 +1,99 @@
+package widget
+
+import (
+	"image"
+	"testing"
+)
+
+func newTestTheme() *Theme {
+	return &Theme{FontSize: 8, Spacing: 4, PanelPadding: 6}
+}
+
+func TestScreenFocusNavigation(t *testing.T) {
+	clicked := ""
+	start := NewButton("Start", func() { clicked = "start" })
+	disabled := NewButton("Load", nil)
+	disabled.Disabled = true
+	slider := NewSlider("Volume", 0.5, 0, 1, 0.25, nil)
+	quit := NewButton("Quit", func() { clicked = "quit" })
+
+	s := NewScreen(newTestTheme(), NewPanel(NewVBox(NewLabel("Title"), start, disabled, slider, quit)))
+	s.Layout(image.Rect(0, 0, 320, 224))
+
+	if s.Focus() != start {
+		t.Fatal("the first focusable widget should be focused")
+	}
+
+	s.HandleInput(&Input{Down: true})
+	if s.Focus() != slider {
+		t.Fatal("down should skip the disabled button")
+	}
+
+	s.HandleInput(&Input{Right: true})
+	if slider.Value != 0.75 || s.Focus() != slider {
+		t.Errorf("right should step the focused slider, got %v", slider.Value)
+	}
+
+	s.HandleInput(&Input{Down: true})
+	s.HandleInput(&Input{Down: true})
+	if s.Focus() != start {
+		t.Fatal("the focus should wrap around")
+	}
+
+	s.HandleInput(&Input{Up: true})
+	s.HandleInput(&Input{Confirm: true})
+	if clicked != "quit" {
+		t.Errorf("confirm clicked %q, want quit", clicked)
+	}
+
+	// The mouse focuses and clicks the widget under it
+	center := start.Bounds().Min.Add(start.Bounds().Size().Div(2))
+	s.HandleInput(&Input{Mouse: center, MouseMoved: true, Click: true})
+	if s.Focus() != start || clicked != "start" {
+		t.Errorf("mouse click focused %v and clicked %q", s.Focus(), clicked)
+	}
+}
+
+func TestBoxLayout(t *testing.T) {
+	theme := newTestTheme()
+	a, b := NewLabel("ab"), NewLabel("abcd")
+	box := NewVBox(a, b)
+
+	if got, want := box.MinSize(theme), image.Pt(32, 20); got != want {
+		t.Fatalf("min size = %v, want %v", got, want)
+	}
+
+	box.SetBounds(image.Rect(10, 10, 60, 30))
+	box.Layout(theme)
+	if got, want := b.Bounds(), image.Rect(10, 22, 60, 30); got != want {
+		t.Errorf("second child bounds = %v, want %v", got, want)
+	}
+}
+
+func TestSliderClamp(t *testing.T) {
+	changes := 0
+	s := NewSlider("Volume", 1, 0, 1, 0.1, func(float64) { changes++ })
+	s.HandleInput(&Input{Right: true})
+	if s.Value != 1 || changes != 0 {
+		t.Errorf("value = %v after %d changes, want it to stay at the max", s.Value, changes)
+	}
+}
+
+func TestListScrolling(t *testing.T) {
+	l := NewList([]string{"1", "2", "3", "4", "5"}, 2, nil)
+	l.MinSize(newTestTheme())
+
+	for range 3 {
+		if !l.HandleInput(&Input{Down: true}) {
+			t.Fatal("down should be consumed inside the list")
+		}
+	}
+	if l.Selected != 3 || l.offset != 2 {
+		t.Errorf("selected %d offset %d, want 3 and 2", l.Selected, l.offset)
+	}
+
+	l.Select(4)
+	if l.HandleInput(&Input{Down: true}) {
+		t.Error("down at the end of the list should move the focus")
+	}
+}
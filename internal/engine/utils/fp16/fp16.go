@@ -8,3 +8,8 @@ func To16(value int) int {
 func From16(value int) int {
 	return value / scale
 }
+
+// FromFloat converts a fractional pixel value, e.g. a speed read from JSON.
+func FromFloat(value float64) int {
+	return int(value * scale)
+}
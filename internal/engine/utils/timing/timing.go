@@ -10,3 +10,8 @@ const TPS = 60
 func FromDuration(d time.Duration) int {
 	return int(d.Seconds() * TPS)
 }
+
+// ToDuration converts a number of frames (ticks) to a time.Duration based on TPS.
+func ToDuration(frames int) time.Duration {
+	return time.Duration(frames) * time.Second / TPS
+}
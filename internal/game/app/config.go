@@ -2,6 +2,7 @@ package gamesetup
 
 import (
 	"flag"
+	"strings"
 	"time"
 
 	"github.com/leandroatallah/firefly/internal/engine/data/config"
@@ -30,6 +31,7 @@ func NewConfig() *config.AppConfig {
 		UpwardGravity:         4,
 		DownwardGravity:       4,
 		MaxFallSpeed:          fp16.To16(3),
+		MaxStepHeight:         2,
 	}
 
 	cfg := &config.AppConfig{
@@ -46,6 +48,12 @@ func NewConfig() *config.AppConfig {
 	flag.BoolVar(&cfg.CamDebug, "cam-debug", false, "Enable camera debug")
 	flag.BoolVar(&cfg.CollisionBox, "collision-box", false, "Enable collision box debug")
 	flag.BoolVar(&cfg.NoSound, "no-sound", false, "Disable game sound")
+	flag.BoolVar(&cfg.Captions, "captions", false, "Show captions for sounds")
+	flag.StringVar(&cfg.Locale, "locale", "", "Language of the game texts, e.g. pt-BR")
+	flag.Func("post-effects", "Comma separated post effects to enable, e.g. crt,vignette", func(s string) error {
+		cfg.PostEffects = strings.Split(s, ",")
+		return nil
+	})
 
 	return cfg
 }
@@ -0,0 +1,26 @@
+package gamesetup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leandroatallah/firefly/internal/engine/data/i18n"
+)
+
+// TestMissingLocaleKeys lists the string table keys that a locale lacks and
+// another has.
+func TestMissingLocaleKeys(t *testing.T) {
+	l := i18n.New(i18n.DefaultLocale)
+	if err := l.LoadDir("../../../assets/locales"); err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Locales()) == 0 {
+		t.Fatal("no string tables found")
+	}
+
+	for locale, keys := range l.Missing() {
+		if len(keys) > 0 {
+			t.Errorf("locale %s is missing %d keys:\n\t%s", locale, len(keys), strings.Join(keys, "\n\t"))
+		}
+	}
+}
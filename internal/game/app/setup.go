@@ -1,26 +1,33 @@
 package gamesetup
 
 import (
+	"fmt"
 	"io/fs"
-	"log"
-	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/leandroatallah/firefly/internal/engine/app"
 	"github.com/leandroatallah/firefly/internal/engine/assets/font"
 	"github.com/leandroatallah/firefly/internal/engine/audio"
 	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	"github.com/leandroatallah/firefly/internal/engine/data/flags"
+	"github.com/leandroatallah/firefly/internal/engine/data/i18n"
 	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
+	"github.com/leandroatallah/firefly/internal/engine/entity/effects"
 	"github.com/leandroatallah/firefly/internal/engine/event"
 	"github.com/leandroatallah/firefly/internal/engine/physics/space"
+	"github.com/leandroatallah/firefly/internal/engine/render/postfx"
 	"github.com/leandroatallah/firefly/internal/engine/scene"
 	"github.com/leandroatallah/firefly/internal/engine/scene/phases"
+	"github.com/leandroatallah/firefly/internal/engine/ui/caption"
 	"github.com/leandroatallah/firefly/internal/engine/ui/speech"
 	gamescene "github.com/leandroatallah/firefly/internal/game/scenes"
 	scenestypes "github.com/leandroatallah/firefly/internal/game/scenes/types"
 	gamespeech "github.com/leandroatallah/firefly/internal/game/ui/speech"
+	"golang.org/x/image/font/gofont/gomono"
 )
 
+const phaseAudioManifest = "assets/audio/manifests/phase.json"
+
 func Setup(assets fs.FS) error {
 	cfg := config.Get()
 	// Basic Ebiten setup
@@ -29,10 +36,26 @@ func Setup(assets fs.FS) error {
 
 	// Initialize all systems and managers
 	audioManager := audio.NewAudioManager()
+	// The music plays under the sound effects by default
+	audioManager.SetBusVolume(audio.BusMusic, 0.25)
 	sceneManager := scene.NewSceneManager()
 	phaseManager := phases.NewManager()
 	actorManager := actors.NewManager()
 
+	// Load the string tables. Glyphs missing in the main font are drawn with
+	// a monospace fallback.
+	if err := i18n.Default().LoadDir("assets/locales"); err != nil {
+		return err
+	}
+	if cfg.Locale != "" {
+		if err := i18n.Default().SetLocale(cfg.Locale); err != nil {
+			return err
+		}
+	}
+	if err := font.AddFallback(gomono.TTF); err != nil {
+		return err
+	}
+
 	// Initialize Dialogue Manager
 	fontText, err := font.NewFontText(cfg.MainFontFace)
 	if err != nil {
@@ -41,24 +64,65 @@ func Setup(assets fs.FS) error {
 	speechFont := speech.NewSpeechFont(fontText, 8, 14)
 	speechBubble := gamespeech.NewSpeechBubble(speechFont)
 	dialogueManager := speech.NewManager(speechBubble)
+	gameFlags := flags.New()
+	eventManager := event.NewManager()
+	dialogueManager.SetFlags(gameFlags)
+	dialogueManager.SetEventManager(eventManager)
+
+	// Load dialogue speakers, graphs and inline icons
+	if err := speech.LoadSpeakers("assets/dialogue/speakers.json"); err != nil {
+		return err
+	}
+	if err := speech.LoadGraphs("assets/dialogue/graphs.json"); err != nil {
+		return err
+	}
+	if err := speech.LoadIcons("assets/dialogue/icons.json"); err != nil {
+		return err
+	}
+
+	// Audio files are streamed or decoded from the assets on demand
+	audioManager.SetFS(assets)
+
+	// Captions for sounds and events, shown when enabled in the options
+	captions := caption.NewManager(fontText)
+	if err := captions.LoadRules("assets/captions/captions.json"); err != nil {
+		return err
+	}
+	captions.Subscribe(eventManager)
+	captions.ListenTo(audioManager)
+
+	// Load status effects used by actors, items and sequences
+	if err := effects.LoadDefinitions("assets/effects/effects.json"); err != nil {
+		return err
+	}
 
-	// Load audio assets
-	loadAudioAssetsFromFS(assets, audioManager)
+	// Post-processing passes run over the scenes, enabled from the options
+	// and by sequences
+	postFX, err := postfx.NewDefaultPipeline()
+	if err != nil {
+		return err
+	}
+	for _, name := range cfg.PostEffects {
+		if !postFX.SetEnabled(name, true) {
+			return fmt.Errorf("unknown post effect %q", name)
+		}
+	}
 
 	// Load phases
 	phase0 := phases.Phase{
-		ID:           1,
-		Name:         "Phase 1",
-		TilemapPath:  "assets/tilemap/shepherd-phase-0.tmj",
-		NextPhaseID:  2,
-		SequencePath: "assets/sequences/sample.json",
+		ID:            1,
+		Name:          "Phase 1",
+		TilemapPath:   "assets/tilemap/shepherd-phase-0.tmj",
+		NextPhaseID:   2,
+		SequencePath:  "assets/sequences/sample.json",
+		AudioManifest: phaseAudioManifest,
 	}
-	phase1 := phases.Phase{ID: 2, Name: "Phase 2", TilemapPath: "assets/tilemap/shepherd-phase-1.tmj", NextPhaseID: 1}
-	phase2 := phases.Phase{ID: 2, Name: "Phase 2", TilemapPath: "assets/tilemap/shepherd-phase-2.tmj", NextPhaseID: 1}
-	phase3 := phases.Phase{ID: 3, Name: "Phase 3", TilemapPath: "assets/tilemap/shepherd-phase-3.tmj", NextPhaseID: 1}
-	phase4 := phases.Phase{ID: 4, Name: "Phase 4", TilemapPath: "assets/tilemap/shepherd-phase-4.tmj", NextPhaseID: 1}
-	phase5 := phases.Phase{ID: 5, Name: "Phase 5", TilemapPath: "assets/tilemap/shepherd-phase-5.tmj", NextPhaseID: 1}
-	phase6 := phases.Phase{ID: 6, Name: "Phase 6", TilemapPath: "assets/tilemap/shepherd-phase-6.tmj", NextPhaseID: 1}
+	phase1 := phases.Phase{ID: 2, Name: "Phase 2", TilemapPath: "assets/tilemap/shepherd-phase-1.tmj", NextPhaseID: 1, AudioManifest: phaseAudioManifest}
+	phase2 := phases.Phase{ID: 2, Name: "Phase 2", TilemapPath: "assets/tilemap/shepherd-phase-2.tmj", NextPhaseID: 1, AudioManifest: phaseAudioManifest}
+	phase3 := phases.Phase{ID: 3, Name: "Phase 3", TilemapPath: "assets/tilemap/shepherd-phase-3.tmj", NextPhaseID: 1, AudioManifest: phaseAudioManifest}
+	phase4 := phases.Phase{ID: 4, Name: "Phase 4", TilemapPath: "assets/tilemap/shepherd-phase-4.tmj", NextPhaseID: 1, AudioManifest: phaseAudioManifest}
+	phase5 := phases.Phase{ID: 5, Name: "Phase 5", TilemapPath: "assets/tilemap/shepherd-phase-5.tmj", NextPhaseID: 1, AudioManifest: phaseAudioManifest}
+	phase6 := phases.Phase{ID: 6, Name: "Phase 6", TilemapPath: "assets/tilemap/shepherd-phase-6.tmj", NextPhaseID: 1, AudioManifest: phaseAudioManifest}
 	phaseManager.AddPhase(phase0)
 	phaseManager.AddPhase(phase1)
 	phaseManager.AddPhase(phase2)
@@ -71,10 +135,13 @@ func Setup(assets fs.FS) error {
 	appContext := &app.AppContext{
 		AudioManager:    audioManager,
 		DialogueManager: dialogueManager,
-		EventManager:    event.NewManager(),
+		Captions:        captions,
+		PostFX:          postFX,
+		EventManager:    eventManager,
 		ActorManager:    actorManager,
 		SceneManager:    sceneManager,
 		PhaseManager:    phaseManager,
+		Flags:           gameFlags,
 		ImageManager:    nil,
 		DataManager:     nil,
 		Assets:          assets,
@@ -88,11 +155,16 @@ func Setup(assets fs.FS) error {
 	sceneManager.SetFactory(sceneFactory)
 	sceneManager.SetAppContext(appContext)
 
+	// Dialogues are shown in an overlay over the current scene
+	dialogueManager.SetOnStart(func() {
+		sceneManager.Push(scenestypes.SceneDialogue, true)
+	})
+
 	// Create and run the game
 	game := app.NewGame(appContext)
 
 	// Set initial game scene
-	game.AppContext.SceneManager.NavigateTo(scenestypes.ScenePhases, nil, false)
+	game.AppContext.SceneManager.NavigateTo(scenestypes.SceneMenu, nil, false)
 
 	if err := ebiten.RunGame(game); err != nil {
 		return err
@@ -100,34 +172,3 @@ func Setup(assets fs.FS) error {
 
 	return nil
 }
-
-// loadAudioAssetsFromFS is a helper function to load all audio files from an fs.FS.
-func loadAudioAssetsFromFS(assets fs.FS, am *audio.AudioManager) {
-	dir := "assets/audio"
-	files, err := fs.ReadDir(assets, dir)
-	if err != nil {
-		log.Fatalf("error reading embedded audio dir: %v", err)
-	}
-
-	for _, file := range files {
-		if file.IsDir() {
-			continue
-		}
-
-		fileName := file.Name()
-		// Filter for supported audio types
-		if !(strings.HasSuffix(fileName, ".ogg") || strings.HasSuffix(fileName, ".wav") || strings.HasSuffix(fileName, ".mp3")) {
-			continue
-		}
-
-		fullPath := dir + "/" + fileName
-		data, err := fs.ReadFile(assets, fullPath)
-		if err != nil {
-			log.Printf("failed to read embedded file %s: %v", fullPath, err)
-			continue
-		}
-
-		// Use the existing Add method to process and store the player.
-		am.Add(dir+"/"+fileName, data)
-	}
-}
@@ -2,13 +2,19 @@ package builder
 
 import (
 	"fmt"
+	"image"
 
 	"github.com/leandroatallah/firefly/internal/engine/app"
+	"github.com/leandroatallah/firefly/internal/engine/combat"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/animation"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 	"github.com/leandroatallah/firefly/internal/engine/data/schemas"
 	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
+	"github.com/leandroatallah/firefly/internal/engine/entity/actors/movement"
+	"github.com/leandroatallah/firefly/internal/engine/entity/prefabs"
 	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
+	physicsmovement "github.com/leandroatallah/firefly/internal/engine/physics/movement"
+	"github.com/leandroatallah/firefly/internal/engine/physics/skill"
 	"github.com/leandroatallah/firefly/internal/engine/render/sprites"
 	gameentitytypes "github.com/leandroatallah/firefly/internal/game/entity/types"
 )
@@ -28,6 +34,9 @@ func CreateAnimatedCharacter(
 	p.SetFaceDirection(data.FacingDirection)
 	p.SetFrameRate(data.FrameRate)
 	p.SetAppContext(ctx)
+	if ctx != nil {
+		p.Effects().SetEventManager(ctx.EventManager)
+	}
 
 	return p, nil
 }
@@ -63,9 +72,32 @@ func SetCharacterBodies(
 	}
 
 	bodyphysics.SetCollisionBodies(character, data, stateMap, idProvider, addCollisionRect)
+	SetCombatBoxes(character, data, stateMap)
 	return nil
 }
 
+// SetCombatBoxes registers the hitboxes and hurtboxes declared on each sprite asset.
+func SetCombatBoxes(
+	character gameentitytypes.PlatformerActorEntity,
+	data schemas.SpriteData,
+	stateMap map[string]animation.SpriteState,
+) {
+	boxes := character.GetCharacter().CombatBoxes()
+	for key, asset := range data.Assets {
+		state, ok := stateMap[key].(actors.ActorStateEnum)
+		if !ok {
+			continue
+		}
+		for _, h := range asset.Hitboxes {
+			boxes.AddHitbox(state, combat.NewHitboxDef(h))
+		}
+		for _, r := range asset.Hurtboxes {
+			x, y, w, h := r.Rect()
+			boxes.AddHurtbox(state, image.Rect(x, y, x+w, y+h))
+		}
+	}
+}
+
 func SetCharacterStats(character gameentitytypes.PlatformerActorEntity, data actors.StatData) error {
 	character.SetMaxHealth(data.Health)
 	var err error
@@ -79,3 +111,81 @@ func SetCharacterStats(character gameentitytypes.PlatformerActorEntity, data act
 	}
 	return nil
 }
+
+// StateMapFromAssets maps each sprite asset key to the actor state registered with the same name.
+func StateMapFromAssets(data schemas.SpriteData) (map[string]animation.SpriteState, error) {
+	stateMap := make(map[string]animation.SpriteState)
+	for stateName := range data.Assets {
+		enum, ok := actors.GetStateEnum(stateName)
+		if !ok {
+			return nil, fmt.Errorf("state '%s' not registered", stateName)
+		}
+		stateMap[stateName] = enum
+	}
+	return stateMap, nil
+}
+
+type skillAdder interface {
+	AddSkill(s skill.Skill)
+}
+
+// ApplyPrefab sets the stats, bodies, movement model, skills and movement
+// state declared by a prefab. The character owner must already be set.
+func ApplyPrefab(
+	character gameentitytypes.PlatformerActorEntity,
+	p prefabs.Prefab,
+	id, idPrefix string,
+	target body.MovableCollidable,
+) error {
+	character.SetID(id)
+
+	if err := SetCharacterStats(character, p.Stats); err != nil {
+		return fmt.Errorf("SetCharacterStats: %w", err)
+	}
+
+	stateMap, err := StateMapFromAssets(p.Sprites)
+	if err != nil {
+		return err
+	}
+	if err := SetCharacterBodies(character, p.Sprites, stateMap, idPrefix); err != nil {
+		return fmt.Errorf("SetCharacterBodies: %w", err)
+	}
+
+	if p.MovementModel != "" {
+		modelEnum, err := physicsmovement.ParseMovementModel(p.MovementModel)
+		if err != nil {
+			return err
+		}
+		model, err := physicsmovement.NewMovementModel(modelEnum, nil)
+		if err != nil {
+			return err
+		}
+		character.SetMovementModel(model)
+	}
+
+	adder, ok := character.(skillAdder)
+	if !ok && len(p.Skills) > 0 {
+		return fmt.Errorf("character must implement skillAdder")
+	}
+	for _, name := range p.Skills {
+		s, err := skill.NewSkill(name)
+		if err != nil {
+			return err
+		}
+		adder.AddSkill(s)
+	}
+
+	if p.MovementState != "" {
+		state, ok := movement.GetMovementStateEnum(p.MovementState)
+		if !ok {
+			return fmt.Errorf("unknown movement state: %s", p.MovementState)
+		}
+		var options []movement.MovementStateOption
+		if p.MovementOptions.WaitBeforeTurn > 0 {
+			options = append(options, movement.WithWaitBeforeTurn(p.MovementOptions.WaitBeforeTurn))
+		}
+		character.GetCharacter().SetMovementState(state, target, options...)
+	}
+
+	return nil
+}
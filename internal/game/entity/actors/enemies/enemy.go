@@ -0,0 +1,118 @@
+package gameenemies
+
+import (
+	"math"
+
+	"github.com/leandroatallah/firefly/internal/engine/app"
+	"github.com/leandroatallah/firefly/internal/engine/combat"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
+	"github.com/leandroatallah/firefly/internal/engine/entity/actors/movement"
+	"github.com/leandroatallah/firefly/internal/engine/entity/prefabs"
+	physicsspace "github.com/leandroatallah/firefly/internal/engine/physics/space"
+	"github.com/leandroatallah/firefly/internal/game/entity/actors/builder"
+	gameentitytypes "github.com/leandroatallah/firefly/internal/game/entity/types"
+)
+
+// Enemy is an actor built from a prefab of kind "enemy" (e.g. the wolf).
+type Enemy struct {
+	*gameentitytypes.PlatformerCharacter
+
+	prefab prefabs.Prefab
+	touch  prefabs.TouchBehaviour
+}
+
+func NewEnemy(ctx *app.AppContext, p prefabs.Prefab, x, y int, id string, target body.MovableCollidable) (*Enemy, error) {
+	stateMap, err := builder.StateMapFromAssets(p.Sprites)
+	if err != nil {
+		return nil, err
+	}
+	character, err := builder.CreateAnimatedCharacter(ctx, p.Sprites, stateMap)
+	if err != nil {
+		return nil, err
+	}
+
+	character.SetPosition(x, y)
+	enemy := &Enemy{PlatformerCharacter: character, prefab: p}
+	// Set the owner on the embedded character so LastOwner() works correctly
+	enemy.SetOwner(enemy)
+
+	if err = builder.ApplyPrefab(enemy, p, id, "ENEMY", target); err != nil {
+		return nil, err
+	}
+
+	if touch, ok := prefabs.GetTouchBehaviour(p.Touch); ok {
+		enemy.touch = touch
+	}
+	enemy.SetTouchable(enemy)
+	enemy.SetTeam(combat.TeamEnemy)
+
+	return enemy, nil
+}
+
+func (e *Enemy) Prefab() prefabs.Prefab {
+	return e.prefab
+}
+
+func (e *Enemy) SetTarget(target body.MovableCollidable) {
+	e.Character.MovementState().SetTarget(target)
+}
+
+// Sees reports whether the target is within the sight range of the enemy,
+// which shrinks in the dark with the light level where the target is, with no
+// obstacle between them.
+func (e *Enemy) Sees(target body.Body) bool {
+	p := e.prefab.Perception
+	if p.SightRange <= 0 || target == nil {
+		return false
+	}
+	r, t := e.Position(), target.Position()
+	x, y := float64(r.Min.X+r.Max.X)/2, float64(r.Min.Y+r.Max.Y)/2
+	tx, ty := float64(t.Min.X+t.Max.X)/2, float64(t.Min.Y+t.Max.Y)/2
+	if sp := e.AppContext().Space; sp != nil && !physicsspace.LineOfSight(sp, x, y, tx, ty) {
+		return false
+	}
+	if l := e.AppContext().Lighting; l != nil {
+		return l.Sees(x, y, tx, ty, p.SightRange, p.DarkSight)
+	}
+	return math.Hypot(tx-x, ty-y) <= p.SightRange
+}
+
+// pursue heads a patrolling enemy to its target while it sees it.
+func (e *Enemy) pursue() {
+	s, ok := e.MovementState().(*movement.SideToSideMovementState)
+	if !ok || s.Target() == nil {
+		return
+	}
+	if target := s.Target(); e.Sees(target) {
+		s.TurnTowards(target, e.AppContext().Space)
+	}
+}
+
+// Character Methods
+func (e *Enemy) Update(space body.BodiesSpace) error {
+	e.pursue()
+	return e.Character.Update(space)
+}
+
+func (e *Enemy) GetCharacter() *actors.Character {
+	return e.Character
+}
+
+func (e *Enemy) OnTouch(other body.Collidable) {
+	if e.touch != nil {
+		e.touch(e, other)
+	}
+}
+
+// Hurt removes the enemy from the space and the actors once its health runs
+// out.
+func (e *Enemy) Hurt(damage int) {
+	e.Character.Hurt(damage)
+	if e.Health() == 0 {
+		e.AppContext().Space.QueueForRemoval(e)
+		e.AppContext().ActorManager.Unregister(e)
+	}
+}
+
+func (e *Enemy) OnDie() {}
@@ -0,0 +1,116 @@
+package gamenpcs
+
+import (
+	"github.com/leandroatallah/firefly/internal/engine/app"
+	"github.com/leandroatallah/firefly/internal/engine/combat"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
+	"github.com/leandroatallah/firefly/internal/engine/entity/prefabs"
+	"github.com/leandroatallah/firefly/internal/game/entity/actors/builder"
+	gameplayermethods "github.com/leandroatallah/firefly/internal/game/entity/actors/methods"
+	gamestates "github.com/leandroatallah/firefly/internal/game/entity/actors/states"
+	gameentitytypes "github.com/leandroatallah/firefly/internal/game/entity/types"
+)
+
+const GrabTouch = "grab"
+
+func init() {
+	prefabs.RegisterTouchBehaviour(GrabTouch, grabOnTouch)
+}
+
+// grabOnTouch lets the player pick up the npc (e.g. a sheep).
+func grabOnTouch(self, other body.Collidable) {
+	npc, ok := self.(*Npc)
+	if !ok || npc.State() == gamestates.Dying {
+		return
+	}
+
+	player, found := npc.AppContext().ActorManager.GetPlayer()
+	if !found {
+		return
+	}
+
+	if other.ID() != player.ID() {
+		return
+	}
+
+	sheepCarrier, ok := player.(gameentitytypes.SheepCarrier)
+	if ok && !sheepCarrier.IsCarryingSheep() {
+		sheepCarrier.GrabSheep(npc)
+	}
+}
+
+// Npc is an actor built from a prefab of kind "npc" (e.g. the sheep).
+type Npc struct {
+	*gameentitytypes.PlatformerCharacter
+	*gameplayermethods.PlayerDeathBehavior
+
+	prefab prefabs.Prefab
+	touch  prefabs.TouchBehaviour
+}
+
+func NewNpc(ctx *app.AppContext, p prefabs.Prefab, x, y int, id string, target body.MovableCollidable) (*Npc, error) {
+	stateMap, err := builder.StateMapFromAssets(p.Sprites)
+	if err != nil {
+		return nil, err
+	}
+	character, err := builder.CreateAnimatedCharacter(ctx, p.Sprites, stateMap)
+	if err != nil {
+		return nil, err
+	}
+
+	character.SetPosition(x, y)
+	npc := &Npc{PlatformerCharacter: character, prefab: p}
+	// Set the owner on the embedded character so LastOwner() works correctly
+	npc.SetOwner(npc)
+
+	if err = builder.ApplyPrefab(npc, p, id, "NPC", target); err != nil {
+		return nil, err
+	}
+
+	if touch, ok := prefabs.GetTouchBehaviour(p.Touch); ok {
+		npc.touch = touch
+	}
+	npc.SetTouchable(npc)
+	npc.SetTeam(combat.TeamPlayer)
+
+	npc.Character.SetStateTransitionHandler(gameplayermethods.StandardStateTransitionLogic)
+
+	npc.PlayerDeathBehavior = gameplayermethods.NewPlayerDeathBehavior(npc)
+
+	return npc, nil
+}
+
+func (n *Npc) Prefab() prefabs.Prefab {
+	return n.prefab
+}
+
+func (n *Npc) SetTarget(target body.MovableCollidable) {
+	n.Character.MovementState().SetTarget(target)
+}
+
+// Character Methods
+func (n *Npc) Update(space body.BodiesSpace) error {
+	return n.Character.Update(space)
+}
+
+func (n *Npc) GetCharacter() *actors.Character {
+	return n.Character
+}
+
+func (n *Npc) OnTouch(other body.Collidable) {
+	if n.touch != nil {
+		n.touch(n, other)
+	}
+}
+
+func (n *Npc) Hurt(damage int) {
+	if n.State() == gamestates.Dying {
+		return
+	}
+	state, err := n.NewState(gamestates.Dying)
+	if err != nil {
+		return
+	}
+	n.SetState(state)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/leandroatallah/firefly/internal/engine/app"
+	"github.com/leandroatallah/firefly/internal/engine/combat"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/animation"
 	"github.com/leandroatallah/firefly/internal/engine/data/schemas"
 	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
@@ -37,6 +38,8 @@ func SetPlayerBodies(player gameentitytypes.PlatformerActorEntity, data schemas.
 		stateMap[stateName] = enum
 	}
 
+	player.GetCharacter().SetTeam(combat.TeamPlayer)
+
 	return builder.SetCharacterBodies(player, data, stateMap, "PLAYER")
 }
 
@@ -2,74 +2,24 @@ package gameplayer
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/leandroatallah/firefly/internal/engine/app"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body" // ADDED THIS
 	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
+	"github.com/leandroatallah/firefly/internal/engine/input"
 	physicsmovement "github.com/leandroatallah/firefly/internal/engine/physics/movement"
 	"github.com/leandroatallah/firefly/internal/engine/physics/skill"
 	gameplayermethods "github.com/leandroatallah/firefly/internal/game/entity/actors/methods"
 	gamestates "github.com/leandroatallah/firefly/internal/game/entity/actors/states"
 	gameentitytypes "github.com/leandroatallah/firefly/internal/game/entity/types"
+	"github.com/leandroatallah/firefly/internal/game/events"
 )
 
-// shepherdStateTransitionLogic provides custom state handling for the ShepherdPlayer,
-// specifically for managing the "carrying" states.
-func shepherdStateTransitionLogic(c *actors.Character) bool {
-	if gameplayermethods.StandardStateTransitionLogic(c) {
-		return true
-	}
-
-	state := c.State()
-
-	isCarryingState := state == gamestates.CarryingIdle ||
-		state == gamestates.CarryingWalking ||
-		state == gamestates.CarryingFalling ||
-		state == gamestates.CarryingLanding
-
-	if !isCarryingState {
-		return false // Let the engine handle other states
-	}
-
-	setNewState := func(s actors.ActorStateEnum) {
-		state, err := c.NewState(s)
-		if err != nil {
-			// Log the error instead of crashing if a state is not registered.
-			log.Printf("Failed to create new state %v: %v", s, err)
-			return
-		}
-		c.SetState(state)
-	}
-
-	// State machine for when the character is carrying something.
-	switch {
-	case state == gamestates.CarryingLanding:
-		isAnimationOver := c.IsAnimationFinished()
-		if c.IsWalking() {
-			setNewState(gamestates.CarryingWalking)
-		} else if isAnimationOver {
-			setNewState(gamestates.CarryingIdle)
-		}
-	case state == gamestates.CarryingFalling && !c.IsFalling():
-		setNewState(gamestates.CarryingLanding)
-	case state != gamestates.CarryingFalling && c.IsFalling():
-		setNewState(gamestates.CarryingFalling)
-	case state != gamestates.CarryingWalking && c.IsWalking():
-		setNewState(gamestates.CarryingWalking)
-	case state != gamestates.CarryingIdle && c.IsIdle():
-		// This case also handles the initial transition from the base "Carrying"
-		// state to the more specific "CarryingIdle" state.
-		setNewState(gamestates.CarryingIdle)
-	}
-
-	return true // We've handled the state, so the engine shouldn't.
-}
+const shepherdAttack = "crook_swing"
 
 type ShepherdPlayer struct {
 	*gameentitytypes.PlatformerCharacter
 	gameentitytypes.SheepCarrier
-	baseSpeed int
 
 	*gameplayermethods.PlayerDeathBehavior
 }
@@ -87,8 +37,15 @@ func NewShepherdPlayer(ctx *app.AppContext) (gameentitytypes.PlatformerActorEnti
 	character.AddSkill(skill.NewJumpSkill())
 	character.AddSkill(skill.NewHorizontalMovementSkill())
 
-	// Set the custom state transition logic for the player
-	character.SetStateTransitionHandler(shepherdStateTransitionLogic)
+	character.SetStateTransitionHandler(gameplayermethods.StandardStateTransitionLogic)
+
+	// The carrying states are grouped under a "carrying" parent state, which
+	// applies the "carrying" effect while the sheep is carried
+	stateMachine, err := actors.ParseJsonStateMachine("internal/game/entity/actors/player/shepherd_fsm.json")
+	if err != nil {
+		return nil, fmt.Errorf("ParseJsonStateMachine: %w", err)
+	}
+	character.SetStateMachine(stateMachine)
 
 	player := &ShepherdPlayer{
 		PlatformerCharacter: character,
@@ -104,7 +61,6 @@ func NewShepherdPlayer(ctx *app.AppContext) (gameentitytypes.PlatformerActorEnti
 	if err = SetPlayerStats(player, statData); err != nil {
 		return nil, fmt.Errorf("SetPlayerStats: %w", err)
 	}
-	player.baseSpeed = player.Speed()
 
 	// Pass player itself
 	if err = SetMovementModel(player, physicsmovement.Platform); err != nil {
@@ -119,18 +75,21 @@ func NewShepherdPlayer(ctx *app.AppContext) (gameentitytypes.PlatformerActorEnti
 }
 
 func (p *ShepherdPlayer) Update(space body.BodiesSpace) error {
-	if p.IsCarryingSheep() {
-		p.SetHorizontalInertia(1.0)
-		p.SetSpeed(int(float64(p.baseSpeed) * 0.5))
-		p.SetJumpForceMultiplier(0.95)
-	} else {
-		p.SetHorizontalInertia(-1.0)
-		p.SetSpeed(p.baseSpeed)
-		p.SetJumpForceMultiplier(1.0)
-	}
+	p.handleAttack()
 	return p.Character.Update(space)
 }
 
+// handleAttack swings the crook. The shepherd can't attack while carrying a sheep.
+func (p *ShepherdPlayer) handleAttack() {
+	if !input.IsActionJustPressed(input.ActionAttack) {
+		return
+	}
+	if p.IsCarryingSheep() || p.State() == gamestates.Dying || p.IsMovementBlocked() {
+		return
+	}
+	p.AppContext().EventManager.Publish(&events.PlayerAttackedEvent{Projectile: shepherdAttack})
+}
+
 func (p *ShepherdPlayer) GetCharacter() *actors.Character {
 	return p.Character
 }
@@ -155,14 +114,15 @@ func (p *ShepherdPlayer) GrabSheep(s body.MovableCollidableTouchable) {
 	}
 	p.SetState(state)
 	p.AppContext().Space.QueueForRemoval(s)
+	pos := s.Position()
+	p.AppContext().EventManager.Publish(&events.SheepGrabbedEvent{
+		X: float64(pos.Min.X+pos.Max.X) / 2,
+		Y: float64(pos.Min.Y),
+	})
 }
 
 func (p *ShepherdPlayer) IsCarryingSheep() bool {
-	state := p.State()
-	return state == gamestates.CarryingIdle ||
-		state == gamestates.CarryingWalking ||
-		state == gamestates.CarryingFalling ||
-		state == gamestates.CarryingLanding
+	return p.StateMachine().IsIn("carrying")
 }
 
 func (p *ShepherdPlayer) DropSheep() {
@@ -171,4 +131,9 @@ func (p *ShepherdPlayer) DropSheep() {
 		return
 	}
 	p.SetState(state)
+	pos := p.Position()
+	p.AppContext().EventManager.Publish(&events.SheepDroppedEvent{
+		X: float64(pos.Min.X+pos.Max.X) / 2,
+		Y: float64(pos.Min.Y),
+	})
 }
@@ -0,0 +1,17 @@
+package gameplayer
+
+import (
+	"testing"
+
+	"github.com/leandroatallah/firefly/internal/engine/entity/actors"
+	_ "github.com/leandroatallah/firefly/internal/game/entity/actors/states"
+)
+
+// Run with -v to get the Graphviz dump of the shepherd states.
+func TestShepherdStateMachine(t *testing.T) {
+	def, err := actors.ParseJsonStateMachine("shepherd_fsm.json")
+	if err != nil {
+		t.Fatalf("ParseJsonStateMachine() = %v", err)
+	}
+	t.Log("\n" + def.Dot())
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/leandroatallah/firefly/internal/engine/contracts/animation"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 	"github.com/leandroatallah/firefly/internal/engine/data/schemas"
+	"github.com/leandroatallah/firefly/internal/engine/entity/effects"
 	"github.com/leandroatallah/firefly/internal/engine/entity/items"
 	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
 	"github.com/leandroatallah/firefly/internal/engine/render/sprites"
@@ -59,6 +60,18 @@ func SetItemBodies(item items.Item, data schemas.SpriteData) error {
 	return nil
 }
 
+type pickupEffectsSetter interface {
+	SetPickupEffects(names []string)
+}
+
 func SetItemStats(item items.Item, data items.StatData) error {
+	for _, name := range data.Effects {
+		if _, ok := effects.Get(name); !ok {
+			return fmt.Errorf("unknown effect: %s", name)
+		}
+	}
+	if setter, ok := item.(pickupEffectsSetter); ok {
+		setter.SetPickupEffects(data.Effects)
+	}
 	return nil
 }
@@ -2,6 +2,7 @@ package gameitems
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/leandroatallah/firefly/internal/engine/app"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
@@ -64,5 +65,8 @@ func (c *CollectibleCoinItem) OnTouch(other body.Collidable) {
 	if ok {
 		c.SetRemoved(true)
 		coinCollector.AddCoinCount(1)
+		if err := c.ApplyPickupEffects(player.GetCharacter().Effects()); err != nil {
+			log.Println(err)
+		}
 	}
 }
@@ -0,0 +1,43 @@
+package gameprefabs
+
+import (
+	"github.com/leandroatallah/firefly/internal/engine/app"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
+	"github.com/leandroatallah/firefly/internal/engine/entity/prefabs"
+	gameenemies "github.com/leandroatallah/firefly/internal/game/entity/actors/enemies"
+	gamenpcs "github.com/leandroatallah/firefly/internal/game/entity/actors/npcs"
+)
+
+const (
+	PrefabsDir = "assets/prefabs"
+
+	EnemyKind = "enemy"
+	NpcKind   = "npc"
+)
+
+// NewSpawner loads the prefab files and registers the game entity kinds.
+// Actors spawned by it target the current player.
+func NewSpawner(ctx *app.AppContext) (*prefabs.Spawner, error) {
+	registry, err := prefabs.LoadRegistry(PrefabsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	target := func() body.MovableCollidable {
+		player, found := ctx.ActorManager.GetPlayer()
+		if !found {
+			return nil
+		}
+		return player
+	}
+
+	spawner := prefabs.NewSpawner(registry)
+	spawner.RegisterKind(EnemyKind, func(p prefabs.Prefab, x, y int, id string) (body.Collidable, error) {
+		return gameenemies.NewEnemy(ctx, p, x, y, id, target())
+	})
+	spawner.RegisterKind(NpcKind, func(p prefabs.Prefab, x, y int, id string) (body.Collidable, error) {
+		return gamenpcs.NewNpc(ctx, p, x, y, id, target())
+	})
+
+	return spawner, nil
+}
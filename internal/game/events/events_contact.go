@@ -0,0 +1,47 @@
+package events
+
+import "image"
+
+const (
+	EnemyTouchedType = "enemy_touched"
+	SheepGrabbedType = "sheep_grabbed"
+	SheepDroppedType = "sheep_dropped"
+)
+
+// EnemyTouchedEvent is published when an enemy hits an actor, at the position
+// of the actor.
+type EnemyTouchedEvent struct {
+	X, Y float64
+}
+
+func (e *EnemyTouchedEvent) Type() string {
+	return EnemyTouchedType
+}
+
+func (e *EnemyTouchedEvent) Position() image.Rectangle {
+	return image.Rect(int(e.X), int(e.Y), int(e.X), int(e.Y))
+}
+
+type SheepGrabbedEvent struct {
+	X, Y float64
+}
+
+func (e *SheepGrabbedEvent) Type() string {
+	return SheepGrabbedType
+}
+
+func (e *SheepGrabbedEvent) Position() image.Rectangle {
+	return image.Rect(int(e.X), int(e.Y), int(e.X), int(e.Y))
+}
+
+type SheepDroppedEvent struct {
+	X, Y float64
+}
+
+func (e *SheepDroppedEvent) Type() string {
+	return SheepDroppedType
+}
+
+func (e *SheepDroppedEvent) Position() image.Rectangle {
+	return image.Rect(int(e.X), int(e.Y), int(e.X), int(e.Y))
+}
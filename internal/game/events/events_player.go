@@ -1,9 +1,12 @@
 package events
 
+import "image"
+
 const (
 	PlayerReachedFirstPointType = "player_reached_first_point"
 	PlayerJumpedType            = "player_jumped"
 	PlayerLandedType            = "player_landed"
+	PlayerAttackedType          = "player_attacked"
 )
 
 type PlayerReachedFirstPointEvent struct{}
@@ -20,6 +23,10 @@ func (e *PlayerJumpedEvent) Type() string {
 	return PlayerJumpedType
 }
 
+func (e *PlayerJumpedEvent) Position() image.Rectangle {
+	return image.Rect(int(e.X), int(e.Y), int(e.X), int(e.Y))
+}
+
 type PlayerLandedEvent struct {
 	X, Y float64
 }
@@ -27,3 +34,17 @@ type PlayerLandedEvent struct {
 func (e *PlayerLandedEvent) Type() string {
 	return PlayerLandedType
 }
+
+func (e *PlayerLandedEvent) Position() image.Rectangle {
+	return image.Rect(int(e.X), int(e.Y), int(e.X), int(e.Y))
+}
+
+// PlayerAttackedEvent asks the scene to spawn the projectile of an attack
+// (e.g. the crook swing) owned by the player.
+type PlayerAttackedEvent struct {
+	Projectile string
+}
+
+func (e *PlayerAttackedEvent) Type() string {
+	return PlayerAttackedType
+}
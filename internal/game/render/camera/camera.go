@@ -1,14 +1,43 @@
 package gamecamera
 
 import (
+	"github.com/leandroatallah/firefly/internal/engine/data/config"
 	enginecamera "github.com/leandroatallah/firefly/internal/engine/render/camera"
+	"github.com/leandroatallah/firefly/internal/engine/render/viewport"
 )
 
+// Rig is how the camera follows the players. Zones in the "Camera" layer of
+// the phases override it.
+var Rig = enginecamera.Rig{
+	DeadZoneWidth:     20,
+	DeadZoneHeight:    20,
+	LookAhead:         24,
+	LookAheadSpeed:    0.08,
+	VerticalOnLanding: true,
+	MinZoom:           0.5,
+	FramePadding:      32,
+}
+
+// Split is how the screen is divided between the players in local co-op.
+var Split = viewport.SplitAuto
+
+// MergeDistance is how close, in pixels, the players get for their
+// viewports to merge into one.
+var MergeDistance = 160.0
+
+// NewSplitScreen creates the split screen of the phases with a viewport for
+// each camera.
+func NewSplitScreen(cams ...*enginecamera.Controller) *viewport.SplitScreen {
+	cfg := config.Get()
+	s := viewport.NewSplitScreen(cfg.ScreenWidth, cfg.ScreenHeight, cams...)
+	s.Split = Split
+	s.MergeDistance = MergeDistance
+	return s
+}
+
 // New creates a new camera controller with game-specific settings.
 func New(x, y int) *enginecamera.Controller {
 	cam := enginecamera.NewController(float64(x), float64(y))
-	// Should it be removed?
-	cam.DeadZoneRadius = 10.0
-	cam.SmoothingFactor = 0.08
+	cam.SetRig(Rig)
 	return cam
 }
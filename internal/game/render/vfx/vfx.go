@@ -4,11 +4,11 @@ import (
 	"encoding/json"
 	"image/color"
 	"log"
-	"math/rand"
 	"os"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 	"github.com/leandroatallah/firefly/internal/engine/data/schemas"
 	"github.com/leandroatallah/firefly/internal/engine/render/camera"
 	engineparticles "github.com/leandroatallah/firefly/internal/engine/render/particles"
@@ -17,16 +17,19 @@ import (
 type VFXConfig struct {
 	Type string `json:"type"`
 	schemas.ParticleData
+	Emitter *schemas.EmitterData `json:"emitter,omitempty"`
 }
 
 // Manager handles all visual effects for the game.
 type Manager struct {
-	system  *engineparticles.System
-	configs map[string]*engineparticles.Config
+	system   *engineparticles.System
+	configs  map[string]*engineparticles.Config
+	emitters map[string]*engineparticles.EmitterConfig
 }
 
 func NewManager() *Manager {
 	configs := make(map[string]*engineparticles.Config)
+	emitters := make(map[string]*engineparticles.EmitterConfig)
 
 	// Load vfx.json
 	path := "assets/particles/vfx.json"
@@ -40,12 +43,11 @@ func NewManager() *Manager {
 		}
 
 		for _, vfx := range vfxList {
-			img, _, err := ebitenutil.NewImageFromFile(vfx.Image)
+			img, err := loadImage(vfx.Image)
 			if err != nil {
 				log.Printf("failed to load particle image %s: %v", vfx.Image, err)
 				// Fallback to white pixel
-				img = ebiten.NewImage(1, 1)
-				img.Fill(color.White)
+				img = whitePixel()
 			}
 
 			frameCount := 1
@@ -61,48 +63,87 @@ func NewManager() *Manager {
 				FrameRate:   vfx.FrameRate,
 			}
 			configs[vfx.Type] = config
+
+			if vfx.Emitter != nil {
+				emitter, err := engineparticles.NewEmitterConfig(config, *vfx.Emitter)
+				if err != nil {
+					log.Printf("failed to parse emitter %s: %v", vfx.Type, err)
+					continue
+				}
+				emitters[vfx.Type] = emitter
+			}
 		}
 	}
 
 	return &Manager{
-		system:  engineparticles.NewSystem(),
-		configs: configs,
+		system:   engineparticles.NewSystem(),
+		configs:  configs,
+		emitters: emitters,
+	}
+}
+
+// loadImage loads a particle image. Particles without image are a single white pixel.
+func loadImage(path string) (*ebiten.Image, error) {
+	if path == "" {
+		return whitePixel(), nil
 	}
+	img, _, err := ebitenutil.NewImageFromFile(path)
+	return img, err
+}
+
+func whitePixel() *ebiten.Image {
+	img := ebiten.NewImage(1, 1)
+	img.Fill(color.White)
+	return img
+}
+
+// SetSpace enables collisions for the emitters declared with "collide".
+func (m *Manager) SetSpace(space body.BodiesSpace) {
+	m.system.SetSpace(space)
 }
 
-// spawnPuff creates a puff of particles of the specified type at the given location.
-func (m *Manager) spawnPuff(typeKey string, x, y float64, count int, randRange float64) {
-	config, ok := m.configs[typeKey]
+// Emit starts the emitter declared for typeKey at the given location.
+func (m *Manager) Emit(typeKey string, x, y float64) *engineparticles.Emitter {
+	cfg, ok := m.emitters[typeKey]
 	if !ok {
-		return
+		return nil
 	}
+	e := engineparticles.NewEmitter(cfg, x, y)
+	m.system.AddEmitter(e)
+	return e
+}
 
-	for i := 0; i < count; i++ {
-		p := &engineparticles.Particle{
-			X:           x,
-			Y:           y,
-			VelX:        (rand.Float64() - 0.5) * randRange,
-			VelY:        (rand.Float64() - 0.5) * randRange,
-			Duration:    config.FrameCount * config.FrameRate,
-			MaxDuration: config.FrameCount * config.FrameRate,
-			Scale:       1.0,
-			ScaleSpeed:  0,
-			Config:      config,
+// Attach starts the emitter declared for typeKey following the center of anchor.
+func (m *Manager) Attach(typeKey string, anchor engineparticles.Anchor, offsetX, offsetY float64) *engineparticles.Emitter {
+	e := m.Emit(typeKey, 0, 0)
+	if e != nil {
+		e.Attach(anchor, offsetX, offsetY)
+	}
+	return e
+}
+
+// Detach stops the emitters of typeKey attached to anchor.
+func (m *Manager) Detach(typeKey string, anchor engineparticles.Anchor) {
+	cfg := m.emitters[typeKey]
+	for _, e := range m.system.Emitters() {
+		if e.Config == cfg && e.Anchor() == anchor {
+			e.Stop()
 		}
-		m.system.Add(p)
 	}
 }
 
 // SpawnJumpPuff creates a jump dust effect at the specified location.
-// The randRange parameter controls the randomness of the particle velocities.
 func (m *Manager) SpawnJumpPuff(x, y float64, count int) {
-	m.spawnPuff("jump", x, y, count, 0.1)
+	for i := 0; i < count; i++ {
+		m.Emit("jump", x, y)
+	}
 }
 
 // SpawnLandingPuff creates a landing dust effect at the specified location.
-// The randRange parameter controls the randomness of the particle velocities.
 func (m *Manager) SpawnLandingPuff(x, y float64, count int) {
-	m.spawnPuff("landing", x, y, count, 0.1)
+	for i := 0; i < count; i++ {
+		m.Emit("landing", x, y)
+	}
 }
 
 func (m *Manager) Update() {
@@ -24,6 +24,21 @@ func InitSceneMap(context *app.AppContext) navigation.SceneMap {
 		scenestypes.ScenePhaseReboot: func() navigation.Scene {
 			return NewPhaseRebootScene(context)
 		},
+		scenestypes.SceneOptions: func() navigation.Scene {
+			return NewOptionsScene(context)
+		},
+		scenestypes.ScenePhaseSelect: func() navigation.Scene {
+			return NewPhaseSelectScene(context)
+		},
+		scenestypes.ScenePause: func() navigation.Scene {
+			return NewPauseScene(context)
+		},
+		scenestypes.SceneInventory: func() navigation.Scene {
+			return NewInventoryScene(context)
+		},
+		scenestypes.SceneDialogue: func() navigation.Scene {
+			return NewDialogueScene(context)
+		},
 	}
 	return sceneMap
 }
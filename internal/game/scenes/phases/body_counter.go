@@ -16,9 +16,9 @@ type BodyCounter struct {
 func (b *BodyCounter) setBodyCounter(space body.BodiesSpace) {
 	for _, sb := range space.Bodies() {
 		switch sb.(type) {
-		case *gamenpcs.Sheep:
+		case *gamenpcs.Npc:
 			b.sheep++
-		case *gameenemies.WolfEnemy:
+		case *gameenemies.Enemy:
 			b.wolf++
 		}
 	}
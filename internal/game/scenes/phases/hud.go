@@ -4,6 +4,8 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
+// DrawHUD draws the HUD of a viewport on its image, screen, relative to its
+// top left corner.
 func (s *PhasesScene) DrawHUD(screen *ebiten.Image) {
 
 }
@@ -1,44 +1,58 @@
 package gamescenephases
 
 import (
+	"image"
 	"image/color"
 	"log"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/leandroatallah/firefly/internal/engine/app"
-	"github.com/leandroatallah/firefly/internal/engine/assets/font"
+	"github.com/leandroatallah/firefly/internal/engine/combat"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/animation"
 	"github.com/leandroatallah/firefly/internal/engine/contracts/body"
 	"github.com/leandroatallah/firefly/internal/engine/data/config"
-	"github.com/leandroatallah/firefly/internal/engine/entity/actors/enemies"
-	"github.com/leandroatallah/firefly/internal/engine/entity/actors/npcs"
+	"github.com/leandroatallah/firefly/internal/engine/entity"
+	"github.com/leandroatallah/firefly/internal/engine/entity/effects"
 	"github.com/leandroatallah/firefly/internal/engine/entity/items"
 	"github.com/leandroatallah/firefly/internal/engine/event"
+	"github.com/leandroatallah/firefly/internal/engine/input"
 	bodyphysics "github.com/leandroatallah/firefly/internal/engine/physics/body"
+	"github.com/leandroatallah/firefly/internal/engine/render/camera"
+	"github.com/leandroatallah/firefly/internal/engine/render/lighting"
+	"github.com/leandroatallah/firefly/internal/engine/render/particles"
+	"github.com/leandroatallah/firefly/internal/engine/render/postfx"
+	"github.com/leandroatallah/firefly/internal/engine/render/viewport"
 	"github.com/leandroatallah/firefly/internal/engine/scene"
-	"github.com/leandroatallah/firefly/internal/engine/scene/pause"
 	"github.com/leandroatallah/firefly/internal/engine/scene/transition"
 	"github.com/leandroatallah/firefly/internal/engine/sequences"
 	"github.com/leandroatallah/firefly/internal/engine/utils/timing"
-	gameenemies "github.com/leandroatallah/firefly/internal/game/entity/actors/enemies"
-	gamenpcs "github.com/leandroatallah/firefly/internal/game/entity/actors/npcs"
 	gameitems "github.com/leandroatallah/firefly/internal/game/entity/items"
+	gameprefabs "github.com/leandroatallah/firefly/internal/game/entity/prefabs"
 	gameentitytypes "github.com/leandroatallah/firefly/internal/game/entity/types"
 	"github.com/leandroatallah/firefly/internal/game/events"
+	gamecamera "github.com/leandroatallah/firefly/internal/game/render/camera"
 	"github.com/leandroatallah/firefly/internal/game/render/vfx"
 	scenestypes "github.com/leandroatallah/firefly/internal/game/scenes/types"
 )
 
 const (
-	bgSound = "assets/audio/Sketchbook.ogg"
+	bgSound         = "assets/audio/Sketchbook.ogg"
+	hitSound        = "assets/audio/jab8.ogg"
+	projectilesPath = "assets/combat/projectiles.json"
 )
 
+// hurtPulseFrames is how long the screen shifts colors when the player is
+// hurt.
+var hurtPulseFrames = timing.FromDuration(300 * time.Millisecond)
+
+// lanternRadius is the light the shepherd carries in dark phases.
+const lanternRadius = 72
+
 type PhasesScene struct {
 	scene.TilemapScene
 	count       int
 	player      gameentitytypes.PlatformerActorEntity
-	mainText    *font.FontText
 	bodyCounter *BodyCounter
 
 	// Complete phase
@@ -55,59 +69,143 @@ type PhasesScene struct {
 
 	screenFlipper  *scene.ScreenFlipper
 	sequencePlayer *sequences.SequencePlayer
-	pauseScreen    *pause.PauseScreen
 	vfxManager     *vfx.Manager
+	viewports      *viewport.SplitScreen
+	lighting       *lighting.System
+	world          *entity.World
+	subscriptions  []event.Subscription
+
+	// Combat
+	combat            *combat.System
+	projectiles       *combat.ProjectilePool
+	projectileConfigs map[string]*combat.ProjectileConfig
 }
 
 func NewPhasesScene(context *app.AppContext) *PhasesScene {
-	mainText, err := font.NewFontText(config.Get().MainFontFace)
-	if err != nil {
-		log.Fatal(err)
-	}
 	tilemapScene := scene.NewTilemapScene(context)
 	scene := PhasesScene{
 		TilemapScene: *tilemapScene,
-		mainText:     mainText,
 		bodyCounter:  &BodyCounter{},
 	}
 	scene.SetAppContext(context)
 
-	// Subscribe events
-	context.EventManager.Subscribe(events.CharacterDiedEventType, func(e event.Event) {
-		scene.Reboot()
+	return &scene
+}
+
+// subscribe listens to the events of the phase until the scene is finished.
+func (s *PhasesScene) subscribe() {
+	em := s.AppContext().EventManager
+	subscribe := func(eventType string, listener event.Listener) {
+		s.subscriptions = append(s.subscriptions, em.Subscribe(eventType, listener))
+	}
+
+	subscribe(events.CharacterDiedEventType, func(e event.Event) {
+		s.Reboot()
 	})
-	context.EventManager.Subscribe(events.PlayerReachedFirstPointType, func(e event.Event) {
+	subscribe(events.PlayerReachedFirstPointType, func(e event.Event) {
 		if genEvt, ok := e.(event.GenericEvent); ok {
 			msg := genEvt.Payload["message"].(string)
 			log.Println(msg)
 		}
 	})
 
-	context.EventManager.Subscribe(events.PlayerJumpedType, func(e event.Event) {
-		if scene.vfxManager == nil {
+	subscribe(events.PlayerJumpedType, func(e event.Event) {
+		if s.vfxManager == nil {
 			return
 		}
 		if evt, ok := e.(*events.PlayerJumpedEvent); ok {
-			scene.vfxManager.SpawnJumpPuff(evt.X, evt.Y, 1)
+			s.vfxManager.SpawnJumpPuff(evt.X, evt.Y, 1)
 		}
 	})
 
-	context.EventManager.Subscribe(events.PlayerLandedType, func(e event.Event) {
-		if scene.vfxManager == nil {
+	subscribe(events.PlayerLandedType, func(e event.Event) {
+		if s.vfxManager == nil {
 			return
 		}
 		if evt, ok := e.(*events.PlayerLandedEvent); ok {
-			scene.vfxManager.SpawnLandingPuff(evt.X, evt.Y, 1)
+			s.vfxManager.SpawnLandingPuff(evt.X, evt.Y, 1)
 		}
 	})
 
-	return &scene
+	subscribe(combat.DamageEventType, func(e event.Event) {
+		evt, ok := e.(*combat.DamageEvent)
+		if !ok {
+			return
+		}
+		target, ok := evt.Target.(combat.Combatant)
+		if !ok {
+			return
+		}
+		if am := s.AppContext().AudioManager; am != nil && !s.AppContext().Config.NoSound {
+			am.PlaySoundFrom(hitSound, target)
+		}
+		pos := target.Position()
+		if s.vfxManager != nil {
+			s.vfxManager.Emit("hit_sparks", float64(pos.Min.X+pos.Dx()/2), float64(pos.Min.Y+pos.Dy()/2))
+		}
+		// Captions tell the actor was caught by an enemy
+		if src, ok := evt.Source.(combat.Combatant); ok && src.Team() == combat.TeamEnemy {
+			s.AppContext().EventManager.Publish(&events.EnemyTouchedEvent{
+				X: float64(pos.Min.X+pos.Max.X) / 2,
+				Y: float64(pos.Min.Y),
+			})
+		}
+		if fx := s.AppContext().PostFX; fx != nil && s.player != nil && evt.Target == s.player {
+			fx.Pulse("chromatic", hurtPulseFrames)
+		}
+	})
+
+	// Effects with a particle emitter of the same name follow the affected actor
+	subscribe(effects.EffectAppliedEventType, func(e event.Event) {
+		if s.vfxManager == nil {
+			return
+		}
+		if evt, ok := e.(*effects.EffectAppliedEvent); ok && evt.First {
+			if anchor, ok := evt.Target.(particles.Anchor); ok {
+				s.vfxManager.Attach(evt.Effect, anchor, 0, 0)
+			}
+		}
+	})
+	subscribe(effects.EffectRemovedEventType, func(e event.Event) {
+		if s.vfxManager == nil {
+			return
+		}
+		if evt, ok := e.(*effects.EffectRemovedEvent); ok {
+			if anchor, ok := evt.Target.(particles.Anchor); ok {
+				s.vfxManager.Detach(evt.Effect, anchor)
+			}
+		}
+	})
+
+	subscribe(events.PlayerAttackedType, func(e event.Event) {
+		if s.projectiles == nil {
+			return
+		}
+		evt, ok := e.(*events.PlayerAttackedEvent)
+		if !ok {
+			return
+		}
+		cfg, ok := s.projectileConfigs[evt.Projectile]
+		if !ok {
+			log.Printf("unknown projectile: %s", evt.Projectile)
+			return
+		}
+		if owner, ok := s.player.(combat.Combatant); ok {
+			facingLeft := s.player.FaceDirection() == animation.FaceDirectionLeft
+			s.projectiles.Spawn(cfg, owner, facingLeft)
+		}
+	})
 }
 
 func (s *PhasesScene) OnStart() {
 	s.TilemapScene.OnStart()
 	s.count = 0
+	s.subscribe()
 	s.vfxManager = vfx.NewManager()
+	s.vfxManager.SetSpace(s.PhysicsSpace())
+	if captions := s.AppContext().Captions; captions != nil {
+		captions.SetCamera(s.Camera())
+	}
 
 	// Create player and register to space and context
 	p, err := createPlayer(s.AppContext(), gameentitytypes.ShepherdPlayerType)
@@ -120,6 +218,19 @@ func (s *PhasesScene) OnStart() {
 
 	s.initTilemap()
 
+	// Actors and items are updated through the entity world
+	s.world = entity.NewWorld(s.PhysicsSpace())
+	s.world.AddSystem(entity.DefaultSystems()...)
+	s.world.SyncSpace(entity.Adapt)
+
+	// Hitboxes and projectiles are resolved after the bodies are updated
+	s.combat = combat.NewSystem(s.AppContext().EventManager)
+	s.projectiles = combat.NewProjectilePool(s.combat, 8)
+	s.projectileConfigs, err = combat.LoadProjectileConfigs(projectilesPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// After init bodies, set body counter
 	s.bodyCounter.setBodyCounter(s.PhysicsSpace())
 
@@ -127,6 +238,7 @@ func (s *PhasesScene) OnStart() {
 
 	// Init camera target
 	s.SetCameraConfig(scene.CameraConfig{Mode: scene.CameraModeFollow})
+	s.Camera().SetRig(gamecamera.Rig)
 	s.Camera().SetFollowTarget(s.player)
 
 	// Set initial camera position to the screen where the player is
@@ -141,6 +253,9 @@ func (s *PhasesScene) OnStart() {
 
 	s.Camera().SetCenter(float64(camX), float64(camY))
 
+	// A viewport per player camera, a single one covering the screen for now
+	s.viewports = gamecamera.NewSplitScreen(s.Camera())
+
 	// Init collisions bodies and touch trigger for endpoints
 	s.Tilemap().CreateCollisionBodies(s.PhysicsSpace(), func(id string) body.Touchable {
 		return bodyphysics.NewTouchTrigger(func() {
@@ -148,6 +263,8 @@ func (s *PhasesScene) OnStart() {
 		}, s.player)
 	})
 
+	s.initLighting()
+
 	// Init screen flipper
 	s.screenFlipper = scene.NewScreenFlipper(s.Camera(), s.player, s.Tilemap(), s.AppContext())
 	tileWidth := s.Tilemap().Tilewidth
@@ -166,9 +283,6 @@ func (s *PhasesScene) OnStart() {
 		s.player.SetImmobile(false)
 	}
 
-	// Init pause screen
-	s.pauseScreen = pause.NewPauseScreen(ebiten.KeyEnter, 250*time.Millisecond)
-
 	// Init sequence player
 	s.sequencePlayer = sequences.NewSequencePlayer(s.AppContext())
 
@@ -185,9 +299,13 @@ func (s *PhasesScene) OnStart() {
 }
 
 func (s *PhasesScene) Update() error {
-	s.pauseScreen.Update()
-
-	if s.pauseScreen.IsPaused() {
+	// Pause and inventory are overlays that stop the phase while open
+	switch {
+	case input.IsActionJustPressed(input.ActionPause):
+		s.AppContext().SceneManager.Push(scenestypes.ScenePause, true)
+		return nil
+	case input.IsActionJustPressed(input.ActionInventory):
+		s.AppContext().SceneManager.Push(scenestypes.SceneInventory, true)
 		return nil
 	}
 
@@ -219,6 +337,17 @@ func (s *PhasesScene) Update() error {
 	}
 
 	s.TilemapScene.Update() // Update the camera if in follow mode
+	s.viewports.Update()
+
+	// Dialogues are shown in the viewport of the player
+	if dm := s.AppContext().DialogueManager; dm != nil {
+		dm.SetArea(s.viewports.ViewportOf(s.Camera()).Rect)
+	}
+	s.updatePostFXUniforms()
+
+	// Positional sounds are heard from the center of the screen
+	cam := s.Camera().Kamera()
+	s.AppContext().AudioManager.SetListener(cam.X+cam.Width/2, cam.Y+cam.Height/2)
 
 	s.playBackgroundMusic()
 
@@ -226,42 +355,39 @@ func (s *PhasesScene) Update() error {
 
 	// Execute bodies updates
 	space := s.PhysicsSpace()
-	for _, i := range space.Bodies() {
-		switch b := i.(type) {
-		// ActorEntity case should came first. It can be confused with body.Obstacle
-		case gameentitytypes.PlatformerActorEntity:
-			if err := b.Update(space); err != nil {
-				return err
-			}
-		case items.Item:
-			// Remove items marked as removed
-			if b.IsRemoved() {
-				s.PhysicsSpace().RemoveBody(i)
-				continue
-			}
-			if err := b.Update(space); err != nil {
-				return err
-			}
-		case body.Obstacle:
-			continue
-		}
+	s.world.SyncSpace(entity.Adapt)
+	if err := s.world.Update(); err != nil {
+		return err
 	}
+	s.combat.Update(space)
+	s.projectiles.Update(space)
 
 	// Remove bodies queued for removal
 	space.ProcessRemovals()
 
+	s.lighting.Update()
+
 	return nil
 }
 
 func (s *PhasesScene) Draw(screen *ebiten.Image) {
 	screen.Fill(color.RGBA{0, 0, 0, 0xff}) // force black
 
-	// Get tilemap image and draw based on camera
-	tilemap, err := s.Tilemap().Image(screen)
-	if err != nil {
-		log.Fatal(err)
+	s.viewports.Draw(screen, func(v *viewport.Viewport, dst *ebiten.Image) {
+		s.drawWorld(dst, v.Camera)
+		s.DrawHUD(dst)
+	})
+
+	if s.ShowDrawScreenFlash > 0 {
+		DrawScreenFlash(screen)
+		s.ShowDrawScreenFlash--
 	}
-	s.Camera().Draw(tilemap, s.Tilemap().ImageOptions(), screen)
+}
+
+// drawWorld draws the tilemap and bodies seen by the camera.
+func (s *PhasesScene) drawWorld(screen *ebiten.Image, cam *camera.Controller) {
+	// Draw the tilemap chunks seen by the camera
+	s.Tilemap().Draw(screen, cam)
 
 	// Draw bodies based on camera
 	space := s.PhysicsSpace()
@@ -270,9 +396,9 @@ func (s *PhasesScene) Draw(screen *ebiten.Image) {
 		case gameentitytypes.PlatformerActorEntity:
 			opts := sb.ImageOptions()
 			sb.UpdateImageOptions()
-			s.Camera().Draw(sb.Image(), opts, screen)
+			cam.Draw(sb.Image(), opts, screen)
 			if config.Get().CollisionBox {
-				s.Camera().DrawCollisionBox(screen, sb)
+				cam.DrawCollisionBox(screen, sb)
 			}
 		case items.Item:
 			if sb.IsRemoved() {
@@ -280,29 +406,64 @@ func (s *PhasesScene) Draw(screen *ebiten.Image) {
 			}
 			opts := sb.ImageOptions()
 			sb.UpdateImageOptions()
-			s.Camera().Draw(sb.Image(), opts, screen)
+			cam.Draw(sb.Image(), opts, screen)
 			if config.Get().CollisionBox {
-				s.Camera().DrawCollisionBox(screen, sb)
+				cam.DrawCollisionBox(screen, sb)
 			}
 		case body.Obstacle:
 			if config.Get().CollisionBox {
-				s.Camera().DrawCollisionBox(screen, sb)
+				cam.DrawCollisionBox(screen, sb)
 			}
 		}
 	}
 
-	if s.ShowDrawScreenFlash > 0 {
-		DrawScreenFlash(screen)
-		s.ShowDrawScreenFlash--
+	for _, p := range s.projectiles.Active() {
+		if p.Image() == nil {
+			continue
+		}
+		p.UpdateImageOptions()
+		cam.Draw(p.Image(), p.ImageOptions(), screen)
 	}
 
+	// Particles are drawn over the darkness
+	s.lighting.Draw(screen, cam)
+
 	if s.vfxManager != nil {
-		s.vfxManager.Draw(screen, s.Camera())
+		s.vfxManager.Draw(screen, cam)
 	}
+}
 
-	if s.pauseScreen.IsPaused() {
-		s.drawPause(screen)
+// initLighting lights the phase with the ambient light and the lights of
+// its map. In the dark, the shepherd carries a lantern.
+func (s *PhasesScene) initLighting() {
+	s.lighting = lighting.NewSystem(s.PhysicsSpace())
+	if ambient, ok := s.Tilemap().AmbientLight(); ok {
+		s.lighting.Ambient = ambient
+	}
+	if s.lighting.Dark() {
+		lantern := lighting.NewPointLight(0, 0, lanternRadius)
+		lantern.Color = color.NRGBA{0xff, 0xe0, 0xa8, 0xff}
+		lantern.Flicker = 0.1
+		lantern.Attach(s.player, 4, -2)
+		s.lighting.Add(lantern)
+	}
+	s.lighting.Add(s.Tilemap().Lights()...)
+	s.AppContext().Lighting = s.lighting
+}
+
+// updatePostFXUniforms gives the post-processing passes where the player is
+// on screen.
+func (s *PhasesScene) updatePostFXUniforms() {
+	fx := s.AppContext().PostFX
+	if fx == nil {
+		return
 	}
+	v := s.viewports.ViewportOf(s.Camera())
+	pos := s.player.Position()
+	x, y := v.Camera.Kamera().ApplyCameraTransformToPoint(
+		float64(pos.Min.X+pos.Dx()/2), float64(pos.Min.Y+pos.Dy()/2),
+	)
+	fx.SetUniform("PlayerPosition", postfx.ScreenPoint(v.Rect.Min.Add(image.Pt(int(x), int(y)))))
 }
 
 func (s *PhasesScene) Reboot() {
@@ -313,7 +474,19 @@ func (s *PhasesScene) Reboot() {
 
 func (s *PhasesScene) OnFinish() {
 	s.TilemapScene.OnFinish()
+	for _, sub := range s.subscriptions {
+		s.AppContext().EventManager.Unsubscribe(sub)
+	}
+	s.subscriptions = nil
 	s.AppContext().ActorManager.Unregister(s.player)
+	s.AppContext().Lighting = nil
+	if dm := s.AppContext().DialogueManager; dm != nil {
+		dm.SetArea(image.Rectangle{})
+	}
+	if captions := s.AppContext().Captions; captions != nil {
+		captions.SetCamera(nil)
+		captions.Clear()
+	}
 }
 
 func (s *PhasesScene) endpointTrigger(eventID string) {
@@ -335,8 +508,7 @@ func (s *PhasesScene) playBackgroundMusic() {
 
 	if s.count == 60 {
 		if am := s.AppContext().AudioManager; !am.IsPlaying(bgSound) {
-			am.PlayMusic(bgSound)
-			am.SetVolume(0.25)
+			am.CrossfadeMusic(bgSound, time.Second)
 		}
 	}
 
@@ -352,13 +524,14 @@ func (s *PhasesScene) initTilemap() {
 	f := items.NewItemFactory(gameitems.InitItemMap(s.AppContext()))
 	s.InitItems(itemsMap, f)
 
-	// Set enemies position from tilemap
-	enemyFactory := enemies.NewEnemyFactory(gameenemies.InitEnemyMap(s.AppContext()))
-	scene.InitEnemies(&s.TilemapScene, enemyFactory)
-
-	// Set NPCs position from tilemap
-	npcFactory := npcs.NewNpcFactory(gamenpcs.InitNpcMap(s.AppContext()))
-	scene.InitNPCs(&s.TilemapScene, npcFactory)
+	// Spawn enemies and NPCs declared as prefabs in the tilemap
+	spawner, err := gameprefabs.NewSpawner(s.AppContext())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := s.InitPrefabs(spawner); err != nil {
+		log.Fatal(err)
+	}
 
 	s.SetPlayerStartPosition(s.player)
 }
@@ -415,29 +588,3 @@ func (s *PhasesScene) completePhase() {
 
 	s.phaseCompletedDelay--
 }
-
-func (s *PhasesScene) drawPause(screen *ebiten.Image) {
-	if !s.pauseScreen.IsPaused() {
-		return
-	}
-
-	cfg := config.Get()
-	for x := 0; x < cfg.ScreenWidth; x++ {
-		for y := 0; y < cfg.ScreenWidth; y++ {
-			if x%2 == 0 && y%2 == 0 {
-				vector.DrawFilledRect(screen, float32(x), float32(y), 1, 1, color.Black, false)
-			}
-		}
-	}
-
-	speed := 10
-	initialW, initialH := cfg.ScreenWidth/4, cfg.ScreenHeight/4
-	w := max(min(initialW+s.pauseScreen.Count()*speed, cfg.ScreenWidth/2), 1)
-	h := max(min(initialH+s.pauseScreen.Count()*speed, cfg.ScreenHeight/2), 1)
-	container := ebiten.NewImage(w, h)
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Translate(float64(cfg.ScreenWidth)/2, float64(cfg.ScreenHeight)/2)
-	op.GeoM.Translate(-float64(w/2), -float64(h/2))
-	container.Fill(color.Black)
-	screen.DrawImage(container, op)
-}
@@ -0,0 +1,45 @@
+package gamescene
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/app"
+	"github.com/leandroatallah/firefly/internal/engine/scene"
+)
+
+// DialogueScene shows the current dialogue over the world, which keeps
+// updating without input, and closes when the dialogue ends.
+type DialogueScene struct {
+	scene.OverlayScene
+}
+
+func NewDialogueScene(context *app.AppContext) *DialogueScene {
+	scene := DialogueScene{}
+	scene.SetAppContext(context)
+	scene.UpdateBelow = true
+	scene.DrawBelow = true
+	scene.CaptureInput = true
+	return &scene
+}
+
+func (s *DialogueScene) Update() error {
+	dm := s.AppContext().DialogueManager
+	if err := dm.Update(); err != nil {
+		return err
+	}
+	if !dm.IsSpeaking() {
+		if sm := s.AppContext().SceneManager; sm.Top() == s {
+			sm.Pop()
+		}
+	}
+	return nil
+}
+
+// OnFinish ends the dialogue when the overlay is removed before it, e.g. by
+// navigating away.
+func (s *DialogueScene) OnFinish() {
+	s.AppContext().DialogueManager.Stop()
+}
+
+func (s *DialogueScene) Draw(screen *ebiten.Image) {
+	s.AppContext().DialogueManager.Draw(screen)
+}
@@ -5,13 +5,14 @@ import (
 	"log"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/leandroatallah/firefly/internal/engine/data/config"
 	"github.com/leandroatallah/firefly/internal/engine/app"
 	"github.com/leandroatallah/firefly/internal/engine/assets/font"
-	"github.com/leandroatallah/firefly/internal/engine/scene"
+	"github.com/leandroatallah/firefly/internal/engine/audio"
+	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	"github.com/leandroatallah/firefly/internal/engine/data/i18n"
 	"github.com/leandroatallah/firefly/internal/engine/render/screenutil"
+	"github.com/leandroatallah/firefly/internal/engine/scene"
 	"github.com/leandroatallah/firefly/internal/engine/scene/transition"
-	"github.com/leandroatallah/firefly/internal/engine/audio"
 	scenestypes "github.com/leandroatallah/firefly/internal/game/scenes/types"
 )
 
@@ -60,7 +61,7 @@ func NewIntroScene(context *app.AppContext) *IntroScene {
 func (s *IntroScene) Draw(screen *ebiten.Image) {
 	screen.Fill(color.RGBA{A: 255})
 
-	screenutil.DrawCenteredText(screen, s.fontText, "Presented by", 8, color.White)
+	screenutil.DrawCenteredText(screen, s.fontText, i18n.T("intro.presented_by"), 8, color.White)
 
 	op := &ebiten.DrawImageOptions{}
 	op.ColorScale.Scale(1, 1, 1, float32(s.fadeAlpha)/255.0)
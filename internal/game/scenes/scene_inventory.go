@@ -0,0 +1,85 @@
+package gamescene
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leandroatallah/firefly/internal/engine/app"
+	"github.com/leandroatallah/firefly/internal/engine/data/i18n"
+	"github.com/leandroatallah/firefly/internal/engine/input"
+	"github.com/leandroatallah/firefly/internal/engine/scene"
+	"github.com/leandroatallah/firefly/internal/engine/ui/widget"
+	gameentitytypes "github.com/leandroatallah/firefly/internal/game/entity/types"
+)
+
+var inventoryShade = color.RGBA{A: 0x90}
+
+// InventoryScene shows what the player carries over the paused gameplay.
+type InventoryScene struct {
+	scene.OverlayScene
+
+	ui *widget.Screen
+}
+
+func NewInventoryScene(context *app.AppContext) *InventoryScene {
+	scene := InventoryScene{}
+	scene.SetAppContext(context)
+	scene.DrawBelow = true
+	scene.CaptureInput = true
+	return &scene
+}
+
+func (s *InventoryScene) OnStart() {
+	title := widget.NewLabel("inventory.title")
+	title.Centered = true
+	box := widget.NewVBox(title)
+	for _, line := range s.lines() {
+		box.Add(widget.NewLabel(line))
+	}
+	box.Add(widget.NewButton("common.back", s.close))
+
+	s.ui = widget.NewScreen(newMenuTheme(), widget.NewPanel(box))
+	s.ui.OnCancel = s.close
+	s.ui.Layout(screenArea())
+}
+
+// lines describes the player's coins, health and carried sheep.
+func (s *InventoryScene) lines() []string {
+	player, ok := s.AppContext().ActorManager.GetPlayer()
+	if !ok {
+		return nil
+	}
+
+	var lines []string
+	if c, ok := player.(gameentitytypes.CoinCollector); ok {
+		lines = append(lines, i18n.N("inventory.coins", c.CoinCount()))
+	}
+	lines = append(lines, i18n.T("inventory.health", i18n.Args{
+		"health": player.Health(),
+		"max":    player.MaxHealth(),
+	}))
+	if c, ok := player.(gameentitytypes.SheepCarrier); ok && c.IsCarryingSheep() {
+		lines = append(lines, i18n.T("inventory.sheep"))
+	}
+	return lines
+}
+
+func (s *InventoryScene) close() {
+	s.AppContext().SceneManager.Pop()
+}
+
+func (s *InventoryScene) Update() error {
+	if input.IsActionJustPressed(input.ActionInventory) {
+		s.close()
+		return nil
+	}
+	s.ui.Update()
+	return nil
+}
+
+func (s *InventoryScene) Draw(screen *ebiten.Image) {
+	b := screen.Bounds()
+	vector.DrawFilledRect(screen, float32(b.Min.X), float32(b.Min.Y), float32(b.Dx()), float32(b.Dy()), inventoryShade, false)
+	s.ui.Draw(screen)
+}
@@ -1,73 +1,85 @@
 package gamescene
 
 import (
+	"image"
 	"image/color"
 	"log"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/text/v2"
-	"github.com/leandroatallah/firefly/internal/engine/data/config"
 	"github.com/leandroatallah/firefly/internal/engine/app"
 	"github.com/leandroatallah/firefly/internal/engine/assets/font"
+	"github.com/leandroatallah/firefly/internal/engine/contracts/navigation"
+	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	"github.com/leandroatallah/firefly/internal/engine/data/i18n"
 	"github.com/leandroatallah/firefly/internal/engine/scene"
 	"github.com/leandroatallah/firefly/internal/engine/scene/transition"
+	"github.com/leandroatallah/firefly/internal/engine/ui/widget"
 	scenestypes "github.com/leandroatallah/firefly/internal/game/scenes/types"
 )
 
-const (
-	kickBackBG = "assets/audio/kick_backOGG.ogg"
-)
+var menuBackground = color.RGBA{0xCC, 0x24, 0x40, 0xff}
 
 type MenuScene struct {
 	scene.BaseScene
 
-	fontText *font.FontText
+	ui *widget.Screen
 }
 
-func NewMenuScene(context *app.AppContext) *MenuScene {
+// newMenuTheme creates the theme shared by the menu screens. Widget texts are
+// string table keys.
+func newMenuTheme() *widget.Theme {
 	fontText, err := font.NewFontText(config.Get().MainFontFace)
 	if err != nil {
 		log.Fatal(err)
 	}
+	theme, err := widget.NewTheme(fontText)
+	if err != nil {
+		log.Fatal(err)
+	}
+	theme.Localize = func(msg string) string { return i18n.T(msg) }
+	return theme
+}
+
+// screenArea is the area menu screens are centered in.
+func screenArea() image.Rectangle {
+	return image.Rect(0, 0, config.Get().ScreenWidth, config.Get().ScreenHeight)
+}
 
-	scene := MenuScene{fontText: fontText}
+func NewMenuScene(context *app.AppContext) *MenuScene {
+	scene := MenuScene{}
 	scene.SetAppContext(context)
+
+	title := widget.NewLabel("menu.title")
+	title.Centered = true
+	root := widget.NewPanel(widget.NewVBox(
+		title,
+		widget.NewButton("menu.start", func() {
+			scene.navigateTo(scenestypes.ScenePhases)
+		}),
+		widget.NewButton("menu.select_phase", func() {
+			scene.navigateTo(scenestypes.ScenePhaseSelect)
+		}),
+		widget.NewButton("menu.options", func() {
+			scene.navigateTo(scenestypes.SceneOptions)
+		}),
+	))
+	scene.ui = widget.NewScreen(newMenuTheme(), root)
+	scene.ui.Layout(screenArea())
 	return &scene
 }
 
-func (s *MenuScene) OnStart() {
-	// Init audio
-	am := s.AppContext().SceneManager.AudioManager()
-	am.SetVolume(1)
-	// s.audio.PlayMusic(kickBackBG)
+func (s *MenuScene) navigateTo(sceneType navigation.SceneType) {
+	s.AppContext().SceneManager.NavigateTo(sceneType, transition.NewFader(), true)
 }
 
-func (s *MenuScene) Update() error {
-	if ebiten.IsKeyPressed(ebiten.KeyEnter) {
-		s.AppContext().SceneManager.NavigateTo(scenestypes.ScenePhases, transition.NewFader(), true)
-	}
+func (s *MenuScene) OnStart() {}
 
+func (s *MenuScene) Update() error {
+	s.ui.Update()
 	return nil
 }
 
 func (s *MenuScene) Draw(screen *ebiten.Image) {
-	screen.Fill(color.RGBA{0xCC, 0x24, 0x40, 0xff})
-
-	textOp := &text.DrawOptions{
-		LayoutOptions: text.LayoutOptions{
-			PrimaryAlign:   text.AlignCenter,
-			SecondaryAlign: text.AlignCenter,
-			LineSpacing:    0,
-		},
-	}
-	textOp.GeoM.Translate(
-		float64(config.Get().ScreenWidth/2),
-		float64(config.Get().ScreenHeight/2),
-	)
-	textOp.ColorScale.Scale(1, 1, 1, float32(120))
-	s.fontText.Draw(screen, "Press Enter to start", 8, textOp)
-}
-
-func (s *MenuScene) OnFinish() {
-	s.AppContext().AudioManager.PauseMusic(kickBackBG)
+	screen.Fill(menuBackground)
+	s.ui.Draw(screen)
 }
@@ -0,0 +1,149 @@
+package gamescene
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/app"
+	"github.com/leandroatallah/firefly/internal/engine/audio"
+	"github.com/leandroatallah/firefly/internal/engine/data/i18n"
+	"github.com/leandroatallah/firefly/internal/engine/input"
+	"github.com/leandroatallah/firefly/internal/engine/scene"
+	"github.com/leandroatallah/firefly/internal/engine/scene/transition"
+	"github.com/leandroatallah/firefly/internal/engine/ui/widget"
+)
+
+// OptionsScene sets the volume of the audio buses, rebinds the action keys
+// and switches the language. It is opened from the main menu, or pushed
+// over the pause menu.
+type OptionsScene struct {
+	scene.OverlayScene
+
+	ui       *widget.Screen
+	bindings *widget.List
+	language *widget.Button
+	// Action waiting for a key press to be rebound
+	rebinding input.Action
+}
+
+func NewOptionsScene(context *app.AppContext) *OptionsScene {
+	scene := OptionsScene{}
+	scene.SetAppContext(context)
+	scene.CaptureInput = true
+
+	am := context.AudioManager
+	volume := func(text string, bus audio.Bus) *widget.Slider {
+		return widget.NewSlider(text, am.BusVolume(bus), 0, 1, 0.1, func(v float64) {
+			am.SetBusVolume(bus, v)
+		})
+	}
+
+	scene.bindings = widget.NewList(nil, 4, scene.startRebinding)
+	scene.refreshBindings()
+
+	scene.language = widget.NewButton("", scene.nextLanguage)
+	scene.refreshLanguage()
+
+	title := widget.NewLabel("options.title")
+	title.Centered = true
+	root := widget.NewPanel(widget.NewVBox(
+		title,
+		volume("options.master", audio.BusMaster),
+		volume("options.music", audio.BusMusic),
+		volume("options.effects", audio.BusSFX),
+		scene.bindings,
+		widget.NewButton("options.reset_keys", func() {
+			input.ResetBindings()
+			scene.refreshBindings()
+		}),
+		widget.NewToggle("options.captions", context.Config.Captions, func(v bool) {
+			context.Config.Captions = v
+			if !v {
+				context.Captions.Clear()
+			}
+		}),
+		scene.language,
+		widget.NewButton("common.back", scene.back),
+	))
+	scene.ui = widget.NewScreen(newMenuTheme(), root)
+	scene.ui.OnCancel = scene.back
+	scene.ui.Layout(screenArea())
+	return &scene
+}
+
+func (s *OptionsScene) refreshBindings() {
+	items := make([]string, len(input.Actions))
+	for i, a := range input.Actions {
+		key := "..."
+		if a != s.rebinding {
+			key = input.PrimaryKey(a).String()
+		}
+		items[i] = fmt.Sprintf("%-9s %s", i18n.T("action."+string(a)), key)
+	}
+	s.bindings.Items = items
+}
+
+func (s *OptionsScene) refreshLanguage() {
+	s.language.Text = i18n.T("options.language", i18n.Args{"name": i18n.T("language.name")})
+}
+
+// nextLanguage switches to the next loaded locale and lays the screen out
+// again for the new texts.
+func (s *OptionsScene) nextLanguage() {
+	l := i18n.Default()
+	locales := l.Locales()
+	if len(locales) == 0 {
+		return
+	}
+	i := (slices.Index(locales, l.Locale()) + 1) % len(locales)
+	if err := l.SetLocale(locales[i]); err != nil {
+		return
+	}
+	s.refreshLanguage()
+	s.refreshBindings()
+	s.ui.Layout(screenArea())
+}
+
+func (s *OptionsScene) startRebinding(i int) {
+	s.rebinding = input.Actions[i]
+	s.refreshBindings()
+}
+
+// rebind replaces the primary key of the action being rebound with the next
+// key pressed. Escape keeps the current key.
+func (s *OptionsScene) rebind() {
+	k, ok := input.JustPressedKey()
+	if !ok {
+		return
+	}
+	if k != ebiten.KeyEscape {
+		keys := input.Keys(s.rebinding)
+		if len(keys) == 0 {
+			keys = []ebiten.Key{k}
+		} else {
+			keys = append([]ebiten.Key{k}, keys[1:]...)
+		}
+		input.Bind(s.rebinding, keys...)
+	}
+	s.rebinding = ""
+	s.refreshBindings()
+}
+
+func (s *OptionsScene) back() {
+	s.AppContext().SceneManager.NavigateBack(transition.NewFader())
+}
+
+func (s *OptionsScene) Update() error {
+	if s.rebinding != "" {
+		s.rebind()
+		return nil
+	}
+	s.ui.Update()
+	return nil
+}
+
+func (s *OptionsScene) Draw(screen *ebiten.Image) {
+	screen.Fill(menuBackground)
+	s.ui.Draw(screen)
+}
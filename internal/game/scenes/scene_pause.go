@@ -0,0 +1,87 @@
+package gamescene
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/app"
+	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	"github.com/leandroatallah/firefly/internal/engine/input"
+	"github.com/leandroatallah/firefly/internal/engine/scene"
+	"github.com/leandroatallah/firefly/internal/engine/scene/transition"
+	"github.com/leandroatallah/firefly/internal/engine/ui/widget"
+	scenestypes "github.com/leandroatallah/firefly/internal/game/scenes/types"
+)
+
+// PauseScene is drawn over the paused gameplay, which stops updating.
+type PauseScene struct {
+	scene.OverlayScene
+
+	ui     *widget.Screen
+	dither *ebiten.Image
+	// opened is set on the frame the pause was pressed to open the menu
+	opened bool
+}
+
+func NewPauseScene(context *app.AppContext) *PauseScene {
+	scene := PauseScene{}
+	scene.SetAppContext(context)
+	scene.DrawBelow = true
+	scene.CaptureInput = true
+
+	title := widget.NewLabel("pause.title")
+	title.Centered = true
+	root := widget.NewPanel(widget.NewVBox(
+		title,
+		widget.NewButton("pause.resume", scene.resume),
+		widget.NewButton("menu.options", func() {
+			context.SceneManager.Push(scenestypes.SceneOptions, true)
+		}),
+		widget.NewButton("pause.quit", func() {
+			context.SceneManager.NavigateTo(scenestypes.SceneMenu, transition.NewFader(), true)
+		}),
+	))
+	scene.ui = widget.NewScreen(newMenuTheme(), root)
+	scene.ui.OnCancel = scene.resume
+	scene.ui.Layout(screenArea())
+
+	// Darken every other pixel of the gameplay
+	cfg := config.Get()
+	scene.dither = ebiten.NewImage(cfg.ScreenWidth, cfg.ScreenHeight)
+	for x := 0; x < cfg.ScreenWidth; x += 2 {
+		for y := 0; y < cfg.ScreenHeight; y += 2 {
+			scene.dither.Set(x, y, color.Black)
+		}
+	}
+	return &scene
+}
+
+func (s *PauseScene) resume() {
+	s.AppContext().SceneManager.Pop()
+}
+
+func (s *PauseScene) OnStart() {
+	s.opened = true
+}
+
+// Update resumes on pause too, except on the frame the menu opened, and
+// only once when a key is bound to both pause and cancel.
+func (s *PauseScene) Update() error {
+	s.ui.Update()
+	if s.AppContext().SceneManager.Top() != s {
+		return nil
+	}
+	if s.opened {
+		s.opened = false
+		return nil
+	}
+	if input.IsActionJustPressed(input.ActionPause) {
+		s.resume()
+	}
+	return nil
+}
+
+func (s *PauseScene) Draw(screen *ebiten.Image) {
+	screen.DrawImage(s.dither, nil)
+	s.ui.Draw(screen)
+}
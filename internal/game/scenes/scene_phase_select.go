@@ -0,0 +1,69 @@
+package gamescene
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leandroatallah/firefly/internal/engine/app"
+	"github.com/leandroatallah/firefly/internal/engine/data/i18n"
+	"github.com/leandroatallah/firefly/internal/engine/scene"
+	"github.com/leandroatallah/firefly/internal/engine/scene/transition"
+	"github.com/leandroatallah/firefly/internal/engine/ui/widget"
+	scenestypes "github.com/leandroatallah/firefly/internal/game/scenes/types"
+)
+
+// PhaseSelectScene starts any phase of the phase manager.
+type PhaseSelectScene struct {
+	scene.BaseScene
+
+	ui *widget.Screen
+}
+
+func NewPhaseSelectScene(context *app.AppContext) *PhaseSelectScene {
+	scene := PhaseSelectScene{}
+	scene.SetAppContext(context)
+
+	phases := context.PhaseManager.Phases()
+	names := make([]string, len(phases))
+	for i, p := range phases {
+		names[i] = i18n.T("phase.name", i18n.Args{"id": p.ID})
+	}
+	list := widget.NewList(names, 6, func(i int) {
+		if err := context.PhaseManager.SetCurrentPhase(phases[i].ID); err != nil {
+			return
+		}
+		context.SceneManager.NavigateTo(scenestypes.ScenePhases, transition.NewFader(), true)
+	})
+	for i, p := range phases {
+		if p.ID == context.PhaseManager.CurrentPhase {
+			list.Select(i)
+		}
+	}
+
+	title := widget.NewLabel("phase_select.title")
+	title.Centered = true
+	count := widget.NewLabel(i18n.N("phase_select.count", len(phases)))
+	count.Centered = true
+	root := widget.NewPanel(widget.NewVBox(
+		title,
+		count,
+		list,
+		widget.NewButton("common.back", scene.back),
+	))
+	scene.ui = widget.NewScreen(newMenuTheme(), root)
+	scene.ui.OnCancel = scene.back
+	scene.ui.Layout(screenArea())
+	return &scene
+}
+
+func (s *PhaseSelectScene) back() {
+	s.AppContext().SceneManager.NavigateTo(scenestypes.SceneMenu, transition.NewFader(), true)
+}
+
+func (s *PhaseSelectScene) Update() error {
+	s.ui.Update()
+	return nil
+}
+
+func (s *PhaseSelectScene) Draw(screen *ebiten.Image) {
+	screen.Fill(menuBackground)
+	s.ui.Draw(screen)
+}
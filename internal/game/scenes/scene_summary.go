@@ -6,13 +6,14 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
-	"github.com/leandroatallah/firefly/internal/engine/data/config"
 	"github.com/leandroatallah/firefly/internal/engine/app"
 	"github.com/leandroatallah/firefly/internal/engine/assets/font"
+	"github.com/leandroatallah/firefly/internal/engine/audio"
+	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	"github.com/leandroatallah/firefly/internal/engine/data/i18n"
 	"github.com/leandroatallah/firefly/internal/engine/render/screenutil"
-	"github.com/leandroatallah/firefly/internal/engine/scene/transition"
 	"github.com/leandroatallah/firefly/internal/engine/scene"
-	"github.com/leandroatallah/firefly/internal/engine/audio"
+	"github.com/leandroatallah/firefly/internal/engine/scene/transition"
 	scenestypes "github.com/leandroatallah/firefly/internal/game/scenes/types"
 )
 
@@ -37,7 +38,7 @@ func NewSummaryScene(context *app.AppContext) *SummaryScene {
 
 func (s *SummaryScene) Draw(screen *ebiten.Image) {
 	screen.Fill(color.RGBA{A: 255})
-	screenutil.DrawCenteredText(screen, s.fontText, "Summary screen", 10, color.White)
+	screenutil.DrawCenteredText(screen, s.fontText, i18n.T("summary.title"), 10, color.White)
 }
 
 func (s *SummaryScene) Update() error {
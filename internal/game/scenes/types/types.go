@@ -8,4 +8,9 @@ const (
 	ScenePhases
 	SceneSummary
 	ScenePhaseReboot
+	SceneOptions
+	ScenePhaseSelect
+	ScenePause
+	SceneInventory
+	SceneDialogue
 )
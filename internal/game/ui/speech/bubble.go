@@ -1,26 +1,30 @@
 package gamespeech
 
 import (
+	stdimage "image"
 	"image/color"
 	"log"
 	"math"
+	"unicode/utf8"
 
 	"github.com/ebitenui/ebitenui/image"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
-	"github.com/leandroatallah/firefly/internal/engine/data/config"
+	"github.com/leandroatallah/firefly/internal/engine/data/i18n"
 	"github.com/leandroatallah/firefly/internal/engine/ui/speech"
 )
 
 const (
 	padding             = 8 // Increased padding for the new bubble style
+	minHeight           = 52
 	minMargin           = 6
 	maxMargin           = 24
 	delayBeforeRemove   = 12
 	delayBeforeSpelling = 60
 	speedText           = 4
 	animDuration        = 15
+	namePlatePadding    = 3
 )
 
 type SpeechBubble struct {
@@ -31,6 +35,15 @@ type SpeechBubble struct {
 	speedText int
 	nineSlice *image.NineSlice
 	indicator *ebiten.Image
+
+	// Lines of the last wrapped text
+	wrapped   *speech.RichText
+	wrapWidth int
+	lines     []speech.Line
+
+	speaker  *speech.Speaker
+	choices  []string
+	selected int
 }
 
 func NewSpeechBubble(fontSource *speech.SpeechFont) *SpeechBubble {
@@ -56,6 +69,15 @@ func NewSpeechBubble(fontSource *speech.SpeechFont) *SpeechBubble {
 	}
 }
 
+func (s *SpeechBubble) SetSpeaker(speaker *speech.Speaker) {
+	s.speaker = speaker
+}
+
+func (s *SpeechBubble) SetChoices(choices []string, selected int) {
+	s.choices = choices
+	s.selected = selected
+}
+
 func (s *SpeechBubble) Update() error {
 	if err := s.SpeechBase.Update(); err != nil {
 		return err
@@ -112,17 +134,22 @@ func (s *SpeechBubble) Draw(screen *ebiten.Image, msg string) {
 	var x, y float64
 	var w, h int
 
-	// Resting state properties
-	w_rest := float64(config.Get().ScreenWidth - minMargin*2)
-	h_rest := float64(52)
-	x_rest := float64(minMargin)
+	rich, spelled := s.RichText(msg, s.GetSpeed())
+	portrait := s.portrait()
+
+	// Resting state properties, within the area of the screen drawn in,
+	// e.g. a viewport of a split screen
+	area := screen.Bounds()
+	w_rest := float64(area.Dx() - minMargin*2)
+	h_rest := s.restHeight(rich, int(w_rest), portrait, area)
+	x_rest := float64(area.Min.X + minMargin)
 	var y_rest float64
 
 	if s.GetPosition() == "top" {
-		y_rest = float64(minMargin)
+		y_rest = float64(area.Min.Y + minMargin)
 	} else {
 		// Default to bottom
-		y_rest = float64(config.Get().ScreenHeight) - h_rest - float64(minMargin)
+		y_rest = float64(area.Max.Y) - h_rest - float64(minMargin)
 	}
 
 	const animDurationLocal = float64(animDuration)
@@ -156,26 +183,37 @@ func (s *SpeechBubble) Draw(screen *ebiten.Image, msg string) {
 		opts.GeoM.Translate(x, y)
 	})
 
-	// --- Draw Text ---
-	textStr := s.Text(msg) // Get current text
+	// --- Draw Portrait ---
 	textX := x + padding
 	textY := y + padding
 	textW := w - padding*2
 	textH := h - padding*2
+	if portrait != nil && progress >= 1 && !s.ending {
+		op := &ebiten.DrawImageOptions{}
+		op.GeoM.Translate(textX, textY)
+		screen.DrawImage(portrait, op)
 
+		pw := float64(portrait.Bounds().Dx()) + padding
+		textX += pw
+		textW -= int(pw)
+	}
+
+	// --- Draw Name Plate ---
+	if s.speaker != nil && s.speaker.Name != "" && progress >= 1 && !s.ending {
+		s.drawNamePlate(screen, x, y)
+	}
+
+	// --- Draw Text ---
 	if textW > 0 && textH > 0 {
-		textArea := ebiten.NewImage(textW, textH)
-		op := &text.DrawOptions{
-			LayoutOptions: text.LayoutOptions{
-				LineSpacing: s.SpeechBase.FontSource.LineSpacing,
-			},
-		}
-		op.ColorScale.ScaleWithColor(color.Black)
-		s.FontSource.Draw(textArea, textStr, op)
+		tx, ty := int(textX), int(textY)
+		textArea := screen.SubImage(stdimage.Rect(tx, ty, tx+textW, ty+textH)).(*ebiten.Image)
+		s.FontSource.DrawRich(textArea, rich, s.lines, spelled, float64(tx), float64(ty), color.Black, s.Count())
+	}
 
-		textAreaOp := &ebiten.DrawImageOptions{}
-		textAreaOp.GeoM.Translate(textX, textY)
-		screen.DrawImage(textArea, textAreaOp)
+	// --- Draw Choices ---
+	if len(s.choices) > 0 && s.IsSpellingComplete() && !s.ending {
+		s.drawChoices(screen, x+float64(w), y, float64(h))
+		return
 	}
 
 	// --- Draw Indicator ---
@@ -196,6 +234,87 @@ func (s *SpeechBubble) Draw(screen *ebiten.Image, msg string) {
 	}
 }
 
+// restHeight wraps the text to the width of the bubble and returns the
+// height fitting its lines and the portrait, within the area.
+func (s *SpeechBubble) restHeight(rich *speech.RichText, w int, portrait *ebiten.Image, area stdimage.Rectangle) float64 {
+	textW := w - padding*2
+	if portrait != nil {
+		textW -= portrait.Bounds().Dx() + padding
+	}
+	if textW <= 0 {
+		return minHeight
+	}
+	if rich != s.wrapped || textW != s.wrapWidth {
+		s.lines = s.FontSource.Wrap(rich, float64(textW))
+		s.wrapped, s.wrapWidth = rich, textW
+	}
+
+	h := int(math.Ceil(float64(len(s.lines))*s.FontSource.LineSpacing)) + padding*2
+	if portrait != nil {
+		h = max(h, portrait.Bounds().Dy()+padding*2)
+	}
+	return float64(max(min(h, area.Dy()-minMargin*2), minHeight))
+}
+
+func (s *SpeechBubble) portrait() *ebiten.Image {
+	if s.speaker == nil {
+		return nil
+	}
+	return s.speaker.PortraitImage()
+}
+
+// drawNamePlate draws the speaker name on a small plate over the top edge of the bubble.
+func (s *SpeechBubble) drawNamePlate(screen *ebiten.Image, x, y float64) {
+	fontSize := float64(s.FontSource.Size())
+	name := i18n.T(s.speaker.Name)
+	w := int(fontSize)*utf8.RuneCountInString(name) + namePlatePadding*2
+	h := int(fontSize) + namePlatePadding*2
+	px := x + padding
+	py := y - float64(h)/2
+
+	s.nineSlice.Draw(screen, w, h, func(opts *ebiten.DrawImageOptions) {
+		opts.GeoM.Translate(px, py)
+	})
+	op := &text.DrawOptions{}
+	op.GeoM.Translate(px+namePlatePadding, py+namePlatePadding)
+	op.ColorScale.ScaleWithColor(color.Black)
+	s.FontSource.Draw(screen, name, op)
+}
+
+// drawChoices lists the choices in a panel next to the right edge of the
+// bubble, on its outer side.
+func (s *SpeechBubble) drawChoices(screen *ebiten.Image, right, y, h float64) {
+	fontSize := float64(s.FontSource.Size())
+	lineH := s.FontSource.LineSpacing
+
+	longest := 0
+	for _, c := range s.choices {
+		longest = max(longest, utf8.RuneCountInString(c))
+	}
+	w := int(fontSize)*(longest+2) + padding*2
+	ph := int(lineH)*len(s.choices) + padding*2 - int(lineH-fontSize)
+
+	px := right - float64(w)
+	py := y - float64(ph) - 2
+	if s.GetPosition() == "top" {
+		py = y + h + 2
+	}
+
+	s.nineSlice.Draw(screen, w, ph, func(opts *ebiten.DrawImageOptions) {
+		opts.GeoM.Translate(px, py)
+	})
+	for i, c := range s.choices {
+		line := "  " + c
+		if i == s.selected {
+			line = "> " + c
+		}
+		op := &text.DrawOptions{}
+		op.GeoM.Translate(px+padding, py+padding+float64(i)*lineH)
+		op.ColorScale.ScaleWithColor(color.Black)
+		s.FontSource.Draw(screen, line, op)
+	}
+}
+
 func (s *SpeechBubble) ImageOptions() *ebiten.DrawImageOptions {
 	return nil
 }
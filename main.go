@@ -8,7 +8,8 @@ import (
 	"github.com/leandroatallah/firefly/internal/engine/data/config"
 	_ "github.com/leandroatallah/firefly/internal/engine/entity/actors" // Blank import to ensure init() is called
 	gamesetup "github.com/leandroatallah/firefly/internal/game/app"
-	_ "github.com/leandroatallah/firefly/internal/game/entity/actors/states" // Blank import to ensure init() is called
+	_ "github.com/leandroatallah/firefly/internal/game/entity/actors/movement" // Blank import to ensure init() is called
+	_ "github.com/leandroatallah/firefly/internal/game/entity/actors/states"   // Blank import to ensure init() is called
 )
 
 //go:embed assets/*